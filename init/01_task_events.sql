@@ -0,0 +1,56 @@
+-- outbox of the task events, written in the transaction of the change,
+-- relayed to the event handlers and streamed to the clients through GET /events
+CREATE TABLE IF NOT EXISTS task_events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(64) NOT NULL,
+    task_id INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    recipients JSONB NOT NULL DEFAULT '[]',
+    dispatched_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_recipients ON task_events USING GIN (recipients);
+CREATE INDEX IF NOT EXISTS idx_task_events_pending ON task_events (id) WHERE dispatched_at IS NULL;
+
+-- events already handled by each event handler, recorded with the changes of the handler
+-- so that a redelivered event is skipped by the handlers which have processed it
+CREATE TABLE IF NOT EXISTS processed_events (
+    handler VARCHAR(64) NOT NULL,
+    event_id BIGINT NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (handler, event_id)
+);
+
+-- an event a handler keeps failing on is given up after the relay attempts run out, failed_at
+-- takes it out of the pending events so that the events behind it are relayed, clearing
+-- failed_at queues it again
+ALTER TABLE IF EXISTS task_events ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE IF EXISTS task_events ADD COLUMN IF NOT EXISTS last_error TEXT NOT NULL DEFAULT '';
+ALTER TABLE IF EXISTS task_events ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP WITH TIME ZONE;
+
+DROP INDEX IF EXISTS idx_task_events_pending;
+CREATE INDEX IF NOT EXISTS idx_task_events_pending ON task_events (id) WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_task_events_failed ON task_events (id) WHERE failed_at IS NOT NULL;
+
+-- append-only audit log of every task event, written by the "audit" event handler
+CREATE TABLE IF NOT EXISTS task_audit_logs (
+    event_id BIGINT PRIMARY KEY,
+    type VARCHAR(64) NOT NULL,
+    task_id INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    recipients JSONB NOT NULL DEFAULT '[]',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_audit_logs_task_id ON task_audit_logs (task_id, event_id);
+
+-- assignees already notified of a task by the "notification" event handler, so that an
+-- assignee is emailed once when they are assigned and not on every later change of the assignees
+CREATE TABLE IF NOT EXISTS task_assignee_notifications (
+    task_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    notified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
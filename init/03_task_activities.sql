@@ -0,0 +1,11 @@
+-- audit trail of the task mutations returned by GET /tasks/:taskID/activity
+CREATE TABLE IF NOT EXISTS task_activities (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL,
+    actor_id INTEGER NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_activities_task_id ON task_activities (task_id, id);
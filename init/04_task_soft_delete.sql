@@ -0,0 +1,2 @@
+-- deleted tasks stay in the trash until they are purged after the retention period (TRASH_RETENTION_DAYS)
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
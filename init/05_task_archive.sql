@@ -0,0 +1,10 @@
+-- archived tasks are hidden from the task list and read-only until they are unarchived
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+-- settings default to the zero value when a user has no row
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id INTEGER PRIMARY KEY,
+    auto_archive_days INTEGER NOT NULL DEFAULT 0 CHECK (auto_archive_days >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
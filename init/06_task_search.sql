@@ -0,0 +1,20 @@
+-- full-text search over tasks (GET /search).
+-- the 'simple' configuration does not stem, so English and Japanese words are indexed as they are.
+-- Japanese has no spaces between words, so the trigram indexes back the substring match for it.
+-- comments should be added to search_vector once they exist.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS tasks_search_vector_idx ON tasks USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS tasks_title_trgm_idx ON tasks USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS tasks_description_trgm_idx ON tasks USING GIN (description gin_trgm_ops);
+
+-- search_vector is written by the "search" event handler from the task events instead of
+-- being generated, so that it can index more than the columns of the task. Dropping the
+-- expression keeps the values already computed.
+ALTER TABLE IF EXISTS tasks ALTER COLUMN search_vector DROP EXPRESSION IF EXISTS;
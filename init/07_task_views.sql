@@ -0,0 +1,15 @@
+-- saved views (named task filters), the built-in views are defined in the application
+CREATE TABLE IF NOT EXISTS task_views (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_views_user_id ON task_views (user_id);
+
+-- a shared view is listed to every user of the workspace, each user runs it on the tasks they can read
+ALTER TABLE IF EXISTS task_views ADD COLUMN IF NOT EXISTS shared BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_task_views_shared ON task_views (shared) WHERE shared;
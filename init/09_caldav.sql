@@ -0,0 +1,23 @@
+-- personal credentials of the clients which authenticate by Basic authentication,
+-- only the SHA-256 hash of the password is stored
+CREATE TABLE IF NOT EXISTS app_passwords (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    password_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_app_passwords_user_id ON app_passwords (user_id);
+
+-- resource names and UIDs of the tasks created by CalDAV clients, a name belongs to
+-- the user of the client, the other tasks and users are served task-<id>.ics
+CREATE TABLE IF NOT EXISTS cal_dav_resources (
+    task_id INTEGER PRIMARY KEY REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    uid VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cal_dav_resources_user_id_name ON cal_dav_resources (user_id, name);
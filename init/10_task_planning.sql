@@ -0,0 +1,22 @@
+-- priority and estimated effort of the tasks, the task list is sorted and filtered by the priority
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS priority VARCHAR(16) NOT NULL DEFAULT 'none'
+    CHECK (priority IN ('none', 'low', 'medium', 'high', 'urgent'));
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS estimate_minutes INTEGER CHECK (estimate_minutes >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks (priority);
+
+-- time tracked on the tasks by the timers of the users, stopped_at is null while the timer runs.
+-- the entries go away with the task when it is purged from the trash.
+CREATE TABLE IF NOT EXISTS time_entries (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    stopped_at TIMESTAMP WITH TIME ZONE,
+    CHECK (stopped_at IS NULL OR stopped_at >= started_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries (task_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_user_id ON time_entries (user_id, started_at);
+-- a user has at most one running timer
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries (user_id) WHERE stopped_at IS NULL;
@@ -0,0 +1,5 @@
+-- users who must do the task, separate from the permissions deciding who can see it.
+-- every assignee is granted read access, the list is matched with @> by the assignee filter.
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS assignees JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_assignees ON tasks USING GIN (assignees);
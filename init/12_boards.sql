@@ -0,0 +1,24 @@
+-- labels of the tasks, matched with @> by the label filter and the board columns
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_labels ON tasks USING GIN (labels);
+
+-- kanban boards, the columns map to a status or a label of the tasks
+CREATE TABLE IF NOT EXISTS boards (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    columns JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_boards_user_id ON boards (user_id);
+
+-- position of the tasks on the boards. the ranks are compared byte by byte,
+-- a card is moved by rewriting its own rank only.
+CREATE TABLE IF NOT EXISTS board_cards (
+    board_id INTEGER NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+    task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    rank VARCHAR(255) COLLATE "C" NOT NULL,
+    PRIMARY KEY (board_id, task_id)
+);
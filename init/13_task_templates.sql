@@ -0,0 +1,19 @@
+-- subtasks point to their parent task, a subtask becomes a top-level task when the parent is deleted
+ALTER TABLE IF EXISTS tasks ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES tasks (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
+
+-- templates of a task and its subtasks, the texts may hold {{placeholders}}
+CREATE TABLE IF NOT EXISTS task_templates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    subtasks JSONB NOT NULL DEFAULT '[]',
+    labels JSONB NOT NULL DEFAULT '[]',
+    due_offset VARCHAR(20) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_templates_user_id ON task_templates (user_id);
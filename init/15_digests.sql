@@ -0,0 +1,13 @@
+-- daily digest email of the overdue and the due today tasks, sent at digest_hour in the user's timezone
+ALTER TABLE IF EXISTS user_settings ADD COLUMN IF NOT EXISTS digest_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE IF EXISTS user_settings ADD COLUMN IF NOT EXISTS digest_hour INTEGER NOT NULL DEFAULT 8 CHECK (digest_hour BETWEEN 0 AND 23);
+ALTER TABLE IF EXISTS user_settings ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo';
+ALTER TABLE IF EXISTS user_settings ADD COLUMN IF NOT EXISTS locale VARCHAR(8) NOT NULL DEFAULT 'ja';
+
+-- one row per user and day, claimed before the digest is sent so that it is sent only once
+CREATE TABLE IF NOT EXISTS digest_deliveries (
+    user_id INTEGER NOT NULL,
+    digest_date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, digest_date)
+);
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type AppPasswordController struct {
+	AppPasswordUsecase domain.AppPasswordUsecase
+}
+
+func (ac *AppPasswordController) Create(c *gin.Context) {
+	// binding json request
+	var request domain.AppPasswordCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	appPassword, err := ac.AppPasswordUsecase.Create(c, user.ID, request.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "created", AppPasswords: []domain.AppPassword{*appPassword}})
+}
+
+func (ac *AppPasswordController) FetchAllAppPasswordByUserID(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	appPasswords, err := ac.AppPasswordUsecase.FetchAppPasswordsByUserID(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", AppPasswords: appPasswords})
+}
+
+func (ac *AppPasswordController) Delete(c *gin.Context) {
+	// get id from path
+	var request domain.AppPasswordDeleteRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := ac.AppPasswordUsecase.Delete(c, request.ID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "deleted"})
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type BoardController struct {
+	BoardUsecase domain.BoardUsecase
+}
+
+func (bc *BoardController) Create(c *gin.Context) {
+	// binding json request
+	var request domain.BoardCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	board, err := bc.BoardUsecase.Create(c, user.ID, request.Name, request.Columns)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "created", Boards: []domain.Board{*board}})
+}
+
+func (bc *BoardController) FetchAllBoardByUserID(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	boards, err := bc.BoardUsecase.FetchBoardsByUserID(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Boards: boards})
+}
+
+// FetchBoardByID returns the board with the cards of each column.
+func (bc *BoardController) FetchBoardByID(c *gin.Context) {
+	// get id from path
+	var request domain.BoardFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	board, err := bc.BoardUsecase.FetchBoardByID(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Boards: []domain.Board{*board}})
+}
+
+func (bc *BoardController) Update(c *gin.Context) {
+	// get id from path
+	var uri domain.BoardFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.BoardCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	board, err := bc.BoardUsecase.Update(c, uri.ID, user.ID, request.Name, request.Columns)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "updated", Boards: []domain.Board{*board}})
+}
+
+func (bc *BoardController) Delete(c *gin.Context) {
+	// get id from path
+	var request domain.BoardFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := bc.BoardUsecase.Delete(c, request.ID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "deleted"})
+}
+
+// Move moves a task to a column and a position of the board.
+func (bc *BoardController) Move(c *gin.Context) {
+	// get id from path
+	var uri domain.BoardFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.BoardMoveRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	card, err := bc.BoardUsecase.Move(c, uri.ID, user.ID, request.TaskID, *request.Column, *request.Position)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "moved", Card: card})
+}
@@ -0,0 +1,374 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/caldav"
+	"github.com/keitatwr/task-management-app/internal/ical"
+	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+const (
+	// CalDAVPrincipalPath is the principal and the calendar home of every user,
+	// which holds the single task collection.
+	CalDAVPrincipalPath  = "/caldav/"
+	CalDAVCollectionPath = "/caldav/tasks/"
+
+	calDAVMaxObjectSize = 1 << 20
+	calDAVContentType   = "text/calendar; charset=utf-8; component=VTODO"
+)
+
+type CalDAVController struct {
+	CalDAVUsecase domain.CalDAVUsecase
+}
+
+func (dc *CalDAVController) Options(c *gin.Context) {
+	c.Header("DAV", "1, 3, calendar-access")
+	c.Header("Allow", "OPTIONS, GET, PUT, DELETE, PROPFIND, REPORT")
+	c.Status(http.StatusOK)
+}
+
+func (dc *CalDAVController) PropfindPrincipal(c *gin.Context) {
+	request, ok := dc.bindPropfind(c)
+	if !ok {
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	responses := []caldav.Response{
+		caldav.NewResponse(CalDAVPrincipalPath, request, []caldav.Property{
+			caldav.Raw(caldav.ResourceType, "<d:collection/><d:principal/>"),
+			caldav.Text(caldav.DisplayName, user.Name),
+			caldav.Href(caldav.CurrentUserPrincipal, CalDAVPrincipalPath),
+			caldav.Href(caldav.PrincipalURL, CalDAVPrincipalPath),
+			caldav.Href(caldav.CalendarHomeSet, CalDAVPrincipalPath),
+		}),
+	}
+	if c.GetHeader("Depth") != "0" {
+		properties, err := dc.collectionProperties(c, user.ID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		responses = append(responses, caldav.NewResponse(CalDAVCollectionPath, request, properties))
+	}
+	dc.multistatus(c, responses, "")
+}
+
+func (dc *CalDAVController) PropfindCollection(c *gin.Context) {
+	request, ok := dc.bindPropfind(c)
+	if !ok {
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	properties, err := dc.collectionProperties(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	responses := []caldav.Response{caldav.NewResponse(CalDAVCollectionPath, request, properties)}
+
+	if c.GetHeader("Depth") != "0" {
+		objects, err := dc.CalDAVUsecase.FetchObjects(c, user.ID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		for _, object := range objects {
+			responses = append(responses, objectResponse(request, object))
+		}
+	}
+	dc.multistatus(c, responses, "")
+}
+
+func (dc *CalDAVController) PropfindObject(c *gin.Context) {
+	request, ok := dc.bindPropfind(c)
+	if !ok {
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	object, err := dc.CalDAVUsecase.FetchObjectByName(c, user.ID, c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	dc.multistatus(c, []caldav.Response{objectResponse(request, *object)}, "")
+}
+
+func (dc *CalDAVController) Report(c *gin.Context) {
+	report, err := caldav.ParseReport(c.Request.Body)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	switch report.Name {
+	case caldav.ReportSyncCollection:
+		syncToken, err := caldav.ParseSyncToken(report.SyncToken)
+		if err != nil {
+			logger.W(c.Request.Context(), "occurred caldav error", err)
+			c.Data(http.StatusForbidden, "application/xml; charset=utf-8",
+				[]byte(`<?xml version="1.0" encoding="UTF-8"?><d:error xmlns:d="DAV:"><d:valid-sync-token/></d:error>`))
+			return
+		}
+		changes, err := dc.CalDAVUsecase.FetchChanges(c, user.ID, syncToken)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		responses := make([]caldav.Response, 0, len(changes.Changed)+len(changes.Deleted))
+		for _, object := range changes.Changed {
+			responses = append(responses, objectResponse(report.Prop, object))
+		}
+		for _, name := range changes.Deleted {
+			responses = append(responses, caldav.Response{Href: objectHref(name), Status: http.StatusNotFound})
+		}
+		dc.multistatus(c, responses, caldav.FormatSyncToken(changes.SyncToken))
+
+	case caldav.ReportCalendarMultiget:
+		objects, err := dc.CalDAVUsecase.FetchObjects(c, user.ID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		byName := make(map[string]domain.CalDAVObject, len(objects))
+		for _, object := range objects {
+			byName[object.Name] = object
+		}
+		responses := make([]caldav.Response, 0, len(report.Hrefs))
+		for _, href := range report.Hrefs {
+			if object, ok := byName[objectName(href)]; ok {
+				response := objectResponse(report.Prop, object)
+				// answer with the href as the client sent it
+				response.Href = href
+				responses = append(responses, response)
+			} else {
+				responses = append(responses, caldav.Response{Href: href, Status: http.StatusNotFound})
+			}
+		}
+		dc.multistatus(c, responses, "")
+
+	default:
+		objects, err := dc.CalDAVUsecase.FetchObjects(c, user.ID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		responses := make([]caldav.Response, 0, len(objects))
+		for _, object := range objects {
+			responses = append(responses, objectResponse(report.Prop, object))
+		}
+		dc.multistatus(c, responses, "")
+	}
+}
+
+func (dc *CalDAVController) Get(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	object, err := dc.CalDAVUsecase.FetchObjectByName(c, user.ID, c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var b strings.Builder
+	if err := ical.WriteTodoCalendar(ical.NewEncoder(&b), object.UID, object.Task, time.Now()); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("ETag", caldav.ETag(*object))
+	c.Data(http.StatusOK, calDAVContentType, []byte(b.String()))
+}
+
+func (dc *CalDAVController) Put(c *gin.Context) {
+	// parse the calendar object
+	todo, err := ical.ParseTodo(http.MaxBytesReader(c.Writer, c.Request.Body, calDAVMaxObjectSize))
+	if err != nil {
+		if errors.Is(err, ical.ErrNoTodo) {
+			err = myerror.ErrValidation.WrapWithDescription(err, "only VTODO components are supported")
+		}
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	name := c.Param("name")
+	if !dc.checkPreconditions(c, user.ID, name) {
+		return
+	}
+
+	_, created, err := dc.CalDAVUsecase.Put(c, user.ID, name, todo.UID, todo.Task)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	// no ETag is returned since the stored object drops the properties tasks do not have,
+	// the client fetches the object again
+	if created {
+		c.Status(http.StatusCreated)
+	} else {
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func (dc *CalDAVController) Delete(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	name := c.Param("name")
+	if !dc.checkPreconditions(c, user.ID, name) {
+		return
+	}
+	if err := dc.CalDAVUsecase.Delete(c, user.ID, name); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// checkPreconditions evaluates If-Match and If-None-Match against the current object,
+// so that a client does not overwrite the changes it has not synced yet.
+func (dc *CalDAVController) checkPreconditions(c *gin.Context, userID int, name string) bool {
+	ifMatch := c.GetHeader("If-Match")
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if ifMatch == "" && ifNoneMatch == "" {
+		return true
+	}
+
+	object, err := dc.CalDAVUsecase.FetchObjectByName(c, userID, name)
+	if err != nil && !errors.Is(err, myerror.ErrCalDAVObjectNotFound) {
+		c.Error(err)
+		return false
+	}
+
+	failed := false
+	switch {
+	case ifNoneMatch == "*":
+		failed = object != nil
+	case ifMatch != "":
+		failed = object == nil || (ifMatch != "*" && ifMatch != caldav.ETag(*object))
+	}
+	if failed {
+		c.Error(myerror.ErrPreconditionFailed.WithDescription("calendar object has been changed"))
+		return false
+	}
+	return true
+}
+
+func (dc *CalDAVController) collectionProperties(c *gin.Context, userID int) ([]caldav.Property, error) {
+	syncToken, err := dc.CalDAVUsecase.FetchSyncToken(c, userID)
+	if err != nil {
+		return nil, err
+	}
+	return []caldav.Property{
+		caldav.Raw(caldav.ResourceType, "<d:collection/><c:calendar/>"),
+		caldav.Text(caldav.DisplayName, "Tasks"),
+		caldav.Raw(caldav.SupportedCalendarComponentSet, `<c:comp name="VTODO"/>`),
+		caldav.Raw(caldav.SupportedReportSet,
+			"<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>"+
+				"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>"+
+				"<d:supported-report><d:report><d:sync-collection/></d:report></d:supported-report>"),
+		caldav.Href(caldav.CurrentUserPrincipal, CalDAVPrincipalPath),
+		caldav.Text(caldav.GetCTag, caldav.FormatSyncToken(syncToken)),
+		caldav.Text(caldav.SyncToken, caldav.FormatSyncToken(syncToken)),
+	}, nil
+}
+
+func (dc *CalDAVController) bindPropfind(c *gin.Context) (caldav.PropRequest, bool) {
+	request, err := caldav.ParsePropfind(c.Request.Body)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return request, false
+	}
+	return request, true
+}
+
+func (dc *CalDAVController) multistatus(c *gin.Context, responses []caldav.Response, syncToken string) {
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.Status(http.StatusMultiStatus)
+	if err := caldav.WriteMultistatus(c.Writer, responses, syncToken); err != nil {
+		logger.E(c.Request.Context(), "failed to write multistatus", err)
+	}
+}
+
+// objectResponse renders the calendar data only when it is requested.
+func objectResponse(request caldav.PropRequest, object domain.CalDAVObject) caldav.Response {
+	properties := []caldav.Property{
+		caldav.Raw(caldav.ResourceType, ""),
+		caldav.Text(caldav.GetETag, caldav.ETag(object)),
+		caldav.Text(caldav.GetContentType, calDAVContentType),
+	}
+	for _, name := range request.Names {
+		if name != caldav.CalendarData {
+			continue
+		}
+		var b strings.Builder
+		// writing to a strings.Builder does not fail
+		_ = ical.WriteTodoCalendar(ical.NewEncoder(&b), object.UID, object.Task, time.Now())
+		properties = append(properties, caldav.Text(caldav.CalendarData, b.String()))
+	}
+	return caldav.NewResponse(objectHref(object.Name), request, properties)
+}
+
+func objectHref(name string) string {
+	return CalDAVCollectionPath + url.PathEscape(name)
+}
+
+// objectName returns the resource name of a href in the task collection, the href may be an absolute URL.
+func objectName(href string) string {
+	u, err := url.Parse(href)
+	if err != nil || path.Dir(u.Path)+"/" != CalDAVCollectionPath {
+		return ""
+	}
+	return path.Base(u.Path)
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/ical"
+	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type CalendarController struct {
+	CalendarFeedUsecase domain.CalendarFeedUsecase
+}
+
+func (cc *CalendarController) CreateFeed(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	token, err := cc.CalendarFeedUsecase.CreateFeed(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "created", CalendarURL: feedURL(c.Request, token)})
+}
+
+func (cc *CalendarController) RevokeFeed(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := cc.CalendarFeedUsecase.RevokeFeed(c, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "revoked"})
+}
+
+// FetchFeed serves the iCalendar feed. It is a public route, the token in the path authenticates the user.
+func (cc *CalendarController) FetchFeed(c *gin.Context) {
+	// binding query
+	var request domain.CalendarFeedRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	tasks, err := cc.CalendarFeedUsecase.FetchTasksByToken(c, token, request.TaskFilter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	component := ical.ComponentVEVENT
+	if request.Component == domain.CalendarComponentTodo {
+		component = ical.ComponentVTODO
+	}
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Status(http.StatusOK)
+	if err := ical.WriteCalendar(ical.NewEncoder(c.Writer), "Tasks", component, tasks, time.Now()); err != nil {
+		logger.E(c.Request.Context(), "failed to write calendar feed", err)
+	}
+}
+
+// feedURL returns the absolute URL of the feed, which is subscribed from calendar clients.
+func feedURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return fmt.Sprintf("%s://%s%s/calendar/%s.ics", scheme, r.Host, APIBasePath, token)
+}
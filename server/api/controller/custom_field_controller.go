@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type CustomFieldController struct {
+	CustomFieldUsecase domain.CustomFieldUsecase
+}
+
+func (fc *CustomFieldController) Create(c *gin.Context) {
+	// binding json request
+	var request domain.CustomFieldCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	field, err := fc.CustomFieldUsecase.Create(c, user.ID, domain.CustomField{
+		Key:     request.Key,
+		Name:    request.Name,
+		Type:    request.Type,
+		Options: request.Options,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "created", CustomFields: []domain.CustomField{*field}})
+}
+
+func (fc *CustomFieldController) FetchFields(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	fields, err := fc.CustomFieldUsecase.FetchFields(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", CustomFields: fields})
+}
+
+func (fc *CustomFieldController) Update(c *gin.Context) {
+	// get id from path
+	var uri domain.CustomFieldFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.CustomFieldUpdateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	field, err := fc.CustomFieldUsecase.Update(c, uri.ID, user.ID, request.Name, request.Options)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "updated", CustomFields: []domain.CustomField{*field}})
+}
+
+// Delete deletes the custom field and its values on all the tasks.
+func (fc *CustomFieldController) Delete(c *gin.Context) {
+	// get id from path
+	var request domain.CustomFieldFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := fc.CustomFieldUsecase.Delete(c, request.ID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "deleted"})
+}
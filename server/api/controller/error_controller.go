@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/response"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/i18n"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+// ErrorController serves the definitions of the error codes, the type of a problem
+// details is the URI of its definition.
+type ErrorController struct{}
+
+func (ec *ErrorController) FetchCatalog(c *gin.Context) {
+	// binding query request
+	var request domain.ErrorCatalogRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	catalog := response.Catalog(catalogLocale(c, request.Locale))
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Catalog: &catalog})
+}
+
+func (ec *ErrorController) FetchDefinition(c *gin.Context) {
+	// binding uri and query request
+	var request domain.ErrorDefinitionRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	code := myerror.ErrorCode(request.Code)
+	if _, ok := myerror.ErrMessages[code]; !ok {
+		c.Error(myerror.ErrErrorDefinitionNotFound.WithDescription("unknown error code"))
+		return
+	}
+	definition := response.Definition(catalogLocale(c, request.Locale), code)
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Definition: &definition})
+}
+
+// catalogLocale returns the locale of the query, or the one of Accept-Language.
+func catalogLocale(c *gin.Context, locale string) string {
+	if locale != "" {
+		return locale
+	}
+	if locale, ok := i18n.Negotiate(c.GetHeader("Accept-Language")); ok {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
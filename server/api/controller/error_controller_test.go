@@ -0,0 +1,117 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupErrorRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.Use(middleware.ErrorMiddleware())
+	ec := controller.ErrorController{}
+	r.GET("/errors", ec.FetchCatalog)
+	r.GET("/errors/:code", ec.FetchDefinition)
+	return r
+}
+
+func TestFetchErrorCatalog(t *testing.T) {
+	tests := []struct {
+		title          string
+		target         string
+		acceptLanguage string
+		wantLocale     string
+		wantTitle      string
+		wantRequired   string
+	}{
+		{"default", "/errors", "", "en", "task not found", "{field} is required"},
+		{"accept language", "/errors", "ja-JP,ja;q=0.9", "ja", "タスクが見つかりません", "{field}は必須です"},
+		{"query", "/errors?locale=en", "ja", "en", "task not found", "{field} is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			r := setupErrorRouter()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var res domain.SuccessResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			if !assert.NotNil(t, res.Catalog) {
+				return
+			}
+			assert.Equal(t, tt.wantLocale, res.Catalog.Locale)
+			assert.Len(t, res.Catalog.Errors, len(myerror.ErrMessages))
+			assert.Contains(t, res.Catalog.Errors, domain.ErrorDefinition{
+				Code:   int(myerror.CodeTaskNotFound),
+				Type:   "/api/v1/errors/3001",
+				Title:  tt.wantTitle,
+				Status: http.StatusNotFound,
+			})
+			assert.Equal(t, tt.wantRequired, res.Catalog.Rules["required"])
+		})
+	}
+}
+
+func TestFetchErrorDefinition(t *testing.T) {
+	tests := []struct {
+		title        string
+		target       string
+		wantStatus   int
+		wantResponse any
+	}{
+		{
+			"success",
+			"/errors/2002",
+			http.StatusOK,
+			domain.SuccessResponse{Message: "fetched", Definition: &domain.ErrorDefinition{
+				Code: 2002, Type: "/api/v1/errors/2002", Title: "task is archived", Status: http.StatusConflict,
+			}},
+		},
+		{
+			"success in japanese",
+			"/errors/9999?locale=ja",
+			http.StatusOK,
+			domain.SuccessResponse{Message: "fetched", Definition: &domain.ErrorDefinition{
+				Code: 9999, Type: "/api/v1/errors/9999", Title: "予期しないエラーが発生しました", Status: http.StatusInternalServerError,
+			}},
+		},
+		{
+			"unknown code",
+			"/errors/1234",
+			http.StatusNotFound,
+			helper.Problem(http.StatusNotFound, myerror.CodeErrorDefinitionNotFound, "unknown error code"),
+		},
+		{
+			"unsupported locale",
+			"/errors/2002?locale=fr",
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: locale",
+				domain.FieldError{Field: "locale", Rule: "oneof", Param: "en ja", Message: "locale must be one of [en ja]"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			r := setupErrorRouter()
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantResponse, w)
+		})
+	}
+}
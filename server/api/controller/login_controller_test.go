@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/api/middleware"
 	"github.com/keitatwr/task-management-app/domain"
 	"github.com/keitatwr/task-management-app/internal/myerror"
 	"github.com/keitatwr/task-management-app/internal/security"
@@ -69,16 +70,7 @@ func TestLogin(t *testing.T) {
 			nil,
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing fields: Password",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: password", domain.FieldError{Field: "password", Rule: "required", Message: "password is required"}),
 		},
 		{
 			"validation error type missmatch",
@@ -86,16 +78,7 @@ func TestLogin(t *testing.T) {
 			nil,
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing field type: password, expect: string, actual: number",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "missing field type: password, expect: string, actual: number", domain.FieldError{Field: "password", Rule: "type", Param: "string", Message: "password must be of type string"}),
 		},
 		{
 			"validation error json syntax error",
@@ -103,16 +86,7 @@ func TestLogin(t *testing.T) {
 			nil,
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "json syntax error, offset: 30",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "json syntax error, offset: 30"),
 		},
 		{
 			"user not found",
@@ -123,16 +97,7 @@ func TestLogin(t *testing.T) {
 			},
 			nil,
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "failed to login",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeUserNotFound),
-						Message:     myerror.ErrMessages[myerror.CodeUserNotFound],
-						Description: "user not found",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeInvalidPassword, "invalid email or password"),
 		},
 		{
 			"fetch user failed",
@@ -143,16 +108,7 @@ func TestLogin(t *testing.T) {
 			},
 			nil,
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to login",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeQueryFailed),
-						Message:     myerror.ErrMessages[myerror.CodeQueryFailed],
-						Description: "failed to execute query",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeQueryFailed, ""),
 		},
 		{
 			"invalid passwrod",
@@ -167,16 +123,7 @@ func TestLogin(t *testing.T) {
 			},
 			&ErrMockPasswordComparer{},
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "failed to login",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeInvalidPassword),
-						Message:     myerror.ErrMessages[myerror.CodeInvalidPassword],
-						Description: "invalid password",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeInvalidPassword, "invalid email or password"),
 		},
 		{
 			"create session failed",
@@ -192,16 +139,7 @@ func TestLogin(t *testing.T) {
 			},
 			&MockPasswordComparer{},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to login",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeCreateSessionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeCreateSessionFailed],
-						Description: "failed to create session",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeCreateSessionFailed, "failed to create session"),
 		},
 	}
 
@@ -230,6 +168,7 @@ func TestLogin(t *testing.T) {
 			}
 
 			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
 			r.POST("/login", loginController.Login)
 			r.ServeHTTP(response, ctx.Request)
 
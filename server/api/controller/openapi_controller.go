@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/internal/openapi"
+)
+
+// APIBasePath is the prefix of the versioned REST API.
+const APIBasePath = "/api/v1"
+
+// swaggerUIPage renders the specification next to it with Swagger UI loaded from the CDN.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Task Management API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+type OpenAPIController struct {
+	Document *openapi.Document
+}
+
+func (oc *OpenAPIController) FetchSpec(c *gin.Context) {
+	c.JSON(http.StatusOK, oc.Document)
+}
+
+func (oc *OpenAPIController) SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/api/middleware"
 	"github.com/keitatwr/task-management-app/domain"
 	"github.com/keitatwr/task-management-app/internal/myerror"
 	"github.com/keitatwr/task-management-app/internal/security"
@@ -66,16 +67,7 @@ func TestSignupController(t *testing.T) {
 			nil,
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing fields: Name",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: name", domain.FieldError{Field: "name", Rule: "required", Message: "name is required"}),
 		},
 		{
 			"validation error two missing field",
@@ -83,16 +75,7 @@ func TestSignupController(t *testing.T) {
 			nil,
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing fields: Name, Email",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: name, email", domain.FieldError{Field: "name", Rule: "required", Message: "name is required"}, domain.FieldError{Field: "email", Rule: "required", Message: "email is required"}),
 		},
 		{
 			"validation error type mismatch",
@@ -100,16 +83,7 @@ func TestSignupController(t *testing.T) {
 			nil,
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing field type: password, expect: string, actual: number",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "missing field type: password, expect: string, actual: number", domain.FieldError{Field: "password", Rule: "type", Param: "string", Message: "password must be of type string"}),
 		},
 		{
 			"validation error json syntax error",
@@ -117,16 +91,7 @@ func TestSignupController(t *testing.T) {
 			nil,
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "json syntax error, offset: 16",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "json syntax error, offset: 16"),
 		},
 		{
 			"user already exists",
@@ -137,16 +102,7 @@ func TestSignupController(t *testing.T) {
 			},
 			nil,
 			http.StatusConflict,
-			domain.ErrorResponse{
-				Message: "user already exists",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeUserAlreadyExists),
-						Message:     myerror.ErrMessages[myerror.CodeUserAlreadyExists],
-						Description: "email 'test@example.com' is already exists",
-					},
-				},
-			},
+			helper.Problem(http.StatusConflict, myerror.CodeUserAlreadyExists, "email 'test@example.com' is already exists"),
 		},
 		{
 			"failed to hash password",
@@ -157,16 +113,7 @@ func TestSignupController(t *testing.T) {
 			},
 			&ErrMockPasswordHasher{},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to hash password",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeHashPasswordFailed),
-						Message:     myerror.ErrMessages[myerror.CodeHashPasswordFailed],
-						Description: "failed to hash password",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeHashPasswordFailed, "failed to hash password"),
 		},
 		{
 			"failed to create user",
@@ -179,16 +126,7 @@ func TestSignupController(t *testing.T) {
 			},
 			&MockPasswordHasher{},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to create user",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeQueryFailed),
-						Message:     myerror.ErrMessages[myerror.CodeQueryFailed],
-						Description: "failed to execute query",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeQueryFailed, ""),
 		},
 	}
 
@@ -218,6 +156,7 @@ func TestSignupController(t *testing.T) {
 
 			// run
 			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
 			r.POST("/signup", signupController.Signup)
 			r.ServeHTTP(response, ctx.Request)
 
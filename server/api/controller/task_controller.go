@@ -2,89 +2,179 @@ package controller
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/keitatwr/task-management-app/api/middleware"
 	"github.com/keitatwr/task-management-app/api/response"
 	"github.com/keitatwr/task-management-app/domain"
 	"github.com/keitatwr/task-management-app/internal/logger"
 	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/quickadd"
+	"github.com/keitatwr/task-management-app/internal/taskio"
 )
 
 type TaskController struct {
 	TaskUsecase domain.TaskUsecase
+	// UserSettingUsecase gives the user's timezone to the quick-add lines without a timezone.
+	UserSettingUsecase domain.UserSettingUsecase
 }
 
 func (tc *TaskController) Create(c *gin.Context) {
 	// binding json request
 	var request domain.TaskCreateRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
-		tc.handleValidationError(c, err)
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	// get user from context
 	user := middleware.GetUserContext(c)
 	if user == nil {
-		err := myerror.ErrContextUserNotFound.WithDescription("user not found in context")
-		logger.W(c.Request.Context(), "occurred context error", err)
-		response.Error(c, http.StatusUnauthorized, "unauthorized", err)
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
 		return
 	}
 	// create task
-	if err := tc.TaskUsecase.Create(c, request.Title, request.Description, user.ID, request.DueDate); err != nil {
-		tc.handleCreateTaskError(c, err)
+	if _, err := tc.TaskUsecase.Create(c, request.Title, request.Description, user.ID, request.DueDate,
+		request.Priority, request.EstimateMinutes, request.CustomFields); err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusCreated, "created")
+}
+
+// AddSubtask creates a subtask of the task.
+func (tc *TaskController) AddSubtask(c *gin.Context) {
+	// get id from path
+	var uri domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.TaskSubtaskCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if _, err := tc.TaskUsecase.AddSubtask(c, uri.ID, user.ID, request.Title, request.Description); err != nil {
+		c.Error(err)
 		return
 	}
 	response.JSON(c, http.StatusCreated, "created")
 }
 
 func (tc *TaskController) FetchAllTaskByUserID(c *gin.Context) {
+	// get filter from query
+	var filter domain.TaskFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
 	// get user from context
 	user := middleware.GetUserContext(c)
 	if user == nil {
-		err := myerror.ErrContextUserNotFound.WithDescription("user not found in context")
-		logger.W(c.Request.Context(), "occurred context error", err)
-		response.Error(c, http.StatusUnauthorized, "unauthorized", err)
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
 		return
 	}
 
 	// get all task by user id
-	tasks, err := tc.TaskUsecase.FetchAllTaskByUserID(c, user.ID)
+	tasks, err := tc.TaskUsecase.FetchAllTaskByUserID(c, user.ID, filter)
 	if err != nil {
-		tc.handleFetchTaskError(c, err)
+		c.Error(err)
 		return
 	}
 	response.JSON(c, http.StatusOK, "fetched", tasks...)
 }
 
+func (tc *TaskController) Search(c *gin.Context) {
+	// get query from query string
+	var request domain.TaskSearchRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	results, err := tc.TaskUsecase.Search(c, user.ID, request.Query, request.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Results: results})
+}
+
+// QuickAdd parses a task written in a single line. The task is not created, the client
+// creates it after the user confirms the parsed fields.
+func (tc *TaskController) QuickAdd(c *gin.Context) {
+	var request domain.TaskQuickAddRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	var loc *time.Location
+	if request.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(request.Timezone)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+	} else {
+		// get user from context
+		user := middleware.GetUserContext(c)
+		if user == nil {
+			c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+			return
+		}
+		setting, err := tc.UserSettingUsecase.FetchSettingByUserID(c, user.ID)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		loc = setting.Location()
+	}
+
+	task := quickadd.Parse(request.Text, time.Now().In(loc))
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "parsed", QuickAdd: &task})
+}
+
 func (tc *TaskController) FetchTaskByTaskID(c *gin.Context) {
 	// get id from path
 	var request domain.TaskFetchRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		tc.handleValidationError(c, err)
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	// get user from context
 	user := middleware.GetUserContext(c)
 	if user == nil {
-		err := myerror.ErrContextUserNotFound.WithDescription("user not found in context")
-		logger.W(c.Request.Context(), "occurred context error", err)
-		response.Error(c, http.StatusUnauthorized, "unauthorized", err)
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
 		return
 	}
 
 	task, err := tc.TaskUsecase.FetchTaskByTaskID(c, request.ID, user.ID)
 	if err != nil {
-		tc.handleFetchTaskError(c, err)
+		c.Error(err)
 		return
 	}
 	response.JSON(c, http.StatusOK, "fetched", *task)
@@ -94,27 +184,53 @@ func (tc *TaskController) Update(c *gin.Context) {
 	// get id from path
 	var request domain.TaskUpdateRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		tc.handleValidationError(c, err)
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 	if err := c.ShouldBindJSON(&request); err != nil {
-		fmt.Println(err)
-		tc.handleValidationError(c, err)
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	// get user from context
 	user := middleware.GetUserContext(c)
 	if user == nil {
-		err := myerror.ErrContextUserNotFound.WithDescription("user not found in context")
-		logger.W(c.Request.Context(), "occurred context error", err)
-		response.Error(c, http.StatusUnauthorized, "unauthorized", err)
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
 		return
 	}
 
 	// update task
-	if err := tc.TaskUsecase.Update(c, request.ID, user.ID, request.Title, request.Description, request.DueDate); err != nil {
-		tc.handleUpdateTaskError(c, err)
+	if err := tc.TaskUsecase.Update(c, request.ID, user.ID, request.Title, request.Description, request.DueDate,
+		request.Priority, request.EstimateMinutes, request.CustomFields); err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusOK, "updated")
+}
+
+func (tc *TaskController) Complete(c *gin.Context) {
+	// get id from path
+	var uri domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.TaskCompleteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	// complete task
+	if err := tc.TaskUsecase.Complete(c, uri.ID, user.ID, *request.Completed); err != nil {
+		c.Error(err)
 		return
 	}
 	response.JSON(c, http.StatusOK, "updated")
@@ -124,187 +240,372 @@ func (tc *TaskController) Delete(c *gin.Context) {
 	// get id from path
 	var request domain.TaskFetchRequest
 	if err := c.ShouldBindUri(&request); err != nil {
-		tc.handleValidationError(c, err)
+		c.Error(err).SetType(gin.ErrorTypeBind)
 		return
 	}
 
 	// get user from context
 	user := middleware.GetUserContext(c)
 	if user == nil {
-		err := myerror.ErrContextUserNotFound.WithDescription("user not found in context")
-		logger.W(c.Request.Context(), "occurred context error", err)
-		response.Error(c, http.StatusUnauthorized, "unauthorized", err)
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
 		return
 	}
 
 	// delete task
 	if err := tc.TaskUsecase.Delete(c, request.ID, user.ID); err != nil {
-		tc.handleDeleteTaskError(c, err)
+		c.Error(err)
 		return
 	}
 	response.JSON(c, http.StatusOK, "deleted")
 }
 
-func (tc *TaskController) handleValidationError(c *gin.Context, err error) {
-	var vErr *myerror.AppError
+func (tc *TaskController) Share(c *gin.Context) {
+	// get id from path
+	var uri domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.TaskShareRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
 
-	switch e := err.(type) {
-	case validator.ValidationErrors:
-		missingFields := []string{}
-		for _, fieldErr := range e {
-			missingFields = append(missingFields, fieldErr.Field())
-		}
-		vErr = myerror.ErrValidation.WrapWithDescription(e,
-			fmt.Sprintf("missing fields: %v", strings.Join(missingFields, ", ")))
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+	if request.UserID == user.ID {
+		c.Error(myerror.ErrValidation.WithDescription("cannot share task with yourself"))
+		return
+	}
 
-	case *json.UnmarshalTypeError:
-		vErr = myerror.ErrValidation.WrapWithDescription(e,
-			fmt.Sprintf("missing field type: %v, expect: %s, actual: %s", e.Field, e.Type, e.Value))
+	// share task
+	if err := tc.TaskUsecase.Share(c, uri.ID, user.ID, request.UserID, request.CanEdit); err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusOK, "shared")
+}
 
-	case *json.SyntaxError:
-		vErr = myerror.ErrValidation.WrapWithDescription(e,
-			fmt.Sprintf("json syntax error, offset: %d", e.Offset))
+// Assign replaces the assignees of the task, the assignees are granted read access.
+func (tc *TaskController) Assign(c *gin.Context) {
+	// get id from path
+	var uri domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.TaskAssignRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
 
-	case *time.ParseError:
-		vErr = myerror.ErrValidation.WrapWithDescription(e,
-			fmt.Sprintf("time parse error, expect format: %s", "yyyy-mm-dd"))
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
 
-	case *strconv.NumError:
-		vErr = myerror.ErrValidation.WrapWithDescription(e,
-			"string convert error, expect format: number")
+	if err := tc.TaskUsecase.Assign(c, uri.ID, user.ID, request.UserIDs); err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusOK, "assigned")
+}
 
-	default:
-		vErr = myerror.ErrUnExpected.WithDescription(err.Error())
+func (tc *TaskController) SetLabels(c *gin.Context) {
+	// get id from path
+	var uri domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.TaskLabelRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
 	}
 
-	if vErr != nil {
-		logger.W(c.Request.Context(), "occurred validation error", vErr)
-		response.Error(c, http.StatusBadRequest, "your request is validation failed", vErr)
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
 	}
 
+	if err := tc.TaskUsecase.SetLabels(c, uri.ID, user.ID, request.Labels); err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusOK, "updated")
 }
 
-func (tc *TaskController) handleCreateTaskError(c *gin.Context, err error) {
-	ctx := c.Request.Context()
-
-	var appErr *myerror.AppError
-	if errors.As(err, &appErr) {
-		switch {
-		case errors.Is(appErr, myerror.ErrQueryFailed):
-			err := appErr.WithDescription("failed to execute query")
-			logger.E(ctx, "occurred db error", err)
-			response.Error(c, http.StatusInternalServerError, "failed to create task", err)
-
-		case errors.Is(appErr, myerror.ErrGrantPermission):
-			err := appErr.WithDescription("failed to grant permission")
-			logger.E(ctx, "occurred create task error", err)
-			response.Error(c, http.StatusInternalServerError, "failed to create task", err)
-
-		default:
-			logger.E(ctx, "occurred create task error", appErr)
-			response.Error(c, http.StatusInternalServerError, "failed to create task", appErr)
-		}
-	} else {
+func (tc *TaskController) FetchActivitiesByTaskID(c *gin.Context) {
+	// get id from path
+	var request domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
 
-		logger.E(ctx, "unexpected error occurred", err)
-		response.Error(c, http.StatusInternalServerError, "failed to create task", err)
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
 	}
+
+	activities, err := tc.TaskUsecase.FetchActivitiesByTaskID(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Activities: activities})
 }
 
-func (tc *TaskController) handleFetchTaskError(c *gin.Context, err error) {
-	ctx := c.Request.Context()
-
-	var appErr *myerror.AppError
-	if errors.As(err, &appErr) {
-		switch {
-		case errors.Is(appErr, myerror.ErrQueryFailed):
-			err := appErr.WithDescription("failed to execute query")
-			logger.E(ctx, "occurred fetch task error", err)
-			response.Error(c, http.StatusInternalServerError, "failed to fetch task", err)
-
-		case errors.Is(appErr, myerror.ErrTaskNotFound):
-			err := appErr.WithDescription("no task yet")
-			logger.W(ctx, "occurred fetch task error", err)
-			response.Error(c, http.StatusNotFound, "failed to fetch task", err)
-
-		case errors.Is(appErr, myerror.ErrPermissionNotFound):
-			err := appErr.WithDescription("you don't have permission to access task")
-			logger.W(ctx, "occurred fetch task error", err)
-			response.Error(c, http.StatusForbidden, "failed to fetch task", err)
-
-		case errors.Is(appErr, myerror.ErrPermissionDenied):
-			err := appErr.WithDescription("permission denied")
-			logger.W(ctx, "occurred fetch task error", err)
-			response.Error(c, http.StatusForbidden, "failed to fetch task", err)
-
-		default:
-			logger.E(ctx, "occurred fetch task error", appErr)
-			response.Error(c, http.StatusInternalServerError, "failed to fetch task", appErr)
-		}
+func (tc *TaskController) FetchTrashByUserID(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	tasks, err := tc.TaskUsecase.FetchTrashByUserID(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusOK, "fetched", tasks...)
+}
+
+func (tc *TaskController) Restore(c *gin.Context) {
+	// get id from path
+	var request domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	// restore task from trash
+	if err := tc.TaskUsecase.Restore(c, request.ID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusOK, "restored")
+}
+
+func (tc *TaskController) Archive(c *gin.Context) {
+	tc.archive(c, true)
+}
+
+func (tc *TaskController) Unarchive(c *gin.Context) {
+	tc.archive(c, false)
+}
+
+func (tc *TaskController) archive(c *gin.Context, archived bool) {
+	// get id from path
+	var request domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	// archive or unarchive task
+	if err := tc.TaskUsecase.Archive(c, request.ID, user.ID, archived); err != nil {
+		c.Error(err)
+		return
+	}
+	if archived {
+		response.JSON(c, http.StatusOK, "archived")
 	} else {
-		logger.E(ctx, "unexpected error occurred", err)
-		response.Error(c, http.StatusInternalServerError, "failed to fetch task", err)
+		response.JSON(c, http.StatusOK, "unarchived")
+	}
+}
+
+func (tc *TaskController) Bulk(c *gin.Context) {
+	// binding json request
+	var request domain.TaskBulkRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	results, err := tc.TaskUsecase.Bulk(c, user.ID, request.TaskIDs, request.Filter, request.Operation, request.AllOrNothing)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	message := "completed"
+	for _, r := range results {
+		if r.Status == domain.TaskBulkRolledBack {
+			message = "rolled back"
+			break
+		}
 	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: message, BulkResults: results})
 }
 
-func (tc *TaskController) handleUpdateTaskError(c *gin.Context, err error) {
-	ctx := c.Request.Context()
-
-	var appErr *myerror.AppError
-	if errors.As(err, &appErr) {
-		switch {
-		case errors.Is(appErr, myerror.ErrQueryFailed):
-			err := appErr.WithDescription("failed to execute query")
-			logger.E(ctx, "occurred update task error", err)
-			response.Error(c, http.StatusInternalServerError, "failed to update task", err)
-
-		case errors.Is(appErr, myerror.ErrPermissionNotFound):
-			err := appErr.WithDescription("you don't have permission to access task")
-			logger.W(ctx, "occurred update task error", err)
-			response.Error(c, http.StatusForbidden, "failed to update task", err)
-
-		case errors.Is(appErr, myerror.ErrPermissionDenied):
-			err := appErr.WithDescription("permission denied")
-			logger.W(ctx, "occurred update task error", err)
-			response.Error(c, http.StatusForbidden, "failed to update task", err)
-
-		default:
-			logger.E(ctx, "occurred update task error", appErr)
-			response.Error(c, http.StatusInternalServerError, "failed to update task", appErr)
+func (tc *TaskController) Export(c *gin.Context) {
+	var request domain.TaskExportRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	if request.Format == "" {
+		request.Format = taskio.FormatCSV
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	// the tasks are written as they are fetched, so the status cannot change once the first batch is sent
+	var encoder taskio.Encoder
+	begin := func() error {
+		contentType, extension := "text/csv; charset=utf-8", request.Format
+		switch request.Format {
+		case taskio.FormatJSON:
+			contentType = "application/json; charset=utf-8"
+		case taskio.FormatMarkdown:
+			contentType, extension = "text/markdown; charset=utf-8", "md"
+		}
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, extension))
+		c.Status(http.StatusOK)
+
+		var err error
+		encoder, err = taskio.NewEncoder(c.Writer, request.Format)
+		return err
+	}
+
+	err := tc.TaskUsecase.Export(c, user.ID, func(tasks []domain.Task) error {
+		if encoder == nil {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		for _, task := range tasks {
+			if err := encoder.Encode(task); err != nil {
+				return err
+			}
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if encoder != nil {
+			logger.E(c.Request.Context(), "failed to export tasks", err)
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	// no task to export, write the empty file
+	if encoder == nil {
+		if err := begin(); err != nil {
+			logger.E(c.Request.Context(), "failed to export tasks", err)
+			return
 		}
-	} else {
-		logger.E(ctx, "unexpected error occurred", err)
-		response.Error(c, http.StatusInternalServerError, "failed to update task", err)
+	}
+	if err := encoder.Close(); err != nil {
+		logger.E(c.Request.Context(), "failed to export tasks", err)
 	}
 }
 
-func (tc *TaskController) handleDeleteTaskError(c *gin.Context, err error) {
-	ctx := c.Request.Context()
-
-	var appErr *myerror.AppError
-	if errors.As(err, &appErr) {
-		switch {
-		case errors.Is(appErr, myerror.ErrQueryFailed):
-			err := appErr.WithDescription("failed to execute query")
-			logger.E(ctx, "occurred delete task error", err)
-			response.Error(c, http.StatusInternalServerError, "failed to delete task", err)
-
-		case errors.Is(appErr, myerror.ErrPermissionNotFound):
-			err := appErr.WithDescription("you don't have permission to access task")
-			logger.W(ctx, "occurred delete task error", err)
-			response.Error(c, http.StatusForbidden, "failed to delete task", err)
-
-		case errors.Is(appErr, myerror.ErrPermissionDenied):
-			err := appErr.WithDescription("permission denied")
-			logger.W(ctx, "occurred delete task error", err)
-			response.Error(c, http.StatusForbidden, "failed to delete task", err)
-
-		default:
-			logger.E(ctx, "occurred delete task error", appErr)
-			response.Error(c, http.StatusInternalServerError, "failed to delete task", appErr)
+func (tc *TaskController) Import(c *gin.Context) {
+	var request domain.TaskImportRequest
+	if err := c.ShouldBind(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.Error(myerror.ErrValidation.WrapWithDescription(err, "file is required"))
+		return
+	}
+	if request.Format == "" {
+		request.Format = taskio.FormatCSV
+		switch filename := strings.ToLower(file.Filename); {
+		case strings.HasSuffix(filename, ".json"):
+			request.Format = taskio.FormatJSON
+		case strings.HasSuffix(filename, ".md"), strings.HasSuffix(filename, ".markdown"):
+			request.Format = taskio.FormatMarkdown
 		}
-	} else {
-		logger.E(ctx, "unexpected error occurred", err)
-		response.Error(c, http.StatusInternalServerError, "failed to delete task", err)
 	}
+	mapping := taskio.Mapping{}
+	if request.Mapping != "" {
+		if err := json.Unmarshal([]byte(request.Mapping), &mapping); err != nil {
+			c.Error(err).SetType(gin.ErrorTypeBind)
+			return
+		}
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer f.Close()
+	records, err := taskio.Decode(f, request.Format, mapping)
+	if err != nil {
+		c.Error(myerror.ErrValidation.WrapWithDescription(err, fmt.Sprintf("failed to read the file as %s: %v", request.Format, err)))
+		return
+	}
+
+	results, err := tc.TaskUsecase.Import(c, user.ID, records, request.DryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for _, r := range results {
+		if r.Status == domain.TaskImportInvalid {
+			c.JSON(http.StatusUnprocessableEntity, domain.SuccessResponse{Message: "nothing imported, some rows are invalid", Imports: results})
+			return
+		}
+	}
+	if request.DryRun {
+		c.JSON(http.StatusOK, domain.SuccessResponse{Message: "validated", Imports: results})
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "imported", Imports: results})
 }
@@ -1,6 +1,9 @@
 package controller_test
 
 import (
+	"bytes"
+	"context"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -41,8 +44,8 @@ func TestTaskCtrlCreate(t *testing.T) {
 			httptest.NewRequest("POST", "/tasks",
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().Create(gomock.Any(), "test title", "test description", 1, gomock.Any()).
-					Return(nil)
+				taskUsecase.EXPECT().Create(gomock.Any(), "test title", "test description", 1, gomock.Any(), domain.TaskPriority(""), nil, nil).
+					Return(1, nil)
 			},
 			http.StatusCreated,
 			domain.SuccessResponse{Message: "created"},
@@ -53,16 +56,7 @@ func TestTaskCtrlCreate(t *testing.T) {
 				strings.NewReader(`{"description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing fields: Title",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: title", domain.FieldError{Field: "title", Rule: "required", Message: "title is required"}),
 		},
 		{
 			"validation error two missing fields",
@@ -70,16 +64,7 @@ func TestTaskCtrlCreate(t *testing.T) {
 				strings.NewReader(`{"dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing fields: Title, Description",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: title, description", domain.FieldError{Field: "title", Rule: "required", Message: "title is required"}, domain.FieldError{Field: "description", Rule: "required", Message: "description is required"}),
 		},
 		{
 			"validation error type mismatch",
@@ -87,16 +72,7 @@ func TestTaskCtrlCreate(t *testing.T) {
 				strings.NewReader(`{"title":1,"description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing field type: title, expect: string, actual: number",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "missing field type: title, expect: string, actual: number", domain.FieldError{Field: "title", Rule: "type", Param: "string", Message: "title must be of type string"}),
 		},
 		{
 			"validation error json syntax error",
@@ -104,16 +80,7 @@ func TestTaskCtrlCreate(t *testing.T) {
 				strings.NewReader(`{"title":"test title, "description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "json syntax error, offset: 24",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "json syntax error, offset: 24"),
 		},
 		{
 			"validation error time parse error",
@@ -121,16 +88,7 @@ func TestTaskCtrlCreate(t *testing.T) {
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "time parse error, expect format: yyyy-mm-dd",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "time parse error, expect format: yyyy-mm-dd"),
 		},
 		{
 			"user not found",
@@ -138,56 +96,29 @@ func TestTaskCtrlCreate(t *testing.T) {
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "unauthorized",
-				Errors: []domain.ErrorItem{
-					{
-						Message:     myerror.ErrMessages[myerror.CodeContextUserNotFound],
-						Code:        int(myerror.CodeContextUserNotFound),
-						Description: "user not found in context",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeContextUserNotFound, "user not found in context"),
 		},
 		{
 			"create task DB error",
 			httptest.NewRequest("POST", "/tasks",
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().Create(gomock.Any(), "test title", "test description", 1, gomock.Any()).
-					Return(myerror.ErrQueryFailed)
+				taskUsecase.EXPECT().Create(gomock.Any(), "test title", "test description", 1, gomock.Any(), domain.TaskPriority(""), nil, nil).
+					Return(0, myerror.ErrQueryFailed)
 			},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to create task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeQueryFailed),
-						Message:     myerror.ErrMessages[myerror.CodeQueryFailed],
-						Description: "failed to execute query",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeQueryFailed, ""),
 		},
 		{
 			"create task failed grant permission",
 			httptest.NewRequest("POST", "/tasks",
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().Create(gomock.Any(), "test title", "test description", 1, gomock.Any()).
-					Return(myerror.ErrGrantPermission)
+				taskUsecase.EXPECT().Create(gomock.Any(), "test title", "test description", 1, gomock.Any(), domain.TaskPriority(""), nil, nil).
+					Return(0, myerror.ErrGrantPermission)
 			},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to create task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeGrantPermissionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeGrantPermissionFailed],
-						Description: "failed to grant permission",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeGrantPermissionFailed, ""),
 		},
 	}
 
@@ -218,6 +149,7 @@ func TestTaskCtrlCreate(t *testing.T) {
 
 			// run
 			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
 			r.POST("/tasks", taskCotroller.Create)
 			r.ServeHTTP(response, ctx.Request)
 
@@ -239,18 +171,18 @@ func TestTaskCtrlFetchAllTaskByUserID(t *testing.T) {
 		{
 			"success",
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1).
+				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1, domain.TaskFilter{}).
 					Return([]domain.Task{
-						{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.NewDateOnly("2024-12-31")},
-						{ID: 2, Title: "title2", Description: "description2", CreatedBy: 1, DueDate: domain.NewDateOnly("2024-12-31")},
+						{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31")},
+						{ID: 2, Title: "title2", Description: "description2", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31")},
 					}, nil)
 			},
 			http.StatusOK,
 			domain.SuccessResponse{
 				Message: "fetched",
 				Tasks: []domain.Task{
-					{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.NewDateOnly("2024-12-31")},
-					{ID: 2, Title: "title2", Description: "description2", CreatedBy: 1, DueDate: domain.NewDateOnly("2024-12-31")},
+					{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31")},
+					{ID: 2, Title: "title2", Description: "description2", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31")},
 				},
 			},
 		},
@@ -258,70 +190,34 @@ func TestTaskCtrlFetchAllTaskByUserID(t *testing.T) {
 			"user not found",
 			nil,
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "unauthorized",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeContextUserNotFound),
-						Message:     myerror.ErrMessages[myerror.CodeContextUserNotFound],
-						Description: "user not found in context",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeContextUserNotFound, "user not found in context"),
 		},
 		{
 			"task not found",
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1).
+				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1, domain.TaskFilter{}).
 					Return(nil, myerror.ErrTaskNotFound)
 			},
 			http.StatusNotFound,
-			domain.ErrorResponse{
-				Message: "failed to fetch task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeTaskNotFound),
-						Message:     myerror.ErrMessages[myerror.CodeTaskNotFound],
-						Description: "no task yet",
-					},
-				},
-			},
+			helper.Problem(http.StatusNotFound, myerror.CodeTaskNotFound, ""),
 		},
 		{
 			"permission not found",
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1).
+				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1, domain.TaskFilter{}).
 					Return(nil, myerror.ErrPermissionNotFound)
 			},
 			http.StatusForbidden,
-			domain.ErrorResponse{
-				Message: "failed to fetch task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodePermissionNotFound),
-						Message:     myerror.ErrMessages[myerror.CodePermissionNotFound],
-						Description: "you don't have permission to access task",
-					},
-				},
-			},
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionNotFound, ""),
 		},
 		{
 			"query error",
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1).
+				taskUsecase.EXPECT().FetchAllTaskByUserID(gomock.Any(), 1, domain.TaskFilter{}).
 					Return(nil, myerror.ErrQueryFailed)
 			},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to fetch task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeQueryFailed),
-						Message:     myerror.ErrMessages[myerror.CodeQueryFailed],
-						Description: "failed to execute query",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeQueryFailed, ""),
 		},
 	}
 
@@ -353,6 +249,7 @@ func TestTaskCtrlFetchAllTaskByUserID(t *testing.T) {
 
 			// run
 			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
 			r.GET("/tasks", taskCotroller.FetchAllTaskByUserID)
 			r.ServeHTTP(response, ctx.Request)
 
@@ -376,12 +273,12 @@ func TestCtrlFetchTaskByTaskID(t *testing.T) {
 			httptest.NewRequest("GET", "/tasks/1", nil),
 			func(taskUsecase *mock.MockTaskUsecase) {
 				taskUsecase.EXPECT().FetchTaskByTaskID(gomock.Any(), 1, 1).
-					Return(&domain.Task{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.NewDateOnly("2024-12-31")}, nil)
+					Return(&domain.Task{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31")}, nil)
 			},
 			http.StatusOK,
 			domain.SuccessResponse{
 				Message: "fetched",
-				Tasks:   []domain.Task{{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.NewDateOnly("2024-12-31")}},
+				Tasks:   []domain.Task{{ID: 1, Title: "title1", Description: "description1", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31")}},
 			},
 		},
 		{
@@ -389,32 +286,14 @@ func TestCtrlFetchTaskByTaskID(t *testing.T) {
 			httptest.NewRequest("GET", "/tasks/abc", nil),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "string convert error, expect format: number",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "string convert error, expect format: number"),
 		},
 		{
 			"user not found",
 			httptest.NewRequest("GET", "/tasks/1", nil),
 			nil,
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "unauthorized",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeContextUserNotFound),
-						Message:     myerror.ErrMessages[myerror.CodeContextUserNotFound],
-						Description: "user not found in context",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeContextUserNotFound, "user not found in context"),
 		},
 		{
 			"task not found",
@@ -424,16 +303,7 @@ func TestCtrlFetchTaskByTaskID(t *testing.T) {
 					Return(nil, myerror.ErrTaskNotFound)
 			},
 			http.StatusNotFound,
-			domain.ErrorResponse{
-				Message: "failed to fetch task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeTaskNotFound),
-						Message:     myerror.ErrMessages[myerror.CodeTaskNotFound],
-						Description: "no task yet",
-					},
-				},
-			},
+			helper.Problem(http.StatusNotFound, myerror.CodeTaskNotFound, ""),
 		},
 		{
 			"permission denied",
@@ -443,16 +313,7 @@ func TestCtrlFetchTaskByTaskID(t *testing.T) {
 					Return(nil, myerror.ErrPermissionDenied)
 			},
 			http.StatusForbidden,
-			domain.ErrorResponse{
-				Message: "failed to fetch task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodePermissionDenied),
-						Message:     myerror.ErrMessages[myerror.CodePermissionDenied],
-						Description: "permission denied",
-					},
-				},
-			},
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionDenied, ""),
 		},
 		{
 			"query error",
@@ -462,16 +323,7 @@ func TestCtrlFetchTaskByTaskID(t *testing.T) {
 					Return(nil, myerror.ErrQueryFailed)
 			},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to fetch task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeQueryFailed),
-						Message:     myerror.ErrMessages[myerror.CodeQueryFailed],
-						Description: "failed to execute query",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeQueryFailed, ""),
 		},
 	}
 
@@ -504,6 +356,7 @@ func TestCtrlFetchTaskByTaskID(t *testing.T) {
 
 			// run
 			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
 			r.GET("/tasks/:taskID", taskCotroller.FetchTaskByTaskID)
 			r.ServeHTTP(response, ctx.Request)
 
@@ -528,7 +381,7 @@ func TestTaskCtrlUpdate(t *testing.T) {
 			httptest.NewRequest("PUT", "/tasks/1",
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.NewDateOnly("2024-12-31")).
+				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.MustDateOnly("2024-12-31"), domain.TaskPriority(""), nil, nil).
 					Return(nil)
 			},
 			http.StatusOK,
@@ -540,16 +393,7 @@ func TestTaskCtrlUpdate(t *testing.T) {
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "string convert error, expect format: number",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "string convert error, expect format: number"),
 		},
 		{
 			"validation error type mismatch",
@@ -557,16 +401,7 @@ func TestTaskCtrlUpdate(t *testing.T) {
 				strings.NewReader(`{"title":1,"description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "missing field type: title, expect: string, actual: number",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "missing field type: title, expect: string, actual: number", domain.FieldError{Field: "title", Rule: "type", Param: "string", Message: "title must be of type string"}),
 		},
 		{
 			"validation error json syntax error",
@@ -574,16 +409,7 @@ func TestTaskCtrlUpdate(t *testing.T) {
 				strings.NewReader(`{"title":"test title, "description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "json syntax error, offset: 24",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "json syntax error, offset: 24"),
 		},
 		{
 			"validation error time parse error",
@@ -591,16 +417,7 @@ func TestTaskCtrlUpdate(t *testing.T) {
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-"}`)),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "time parse error, expect format: yyyy-mm-dd",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "time parse error, expect format: yyyy-mm-dd"),
 		},
 		{
 			"user not found",
@@ -608,76 +425,40 @@ func TestTaskCtrlUpdate(t *testing.T) {
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			nil,
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "unauthorized",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeContextUserNotFound),
-						Message:     myerror.ErrMessages[myerror.CodeContextUserNotFound],
-						Description: "user not found in context",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeContextUserNotFound, "user not found in context"),
 		},
 		{
 			"update task DB error",
 			httptest.NewRequest("PUT", "/tasks/1",
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.NewDateOnly("2024-12-31")).
+				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.MustDateOnly("2024-12-31"), domain.TaskPriority(""), nil, nil).
 					Return(myerror.ErrQueryFailed)
 			},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to update task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeQueryFailed),
-						Message:     myerror.ErrMessages[myerror.CodeQueryFailed],
-						Description: "failed to execute query",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeQueryFailed, ""),
 		},
 		{
 			"permission denied",
 			httptest.NewRequest("PUT", "/tasks/1",
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.NewDateOnly("2024-12-31")).
+				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.MustDateOnly("2024-12-31"), domain.TaskPriority(""), nil, nil).
 					Return(myerror.ErrPermissionDenied)
 			},
 			http.StatusForbidden,
-			domain.ErrorResponse{
-				Message: "failed to update task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodePermissionDenied),
-						Message:     myerror.ErrMessages[myerror.CodePermissionDenied],
-						Description: "permission denied",
-					},
-				},
-			},
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionDenied, ""),
 		},
 		{
 			"permission not found",
 			httptest.NewRequest("PUT", "/tasks/1",
 				strings.NewReader(`{"title":"test title", "description":"test description", "dueDate":"2024-12-31"}`)),
 			func(taskUsecase *mock.MockTaskUsecase) {
-				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.NewDateOnly("2024-12-31")).
+				taskUsecase.EXPECT().Update(gomock.Any(), 1, 1, "test title", "test description", domain.MustDateOnly("2024-12-31"), domain.TaskPriority(""), nil, nil).
 					Return(myerror.ErrPermissionNotFound)
 			},
 			http.StatusForbidden,
-			domain.ErrorResponse{
-				Message: "failed to update task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodePermissionNotFound),
-						Message:     myerror.ErrMessages[myerror.CodePermissionNotFound],
-						Description: "you don't have permission to access task",
-					},
-				},
-			},
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionNotFound, ""),
 		},
 	}
 
@@ -710,6 +491,7 @@ func TestTaskCtrlUpdate(t *testing.T) {
 
 			// run
 			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
 			r.PUT("/tasks/:taskID", taskCotroller.Update)
 			r.ServeHTTP(response, ctx.Request)
 
@@ -744,32 +526,14 @@ func TestTaskCtrlDelete(t *testing.T) {
 			httptest.NewRequest("DELETE", "/tasks/abc", nil),
 			nil,
 			http.StatusBadRequest,
-			domain.ErrorResponse{
-				Message: "your request is validation failed",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeValidtaionFailed),
-						Message:     myerror.ErrMessages[myerror.CodeValidtaionFailed],
-						Description: "string convert error, expect format: number",
-					},
-				},
-			},
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "string convert error, expect format: number"),
 		},
 		{
 			"user not found",
 			httptest.NewRequest("DELETE", "/tasks/1", nil),
 			nil,
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "unauthorized",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeContextUserNotFound),
-						Message:     myerror.ErrMessages[myerror.CodeContextUserNotFound],
-						Description: "user not found in context",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeContextUserNotFound, "user not found in context"),
 		},
 		{
 			"delete task DB error",
@@ -779,16 +543,7 @@ func TestTaskCtrlDelete(t *testing.T) {
 					Return(myerror.ErrQueryFailed)
 			},
 			http.StatusInternalServerError,
-			domain.ErrorResponse{
-				Message: "failed to delete task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeQueryFailed),
-						Message:     myerror.ErrMessages[myerror.CodeQueryFailed],
-						Description: "failed to execute query",
-					},
-				},
-			},
+			helper.Problem(http.StatusInternalServerError, myerror.CodeQueryFailed, ""),
 		},
 		{
 			"permission denied",
@@ -798,16 +553,7 @@ func TestTaskCtrlDelete(t *testing.T) {
 					Return(myerror.ErrPermissionDenied)
 			},
 			http.StatusForbidden,
-			domain.ErrorResponse{
-				Message: "failed to delete task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodePermissionDenied),
-						Message:     myerror.ErrMessages[myerror.CodePermissionDenied],
-						Description: "permission denied",
-					},
-				},
-			},
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionDenied, ""),
 		},
 		{
 			"permission not found",
@@ -817,16 +563,7 @@ func TestTaskCtrlDelete(t *testing.T) {
 					Return(myerror.ErrPermissionNotFound)
 			},
 			http.StatusForbidden,
-			domain.ErrorResponse{
-				Message: "failed to delete task",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodePermissionNotFound),
-						Message:     myerror.ErrMessages[myerror.CodePermissionNotFound],
-						Description: "you don't have permission to access task",
-					},
-				},
-			},
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionNotFound, ""),
 		},
 	}
 
@@ -859,6 +596,7 @@ func TestTaskCtrlDelete(t *testing.T) {
 
 			// run
 			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
 			r.DELETE("/tasks/:taskID", taskCotroller.Delete)
 			r.ServeHTTP(response, ctx.Request)
 
@@ -868,3 +606,829 @@ func TestTaskCtrlDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskCtrlShare(t *testing.T) {
+	// test cases
+	tests := []struct {
+		title       string
+		request     *http.Request
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			httptest.NewRequest("POST", "/tasks/1/share",
+				strings.NewReader(`{"userID":2, "canEdit":true}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Share(gomock.Any(), 1, 1, 2, true).
+					Return(nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{Message: "shared"},
+		},
+		{
+			"validation error missing field",
+			httptest.NewRequest("POST", "/tasks/1/share",
+				strings.NewReader(`{"canEdit":true}`)),
+			nil,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: userID", domain.FieldError{Field: "userID", Rule: "required", Message: "userID is required"}),
+		},
+		{
+			"validation error share with yourself",
+			httptest.NewRequest("POST", "/tasks/1/share",
+				strings.NewReader(`{"userID":1, "canEdit":true}`)),
+			nil,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "cannot share task with yourself"),
+		},
+		{
+			"permission denied",
+			httptest.NewRequest("POST", "/tasks/1/share",
+				strings.NewReader(`{"userID":2, "canEdit":false}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Share(gomock.Any(), 1, 1, 2, false).
+					Return(myerror.ErrPermissionDenied)
+			},
+			http.StatusForbidden,
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionDenied, ""),
+		},
+		{
+			"grant permission failed",
+			httptest.NewRequest("POST", "/tasks/1/share",
+				strings.NewReader(`{"userID":2, "canEdit":false}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Share(gomock.Any(), 1, 1, 2, false).
+					Return(myerror.ErrGrantPermission)
+			},
+			http.StatusInternalServerError,
+			helper.Problem(http.StatusInternalServerError, myerror.CodeGrantPermissionFailed, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = tt.request
+
+			// user context
+			if tt.wantStatus != http.StatusUnauthorized {
+				user := domain.User{ID: 1, Name: "test user"}
+				middleware.SetUserContext(ctx, user)
+			}
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.POST("/tasks/:taskID/share", taskCotroller.Share)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlAssign(t *testing.T) {
+	// test cases
+	tests := []struct {
+		title       string
+		request     *http.Request
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			httptest.NewRequest("PUT", "/tasks/1/assignees",
+				strings.NewReader(`{"userIDs":[2, 3]}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Assign(gomock.Any(), 1, 1, []int{2, 3}).
+					Return(nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{Message: "assigned"},
+		},
+		{
+			"success unassign everyone",
+			httptest.NewRequest("PUT", "/tasks/1/assignees",
+				strings.NewReader(`{"userIDs":[]}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Assign(gomock.Any(), 1, 1, []int{}).
+					Return(nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{Message: "assigned"},
+		},
+		{
+			"validation error duplicated user",
+			httptest.NewRequest("PUT", "/tasks/1/assignees",
+				strings.NewReader(`{"userIDs":[2, 2]}`)),
+			nil,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: userIDs", domain.FieldError{Field: "userIDs", Rule: "unique", Message: "userIDs must not contain duplicate values"}),
+		},
+		{
+			"archived task",
+			httptest.NewRequest("PUT", "/tasks/1/assignees",
+				strings.NewReader(`{"userIDs":[2]}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Assign(gomock.Any(), 1, 1, []int{2}).
+					Return(myerror.ErrTaskArchived)
+			},
+			http.StatusConflict,
+			helper.Problem(http.StatusConflict, myerror.CodeTaskArchived, ""),
+		},
+		{
+			"permission denied",
+			httptest.NewRequest("PUT", "/tasks/1/assignees",
+				strings.NewReader(`{"userIDs":[2]}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Assign(gomock.Any(), 1, 1, []int{2}).
+					Return(myerror.ErrPermissionDenied)
+			},
+			http.StatusForbidden,
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionDenied, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = tt.request
+
+			// user context
+			if tt.wantStatus != http.StatusUnauthorized {
+				user := domain.User{ID: 1, Name: "test user"}
+				middleware.SetUserContext(ctx, user)
+			}
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.PUT("/tasks/:taskID/assignees", taskCotroller.Assign)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlAddSubtask(t *testing.T) {
+	// test cases
+	tests := []struct {
+		title       string
+		request     *http.Request
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			httptest.NewRequest("POST", "/tasks/1/subtasks",
+				strings.NewReader(`{"title":"step 1","description":"first"}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().AddSubtask(gomock.Any(), 1, 1, "step 1", "first").
+					Return(2, nil)
+			},
+			http.StatusCreated,
+			domain.SuccessResponse{Message: "created"},
+		},
+		{
+			"validation error missing title",
+			httptest.NewRequest("POST", "/tasks/1/subtasks",
+				strings.NewReader(`{"description":"first"}`)),
+			nil,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: title", domain.FieldError{Field: "title", Rule: "required", Message: "title is required"}),
+		},
+		{
+			"parent not found",
+			httptest.NewRequest("POST", "/tasks/1/subtasks",
+				strings.NewReader(`{"title":"step 1"}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().AddSubtask(gomock.Any(), 1, 1, "step 1", "").
+					Return(0, myerror.ErrTaskNotFound)
+			},
+			http.StatusNotFound,
+			helper.Problem(http.StatusNotFound, myerror.CodeTaskNotFound, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = tt.request
+
+			// user context
+			if tt.wantStatus != http.StatusUnauthorized {
+				user := domain.User{ID: 1, Name: "test user"}
+				middleware.SetUserContext(ctx, user)
+			}
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.POST("/tasks/:taskID/subtasks", taskCotroller.AddSubtask)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlFetchActivitiesByTaskID(t *testing.T) {
+	tests := []struct {
+		title       string
+		request     *http.Request
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			httptest.NewRequest("GET", "/tasks/1/activity", nil),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().FetchActivitiesByTaskID(gomock.Any(), 1, 1).
+					Return([]domain.TaskActivity{
+						{
+							ID:      1,
+							TaskID:  1,
+							ActorID: 1,
+							Action:  domain.TaskActivityUpdated,
+							Changes: map[string]domain.FieldChange{
+								"title": {Before: "old title", After: "new title"},
+							},
+						},
+					}, nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{
+				Message: "fetched",
+				Activities: []domain.TaskActivity{
+					{
+						ID:      1,
+						TaskID:  1,
+						ActorID: 1,
+						Action:  domain.TaskActivityUpdated,
+						Changes: map[string]domain.FieldChange{
+							"title": {Before: "old title", After: "new title"},
+						},
+					},
+				},
+			},
+		},
+		{
+			"validation error",
+			httptest.NewRequest("GET", "/tasks/abc/activity", nil),
+			nil,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "string convert error, expect format: number"),
+		},
+		{
+			"permission denied",
+			httptest.NewRequest("GET", "/tasks/1/activity", nil),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().FetchActivitiesByTaskID(gomock.Any(), 1, 1).
+					Return(nil, myerror.ErrPermissionDenied)
+			},
+			http.StatusForbidden,
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionDenied, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			gin.SetMode(gin.TestMode)
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = tt.request
+
+			// user context
+			if tt.wantStatus != http.StatusUnauthorized {
+				user := domain.User{ID: 1, Name: "test user"}
+				middleware.SetUserContext(ctx, user)
+			}
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.GET("/tasks/:taskID/activity", taskCotroller.FetchActivitiesByTaskID)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlRestore(t *testing.T) {
+	tests := []struct {
+		title       string
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Restore(gomock.Any(), 1, 1).Return(nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{Message: "restored"},
+		},
+		{
+			"task is not in the trash",
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Restore(gomock.Any(), 1, 1).Return(myerror.ErrTaskNotFound)
+			},
+			http.StatusNotFound,
+			helper.Problem(http.StatusNotFound, myerror.CodeTaskNotFound, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			gin.SetMode(gin.TestMode)
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = httptest.NewRequest("POST", "/tasks/1/restore", nil)
+
+			// user context
+			user := domain.User{ID: 1, Name: "test user"}
+			middleware.SetUserContext(ctx, user)
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.POST("/tasks/:taskID/restore", taskCotroller.Restore)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlArchive(t *testing.T) {
+	tests := []struct {
+		title       string
+		path        string
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"archive task",
+			"/tasks/1/archive",
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Archive(gomock.Any(), 1, 1, true).Return(nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{Message: "archived"},
+		},
+		{
+			"unarchive task",
+			"/tasks/1/unarchive",
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Archive(gomock.Any(), 1, 1, false).Return(nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{Message: "unarchived"},
+		},
+		{
+			"permission denied",
+			"/tasks/1/archive",
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Archive(gomock.Any(), 1, 1, true).Return(myerror.ErrPermissionDenied)
+			},
+			http.StatusForbidden,
+			helper.Problem(http.StatusForbidden, myerror.CodePermissionDenied, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			gin.SetMode(gin.TestMode)
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = httptest.NewRequest("POST", tt.path, nil)
+
+			// user context
+			user := domain.User{ID: 1, Name: "test user"}
+			middleware.SetUserContext(ctx, user)
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.POST("/tasks/:taskID/archive", taskCotroller.Archive)
+			r.POST("/tasks/:taskID/unarchive", taskCotroller.Unarchive)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlSearch(t *testing.T) {
+	tests := []struct {
+		title       string
+		path        string
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			"/search?q=milk&limit=10",
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Search(gomock.Any(), 1, "milk", 10).
+					Return([]domain.TaskSearchResult{
+						{Task: domain.Task{ID: 1, Title: "buy milk", DueDate: domain.MustDateOnly("2024-12-31")}, Rank: 0.5, TitleHighlight: "buy <mark>milk</mark>"},
+					}, nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{
+				Message: "fetched",
+				Results: []domain.TaskSearchResult{
+					{Task: domain.Task{ID: 1, Title: "buy milk", DueDate: domain.MustDateOnly("2024-12-31")}, Rank: 0.5, TitleHighlight: "buy <mark>milk</mark>"},
+				},
+			},
+		},
+		{
+			"query is missing",
+			"/search",
+			nil,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: q", domain.FieldError{Field: "q", Rule: "required", Message: "q is required"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			gin.SetMode(gin.TestMode)
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = httptest.NewRequest("GET", tt.path, nil)
+
+			// user context
+			user := domain.User{ID: 1, Name: "test user"}
+			middleware.SetUserContext(ctx, user)
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.GET("/search", taskCotroller.Search)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlQuickAdd(t *testing.T) {
+	tests := []struct {
+		title       string
+		body        string
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			`{"text":"Deploy API #backend !high @alice","timezone":"UTC"}`,
+			http.StatusOK,
+			domain.SuccessResponse{
+				Message: "parsed",
+				QuickAdd: &domain.TaskQuickAdd{
+					Title: "Deploy API", Labels: []string{"backend"}, Priority: "high", Assignees: []string{"alice"},
+				},
+			},
+		},
+		{
+			"unknown timezone",
+			`{"text":"Deploy API tomorrow","timezone":"Mars/Olympus_Mons"}`,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: timezone", domain.FieldError{Field: "timezone", Rule: "timezone", Message: "timezone must be an IANA timezone such as Asia/Tokyo"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			gin.SetMode(gin.TestMode)
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = httptest.NewRequest("POST", "/tasks/quick", strings.NewReader(tt.body))
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.POST("/tasks/quick", taskCotroller.QuickAdd)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlBulk(t *testing.T) {
+	tests := []struct {
+		title       string
+		request     *http.Request
+		setupMock   func(*mock.MockTaskUsecase)
+		wantStatus  int
+		wantRespose interface{}
+	}{
+		{
+			"success",
+			httptest.NewRequest("POST", "/tasks/bulk",
+				strings.NewReader(`{"taskIDs":[1,2], "operation":{"action":"complete"}}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Bulk(gomock.Any(), 1, []int{1, 2}, nil,
+					domain.TaskBulkOperation{Action: domain.TaskBulkComplete}, false).
+					Return([]domain.TaskBulkResult{
+						{TaskID: 1, Status: domain.TaskBulkSucceeded},
+						{TaskID: 2, Status: domain.TaskBulkSucceeded},
+					}, nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{
+				Message: "completed",
+				BulkResults: []domain.TaskBulkResult{
+					{TaskID: 1, Status: domain.TaskBulkSucceeded},
+					{TaskID: 2, Status: domain.TaskBulkSucceeded},
+				},
+			},
+		},
+		{
+			"rolled back",
+			httptest.NewRequest("POST", "/tasks/bulk",
+				strings.NewReader(`{"taskIDs":[1,2], "operation":{"action":"delete"}, "allOrNothing":true}`)),
+			func(taskUsecase *mock.MockTaskUsecase) {
+				taskUsecase.EXPECT().Bulk(gomock.Any(), 1, []int{1, 2}, nil,
+					domain.TaskBulkOperation{Action: domain.TaskBulkDelete}, true).
+					Return([]domain.TaskBulkResult{
+						{TaskID: 1, Status: domain.TaskBulkRolledBack},
+						{TaskID: 2, Status: domain.TaskBulkFailed, Error: &domain.ErrorItem{
+							Code:    int(myerror.CodePermissionDenied),
+							Message: myerror.ErrMessages[myerror.CodePermissionDenied],
+						}},
+					}, nil)
+			},
+			http.StatusOK,
+			domain.SuccessResponse{
+				Message: "rolled back",
+				BulkResults: []domain.TaskBulkResult{
+					{TaskID: 1, Status: domain.TaskBulkRolledBack},
+					{TaskID: 2, Status: domain.TaskBulkFailed, Error: &domain.ErrorItem{
+						Code:    int(myerror.CodePermissionDenied),
+						Message: myerror.ErrMessages[myerror.CodePermissionDenied],
+					}},
+				},
+			},
+		},
+		{
+			"neither task ids nor filter",
+			httptest.NewRequest("POST", "/tasks/bulk",
+				strings.NewReader(`{"operation":{"action":"complete"}}`)),
+			nil,
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: taskIDs", domain.FieldError{Field: "taskIDs", Rule: "required_without", Param: "Filter", Message: "taskIDs is required when Filter is not present"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			taskUsecase, tearDown := getMockTaskUsecase(t)
+			defer tearDown()
+
+			gin.SetMode(gin.TestMode)
+
+			response := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(response)
+
+			// request
+			ctx.Request = tt.request
+
+			// user context
+			user := domain.User{ID: 1, Name: "test user"}
+			middleware.SetUserContext(ctx, user)
+
+			if tt.setupMock != nil {
+				tt.setupMock(taskUsecase)
+			}
+
+			// controller
+			taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+
+			// run
+			r := gin.Default()
+			r.Use(middleware.ErrorMiddleware())
+			r.POST("/tasks/bulk", taskCotroller.Bulk)
+			r.POST("/tasks/:taskID/share", taskCotroller.Share)
+			r.ServeHTTP(response, ctx.Request)
+
+			// assert
+			assert.Equal(t, tt.wantStatus, response.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantRespose, response)
+		})
+	}
+}
+
+func TestTaskCtrlExport(t *testing.T) {
+	// mock
+	taskUsecase, tearDown := getMockTaskUsecase(t)
+	defer tearDown()
+	taskUsecase.EXPECT().Export(gomock.Any(), 1, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, fn func([]domain.Task) error) error {
+			return fn([]domain.Task{{ID: 1, Title: "buy milk", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31")}})
+		})
+
+	gin.SetMode(gin.TestMode)
+	response := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(response)
+	ctx.Request = httptest.NewRequest("GET", "/tasks/export?format=csv", nil)
+	middleware.SetUserContext(ctx, domain.User{ID: 1, Name: "test user"})
+
+	// run
+	taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+	r := gin.Default()
+	r.Use(middleware.ErrorMiddleware())
+	r.GET("/tasks/export", taskCotroller.Export)
+	r.ServeHTTP(response, ctx.Request)
+
+	// assert
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Equal(t, "id,title,description,dueDate,completed,completedAt,archivedAt,createdBy,createdAt\n"+
+		"1,buy milk,,2024-12-31,false,,,1,0001-01-01T00:00:00Z\n", response.Body.String())
+}
+
+func TestTaskCtrlImport(t *testing.T) {
+	// mock
+	taskUsecase, tearDown := getMockTaskUsecase(t)
+	defer tearDown()
+	taskUsecase.EXPECT().Import(gomock.Any(), 1, []domain.TaskImportRecord{
+		{Row: 1, Title: "buy milk", Description: "low fat", DueDate: "2024-12-31"},
+	}, true).Return([]domain.TaskImportResult{{Row: 1, Status: domain.TaskImportValid}}, nil)
+
+	// multipart request with the file and the column mapping
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fw, err := w.CreateFormFile("file", "tasks.csv")
+	assert.NoError(t, err)
+	_, err = fw.Write([]byte("Name,Notes,Deadline\nbuy milk,low fat,2024-12-31\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteField("mapping", `{"title":"Name","description":"Notes","dueDate":"Deadline"}`))
+	assert.NoError(t, w.WriteField("dryRun", "true"))
+	assert.NoError(t, w.Close())
+
+	gin.SetMode(gin.TestMode)
+	response := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(response)
+	ctx.Request = httptest.NewRequest("POST", "/tasks/import", &body)
+	ctx.Request.Header.Set("Content-Type", w.FormDataContentType())
+	middleware.SetUserContext(ctx, domain.User{ID: 1, Name: "test user"})
+
+	// run
+	taskCotroller := controller.TaskController{TaskUsecase: taskUsecase}
+	r := gin.Default()
+	r.Use(middleware.ErrorMiddleware())
+	r.POST("/tasks/import", taskCotroller.Import)
+	r.ServeHTTP(response, ctx.Request)
+
+	// assert
+	assert.Equal(t, http.StatusOK, response.Code)
+	helper.AssertResponse(t, http.StatusOK, domain.SuccessResponse{
+		Message: "validated",
+		Imports: []domain.TaskImportResult{{Row: 1, Status: domain.TaskImportValid}},
+	}, response)
+}
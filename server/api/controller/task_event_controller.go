@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+const (
+	lastEventIDHeaderKey = "Last-Event-ID"
+	heartbeatInterval    = 30 * time.Second
+)
+
+type TaskEventController struct {
+	TaskEventUsecase domain.TaskEventUsecase
+}
+
+func (ec *TaskEventController) Stream(c *gin.Context) {
+	// browsers resend the header on reconnect, the query is for the first connection
+	lastEventID := c.GetHeader(lastEventIDHeaderKey)
+	if lastEventID == "" {
+		lastEventID = c.DefaultQuery("lastEventID", "0")
+	}
+	lastID, err := strconv.ParseInt(lastEventID, 10, 64)
+	if err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	subscription, err := ec.TaskEventUsecase.Subscribe(c, user.ID, lastID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer subscription.Close()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	for _, event := range subscription.Missed {
+		ec.render(c, event)
+		lastID = event.ID
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return false
+			}
+			// already sent as a missed event
+			if event.ID <= lastID {
+				return true
+			}
+			ec.render(c, event)
+			lastID = event.ID
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
+
+func (ec *TaskEventController) render(c *gin.Context, event domain.TaskEvent) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatInt(event.ID, 10),
+		Event: string(event.Type),
+		Data:  event,
+	})
+}
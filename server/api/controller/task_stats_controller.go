@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/taskio"
+)
+
+type TaskStatsController struct {
+	TaskStatsUsecase domain.TaskStatsUsecase
+}
+
+func (sc *TaskStatsController) FetchStats(c *gin.Context) {
+	var request domain.TaskStatsRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	stats, err := sc.TaskStatsUsecase.FetchStats(c, user.ID, request.Owner, request.Days)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if request.Format == domain.TaskStatsFormatCSV {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="stats.csv"`)
+		c.Status(http.StatusOK)
+		if err := taskio.EncodeStats(c.Writer, stats); err != nil {
+			// the status has been sent, the error can only be logged
+			logger.E(c.Request.Context(), "failed to export stats", err)
+		}
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Stats: stats})
+}
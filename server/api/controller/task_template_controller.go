@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/api/response"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type TaskTemplateController struct {
+	TaskTemplateUsecase domain.TaskTemplateUsecase
+}
+
+func (tc *TaskTemplateController) Create(c *gin.Context) {
+	// binding json request
+	var request domain.TaskTemplateCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	template, err := tc.TaskTemplateUsecase.Create(c, user.ID, domain.TaskTemplate{
+		Name:        request.Name,
+		Title:       request.Title,
+		Description: request.Description,
+		Subtasks:    request.Subtasks,
+		Labels:      request.Labels,
+		DueOffset:   request.DueOffset,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "created", Templates: []domain.TaskTemplate{*template}})
+}
+
+func (tc *TaskTemplateController) FetchAllTemplateByUserID(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	templates, err := tc.TaskTemplateUsecase.FetchTemplatesByUserID(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Templates: templates})
+}
+
+func (tc *TaskTemplateController) FetchTemplateByID(c *gin.Context) {
+	// get id from path
+	var request domain.TaskTemplateFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	template, err := tc.TaskTemplateUsecase.FetchTemplateByID(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Templates: []domain.TaskTemplate{*template}})
+}
+
+func (tc *TaskTemplateController) Delete(c *gin.Context) {
+	// get id from path
+	var request domain.TaskTemplateFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := tc.TaskTemplateUsecase.Delete(c, request.ID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "deleted"})
+}
+
+// Instantiate creates the task and its subtasks from the template.
+func (tc *TaskTemplateController) Instantiate(c *gin.Context) {
+	// get id from path
+	var uri domain.TaskTemplateFetchRequest
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	var request domain.TaskTemplateInstantiateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	tasks, err := tc.TaskTemplateUsecase.Instantiate(c, uri.ID, user.ID, request.Variables)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusCreated, "created", tasks...)
+}
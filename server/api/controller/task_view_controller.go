@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/api/response"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type TaskViewController struct {
+	TaskViewUsecase domain.TaskViewUsecase
+}
+
+func (vc *TaskViewController) Create(c *gin.Context) {
+	// binding json request
+	var request domain.TaskViewCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	view, err := vc.TaskViewUsecase.Create(c, user.ID, request.Name, request.Filter, request.Shared)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "created", Views: []domain.TaskView{*view}})
+}
+
+func (vc *TaskViewController) FetchAllViewByUserID(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	views, err := vc.TaskViewUsecase.FetchViewsByUserID(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Views: views})
+}
+
+func (vc *TaskViewController) Share(c *gin.Context) {
+	// get id from path
+	var request domain.TaskViewShareRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	view, err := vc.TaskViewUsecase.Share(c, request.ID, user.ID, *request.Shared)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "updated", Views: []domain.TaskView{*view}})
+}
+
+func (vc *TaskViewController) Delete(c *gin.Context) {
+	// get id from path
+	var request domain.TaskViewDeleteRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := vc.TaskViewUsecase.Delete(c, request.ID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "deleted"})
+}
+
+func (vc *TaskViewController) FetchTasksByView(c *gin.Context) {
+	// get id from path
+	var request domain.TaskViewFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	tasks, err := vc.TaskViewUsecase.FetchTasksByView(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.JSON(c, http.StatusOK, "fetched", tasks...)
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type TimeEntryController struct {
+	TimeEntryUsecase domain.TimeEntryUsecase
+}
+
+func (tc *TimeEntryController) Start(c *gin.Context) {
+	// get id from path
+	var request domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	entry, err := tc.TimeEntryUsecase.Start(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "started", TimeEntries: []domain.TimeEntry{*entry}})
+}
+
+func (tc *TimeEntryController) Stop(c *gin.Context) {
+	// get id from path
+	var request domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	entry, err := tc.TimeEntryUsecase.Stop(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "stopped", TimeEntries: []domain.TimeEntry{*entry}})
+}
+
+// FetchRunningEntry returns the running timer of the user, no entry when no timer is running.
+func (tc *TimeEntryController) FetchRunningEntry(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	entry, err := tc.TimeEntryUsecase.FetchRunningEntry(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var entries []domain.TimeEntry
+	if entry != nil {
+		entries = append(entries, *entry)
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", TimeEntries: entries})
+}
+
+func (tc *TimeEntryController) FetchEntriesByTaskID(c *gin.Context) {
+	// get id from path
+	var request domain.TaskFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	entries, err := tc.TimeEntryUsecase.FetchEntriesByTaskID(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", TimeEntries: entries})
+}
+
+func (tc *TimeEntryController) FetchTimesheet(c *gin.Context) {
+	// get period from query
+	var request domain.TimesheetRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	timesheet, err := tc.TimeEntryUsecase.FetchTimesheet(c, user.ID,
+		domain.DateOf(request.From), domain.DateOf(request.To))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Timesheet: timesheet})
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type WebhookController struct {
+	WebhookUsecase domain.WebhookUsecase
+}
+
+func (wc *WebhookController) Create(c *gin.Context) {
+	// binding json request
+	var request domain.WebhookCreateRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	// register webhook
+	webhook, err := wc.WebhookUsecase.Register(c, user.ID, request.URL, request.EventTypes)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, domain.SuccessResponse{Message: "created", Webhooks: []domain.Webhook{*webhook}})
+}
+
+func (wc *WebhookController) FetchAllWebhookByUserID(c *gin.Context) {
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	webhooks, err := wc.WebhookUsecase.FetchWebhooksByUserID(c, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Webhooks: webhooks})
+}
+
+func (wc *WebhookController) Delete(c *gin.Context) {
+	// get id from path
+	var request domain.WebhookFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := wc.WebhookUsecase.Delete(c, request.ID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "deleted"})
+}
+
+func (wc *WebhookController) FetchDeliveries(c *gin.Context) {
+	// get id from path
+	var request domain.WebhookFetchRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	deliveries, err := wc.WebhookUsecase.FetchDeliveries(c, request.ID, user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, domain.SuccessResponse{Message: "fetched", Deliveries: deliveries})
+}
+
+func (wc *WebhookController) Redeliver(c *gin.Context) {
+	// get id from path
+	var request domain.WebhookRedeliverRequest
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(err).SetType(gin.ErrorTypeBind)
+		return
+	}
+
+	// get user from context
+	user := middleware.GetUserContext(c)
+	if user == nil {
+		c.Error(myerror.ErrContextUserNotFound.WithDescription("user not found in context"))
+		return
+	}
+
+	if err := wc.WebhookUsecase.Redeliver(c, request.ID, request.DeliveryID, user.ID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, domain.SuccessResponse{Message: "redelivery scheduled"})
+}
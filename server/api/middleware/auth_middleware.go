@@ -3,11 +3,10 @@ package middleware
 import (
 	"context"
 	"encoding/json"
-	"net/http"
+	"errors"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
-	"github.com/keitatwr/task-management-app/api/response"
 	"github.com/keitatwr/task-management-app/domain"
 	"github.com/keitatwr/task-management-app/internal/myerror"
 )
@@ -33,14 +32,14 @@ func AuthMiddleware() gin.HandlerFunc {
 		authUserJson := session.Get("userInfo")
 		if authUserJson == nil {
 			err := myerror.ErrNoLogin.WithDescription("user not logged in")
-			response.Error(c, http.StatusUnauthorized, "unauthorized", err)
+			c.Error(err)
 			c.Abort()
 			return
 		}
 		var authUser domain.User
 		if err := json.Unmarshal([]byte(authUserJson.(string)), &authUser); err != nil {
 			err := myerror.ErrUnExpected.WrapWithDescription(err, "occurrred unexpected error")
-			response.Error(c, http.StatusInternalServerError, "unexpected error", err)
+			c.Error(err)
 			c.Abort()
 			return
 		}
@@ -48,3 +47,34 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// BasicAuthMiddleware authenticates the clients which cannot keep the session, such as
+// CalDAV clients, with the user's email and an app password.
+func BasicAuthMiddleware(appPasswordUsecase domain.AppPasswordUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email, password, ok := c.Request.BasicAuth()
+		if !ok {
+			c.Header("WWW-Authenticate", `Basic realm="task-management-app", charset="UTF-8"`)
+			err := myerror.ErrNoLogin.WithDescription("authorization header not found")
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		user, err := appPasswordUsecase.Authenticate(c.Request.Context(), email, password)
+		if err != nil {
+			if errors.Is(err, myerror.ErrInvalidPassword) {
+				c.Header("WWW-Authenticate", `Basic realm="task-management-app", charset="UTF-8"`)
+				err := myerror.ErrInvalidPassword.WithDescription("invalid email or app password")
+				c.Error(err)
+			} else {
+				err := myerror.ErrUnExpected.WrapWithDescription(err, "failed to authenticate")
+				c.Error(err)
+			}
+			c.Abort()
+			return
+		}
+		SetUserContext(c, *user)
+		c.Next()
+	}
+}
@@ -22,6 +22,7 @@ func setup() *gin.Engine {
 
 	// router
 	r := gin.Default()
+	r.Use(middleware.ErrorMiddleware())
 	// session middleware
 	r.Use(sessions.Sessions("sessionid", store))
 
@@ -65,16 +66,7 @@ func TestAuthMiddleware(t *testing.T) {
 		{
 			"authentication failed",
 			http.StatusUnauthorized,
-			domain.ErrorResponse{
-				Message: "unauthorized",
-				Errors: []domain.ErrorItem{
-					{
-						Code:        int(myerror.CodeNoLogin),
-						Message:     myerror.ErrMessages[myerror.CodeNoLogin],
-						Description: "user not logged in",
-					},
-				},
-			},
+			helper.Problem(http.StatusUnauthorized, myerror.CodeNoLogin, "user not logged in"),
 		},
 	}
 
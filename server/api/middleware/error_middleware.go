@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/response"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/i18n"
+)
+
+type errorConfig struct {
+	userSettingUsecase domain.UserSettingUsecase
+}
+
+type ErrorOption func(*errorConfig)
+
+// WithUserLocale renders the errors of the logged in users in the locale of their
+// settings, unless the request names a supported locale in Accept-Language.
+func WithUserLocale(userSettingUsecase domain.UserSettingUsecase) ErrorOption {
+	return func(c *errorConfig) {
+		c.userSettingUsecase = userSettingUsecase
+	}
+}
+
+// ErrorMiddleware renders the last error added by the handlers with c.Error as the
+// problem details, the status is decided by the code of the error. The errors of the
+// type gin.ErrorTypeBind are validation errors of the request.
+func ErrorMiddleware(opts ...ErrorOption) gin.HandlerFunc {
+	config := &errorConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return func(c *gin.Context) {
+		c.Next()
+
+		ginErr := c.Errors.Last()
+		if ginErr == nil || c.Writer.Written() {
+			return
+		}
+		var err error = ginErr.Err
+		if ginErr.IsType(gin.ErrorTypeBind) {
+			err = response.ValidationError(err)
+		}
+		response.Problem(c, config.locale(c), err)
+	}
+}
+
+// locale chooses the locale of the request, Accept-Language is preferred to the user's
+// settings since it is the choice of the client at hand.
+func (config *errorConfig) locale(c *gin.Context) string {
+	if locale, ok := i18n.Negotiate(c.GetHeader("Accept-Language")); ok {
+		return locale
+	}
+	user := GetUserContext(c)
+	if config.userSettingUsecase == nil || user == nil {
+		return i18n.DefaultLocale
+	}
+	setting, err := config.userSettingUsecase.FetchSettingByUserID(c.Request.Context(), user.ID)
+	if err != nil || !i18n.Supported(setting.Locale) {
+		return i18n.DefaultLocale
+	}
+	return setting.Locale
+}
@@ -0,0 +1,204 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/helper"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestErrorMiddleware(t *testing.T) {
+	tests := []struct {
+		title      string
+		handler    gin.HandlerFunc
+		wantStatus int
+		wantBody   domain.ErrorResponse
+	}{
+		{
+			"not found",
+			func(c *gin.Context) {
+				c.Error(myerror.ErrTaskNotFound)
+			},
+			http.StatusNotFound,
+			helper.Problem(http.StatusNotFound, myerror.CodeTaskNotFound, ""),
+		},
+		{
+			"wrapped with description",
+			func(c *gin.Context) {
+				c.Error(myerror.ErrTaskArchived.WithDescription("task is archived, unarchive it first"))
+			},
+			http.StatusConflict,
+			helper.Problem(http.StatusConflict, myerror.CodeTaskArchived, "task is archived, unarchive it first"),
+		},
+		{
+			"validation of the request",
+			func(c *gin.Context) {
+				var request domain.TaskCompleteRequest
+				if err := c.ShouldBindJSON(&request); err != nil {
+					c.Error(err).SetType(gin.ErrorTypeBind)
+					return
+				}
+			},
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "invalid fields: completed",
+				domain.FieldError{Field: "completed", Rule: "required", Message: "completed is required"}),
+		},
+		{
+			"empty body of the request",
+			func(c *gin.Context) {
+				c.Error(io.EOF).SetType(gin.ErrorTypeBind)
+			},
+			http.StatusBadRequest,
+			helper.Problem(http.StatusBadRequest, myerror.CodeValidtaionFailed, "EOF"),
+		},
+		{
+			"unexpected error",
+			func(c *gin.Context) {
+				c.Error(errors.New("connection reset"))
+			},
+			http.StatusInternalServerError,
+			helper.Problem(http.StatusInternalServerError, myerror.CodeUnExpected, ""),
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.ErrorMiddleware())
+			r.POST("/test", tt.handler)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("{}")))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			helper.AssertResponse(t, tt.wantStatus, tt.wantBody, w)
+		})
+	}
+}
+
+func TestErrorMiddlewareInstance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), "TraceID", "0b6c6a3e-0d0c-4d5f-9f43-3c1e0d8f0a11"))
+	})
+	r.Use(middleware.ErrorMiddleware())
+	r.GET("/test", func(c *gin.Context) {
+		c.Error(myerror.ErrTaskNotFound)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	want := helper.Problem(http.StatusNotFound, myerror.CodeTaskNotFound, "")
+	want.Instance = "urn:uuid:0b6c6a3e-0d0c-4d5f-9f43-3c1e0d8f0a11"
+	helper.AssertResponse(t, http.StatusNotFound, want, w)
+}
+
+func TestErrorMiddlewareLocale(t *testing.T) {
+	tests := []struct {
+		title          string
+		acceptLanguage string
+		loggedIn       bool
+		setupMock      func(*mock.MockUserSettingUsecase)
+		wantLocale     string
+		wantBody       domain.ErrorResponse
+	}{
+		{
+			"default",
+			"",
+			false,
+			func(m *mock.MockUserSettingUsecase) {},
+			"en",
+			domain.ErrorResponse{
+				Type: "/api/v1/errors/1000", Title: "validation failed", Status: http.StatusBadRequest,
+				Detail: "invalid fields: completed", Code: int(myerror.CodeValidtaionFailed),
+				Errors: []domain.FieldError{{Field: "completed", Rule: "required", Message: "completed is required"}},
+			},
+		},
+		{
+			"accept language",
+			"ja-JP,ja;q=0.9,en;q=0.8",
+			true,
+			func(m *mock.MockUserSettingUsecase) {},
+			"ja",
+			domain.ErrorResponse{
+				Type: "/api/v1/errors/1000", Title: "入力内容に誤りがあります", Status: http.StatusBadRequest,
+				Detail: "invalid fields: completed", Code: int(myerror.CodeValidtaionFailed),
+				Errors: []domain.FieldError{{Field: "completed", Rule: "required", Message: "completedは必須です"}},
+			},
+		},
+		{
+			"user setting",
+			"fr-FR",
+			true,
+			func(m *mock.MockUserSettingUsecase) {
+				m.EXPECT().FetchSettingByUserID(gomock.Any(), 1).Return(&domain.UserSetting{UserID: 1, Locale: "ja"}, nil)
+			},
+			"ja",
+			domain.ErrorResponse{
+				Type: "/api/v1/errors/1000", Title: "入力内容に誤りがあります", Status: http.StatusBadRequest,
+				Detail: "invalid fields: completed", Code: int(myerror.CodeValidtaionFailed),
+				Errors: []domain.FieldError{{Field: "completed", Rule: "required", Message: "completedは必須です"}},
+			},
+		},
+		{
+			"user setting failed",
+			"",
+			true,
+			func(m *mock.MockUserSettingUsecase) {
+				m.EXPECT().FetchSettingByUserID(gomock.Any(), 1).Return(nil, myerror.ErrQueryFailed)
+			},
+			"en",
+			domain.ErrorResponse{
+				Type: "/api/v1/errors/1000", Title: "validation failed", Status: http.StatusBadRequest,
+				Detail: "invalid fields: completed", Code: int(myerror.CodeValidtaionFailed),
+				Errors: []domain.FieldError{{Field: "completed", Rule: "required", Message: "completed is required"}},
+			},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mock.NewMockUserSettingUsecase(ctrl)
+			tt.setupMock(m)
+
+			r := gin.New()
+			r.Use(middleware.ErrorMiddleware(middleware.WithUserLocale(m)))
+			r.POST("/test", func(c *gin.Context) {
+				if tt.loggedIn {
+					middleware.SetUserContext(c, domain.User{ID: 1})
+				}
+				var request domain.TaskCompleteRequest
+				if err := c.ShouldBindJSON(&request); err != nil {
+					c.Error(err).SetType(gin.ErrorTypeBind)
+					return
+				}
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("{}"))
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantLocale, w.Header().Get("Content-Language"))
+			helper.AssertResponse(t, http.StatusBadRequest, tt.wantBody, w)
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package response
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/i18n"
+	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+// ProblemContentType is the media type of the problem details of RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes the error code to make the type URI of a problem, the URI
+// serves the definition of the code.
+const problemTypeBase = "/api/v1/errors/"
+
+func init() {
+	// name the fields failing the validation as the clients send them, the JSON name of
+	// a body field and the query or the path parameter of the others
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// statuses map the error codes to the HTTP statuses, the codes not listed are server errors.
+var statuses = map[myerror.ErrorCode]int{
+	// 1000
+	myerror.CodeValidtaionFailed:    http.StatusBadRequest,
+	myerror.CodeContextUserNotFound: http.StatusUnauthorized,
+	myerror.CodeNoLogin:             http.StatusUnauthorized,
+
+	// 2000
+	myerror.CodeUserAlreadyExists:        http.StatusConflict,
+	myerror.CodeInvalidPassword:          http.StatusUnauthorized,
+	myerror.CodeTaskArchived:             http.StatusConflict,
+	myerror.CodePreconditionFailed:       http.StatusPreconditionFailed,
+	myerror.CodeTimerAlreadyRunning:      http.StatusConflict,
+	myerror.CodeCustomFieldAlreadyExists: http.StatusConflict,
+
+	// 3000
+	myerror.CodeTaskNotFound:            http.StatusNotFound,
+	myerror.CodeUserNotFound:            http.StatusNotFound,
+	myerror.CodePermissionNotFound:      http.StatusForbidden,
+	myerror.CodePermissionDenied:        http.StatusForbidden,
+	myerror.CodeTaskEventNotFound:       http.StatusNotFound,
+	myerror.CodeWebhookNotFound:         http.StatusNotFound,
+	myerror.CodeWebhookDeliveryNotFound: http.StatusNotFound,
+	myerror.CodeTaskViewNotFound:        http.StatusNotFound,
+	myerror.CodeCalendarFeedNotFound:    http.StatusNotFound,
+	myerror.CodeAppPasswordNotFound:     http.StatusNotFound,
+	myerror.CodeCalDAVObjectNotFound:    http.StatusNotFound,
+	myerror.CodeTimeEntryNotFound:       http.StatusNotFound,
+	myerror.CodeBoardNotFound:           http.StatusNotFound,
+	myerror.CodeTaskTemplateNotFound:    http.StatusNotFound,
+	myerror.CodeCustomFieldNotFound:     http.StatusNotFound,
+	myerror.CodeErrorDefinitionNotFound: http.StatusNotFound,
+}
+
+// Status returns the HTTP status of the error code.
+func Status(code myerror.ErrorCode) int {
+	if status, ok := statuses[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ProblemType returns the type URI of the problems of the error code.
+func ProblemType(code myerror.ErrorCode) string {
+	return problemTypeBase + strconv.Itoa(int(code))
+}
+
+// Definition describes the error code in the locale.
+func Definition(locale string, code myerror.ErrorCode) domain.ErrorDefinition {
+	return domain.ErrorDefinition{
+		Code:   int(code),
+		Type:   ProblemType(code),
+		Title:  i18n.ErrorMessage(locale, code),
+		Status: Status(code),
+	}
+}
+
+// Catalog describes all the error codes and the validation rules in the locale.
+func Catalog(locale string) domain.ErrorCatalog {
+	codes := make([]myerror.ErrorCode, 0, len(myerror.ErrMessages))
+	for code := range myerror.ErrMessages {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+
+	catalog := domain.ErrorCatalog{
+		Locale: locale,
+		Errors: make([]domain.ErrorDefinition, 0, len(codes)),
+		Rules:  i18n.RuleMessages(locale),
+	}
+	for _, code := range codes {
+		catalog.Errors = append(catalog.Errors, Definition(locale, code))
+	}
+	return catalog
+}
+
+// ValidationError converts the error of binding a request to a validation error.
+func ValidationError(err error) *myerror.AppError {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+		parseErr       *time.ParseError
+		numErr         *strconv.NumError
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		invalidFields := []string{}
+		for _, fieldErr := range validationErrs {
+			invalidFields = append(invalidFields, fieldErr.Field())
+		}
+		return myerror.ErrValidation.WrapWithDescription(err,
+			fmt.Sprintf("invalid fields: %v", strings.Join(invalidFields, ", ")))
+
+	case errors.As(err, &typeErr):
+		return myerror.ErrValidation.WrapWithDescription(err,
+			fmt.Sprintf("missing field type: %v, expect: %s, actual: %s", typeErr.Field, typeErr.Type, typeErr.Value))
+
+	case errors.As(err, &syntaxErr):
+		return myerror.ErrValidation.WrapWithDescription(err,
+			fmt.Sprintf("json syntax error, offset: %d", syntaxErr.Offset))
+
+	case errors.As(err, &parseErr):
+		return myerror.ErrValidation.WrapWithDescription(err,
+			fmt.Sprintf("time parse error, expect format: %s", "yyyy-mm-dd"))
+
+	case errors.As(err, &numErr):
+		return myerror.ErrValidation.WrapWithDescription(err,
+			"string convert error, expect format: number")
+
+	default:
+		return myerror.ErrValidation.WrapWithDescription(err, err.Error())
+	}
+}
+
+// isBindingError reports whether the error comes from decoding or validating a request.
+func isBindingError(err error) bool {
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+		syntaxErr      *json.SyntaxError
+		parseErr       *time.ParseError
+		numErr         *strconv.NumError
+	)
+	return errors.As(err, &validationErrs) || errors.As(err, &typeErr) ||
+		errors.As(err, &syntaxErr) || errors.As(err, &parseErr) || errors.As(err, &numErr)
+}
+
+// NewProblem converts the error to the problem details, the title and the messages of
+// the fields are in the locale. The errors which are not an AppError are unexpected
+// errors, except the errors of binding a request.
+func NewProblem(locale string, err error) domain.ErrorResponse {
+	var appErr *myerror.AppError
+	if !errors.As(err, &appErr) {
+		if isBindingError(err) {
+			appErr = ValidationError(err)
+		} else {
+			appErr = myerror.ErrUnExpected
+		}
+	}
+
+	status := Status(appErr.Code)
+	problem := domain.ErrorResponse{
+		Type:   ProblemType(appErr.Code),
+		Title:  i18n.ErrorMessage(locale, appErr.Code),
+		Status: status,
+		Detail: appErr.Description,
+		Code:   int(appErr.Code),
+	}
+
+	var (
+		validationErrs validator.ValidationErrors
+		typeErr        *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fieldErr := range validationErrs {
+			problem.Errors = append(problem.Errors, domain.FieldError{
+				Field:   fieldErr.Field(),
+				Rule:    fieldErr.Tag(),
+				Param:   fieldErr.Param(),
+				Message: i18n.FieldMessage(locale, fieldErr.Field(), fieldErr.Tag(), fieldErr.Param(), fieldErr.Kind()),
+			})
+		}
+	case errors.As(err, &typeErr):
+		expected := jsonType(typeErr.Type)
+		problem.Errors = append(problem.Errors, domain.FieldError{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   expected,
+			Message: i18n.FieldMessage(locale, typeErr.Field, "type", expected, reflect.Invalid),
+		})
+	}
+	return problem
+}
+
+// jsonType returns the JSON type of the Go type decoded from a request.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// the types decoding themselves, such as time.Time, are sent as strings
+	if reflect.PointerTo(t).Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
+
+// Problem logs the error and renders it as application/problem+json in the locale, the
+// instance is the trace ID of the request.
+func Problem(c *gin.Context, locale string, err error) {
+	ctx := c.Request.Context()
+	problem := NewProblem(locale, err)
+	if traceID, ok := ctx.Value("TraceID").(string); ok {
+		problem.Instance = "urn:uuid:" + traceID
+	}
+
+	if problem.Status >= http.StatusInternalServerError {
+		logger.E(ctx, "occurred server error", err)
+	} else {
+		logger.W(ctx, "occurred client error", err)
+	}
+	c.Header("Content-Type", ProblemContentType)
+	c.Header("Content-Language", locale)
+	c.JSON(problem.Status, problem)
+}
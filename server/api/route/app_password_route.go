@@ -0,0 +1,22 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewAppPasswordRouter(timeout time.Duration, db *gorm.DB, r *gin.RouterGroup) {
+	apRepo := repository.NewAppPasswordRepository(db)
+	uRepo := repository.NewUserReposiotry(db)
+	ac := controller.AppPasswordController{
+		AppPasswordUsecase: usecase.NewAppPasswordUsecase(apRepo, uRepo),
+	}
+	r.POST("/app-passwords", ac.Create)
+	r.GET("/app-passwords", ac.FetchAllAppPasswordByUserID)
+	r.DELETE("/app-passwords/:appPasswordID", ac.Delete)
+}
@@ -0,0 +1,34 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewBoardRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, r *gin.RouterGroup) {
+	tRepo := repository.NewTaskRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
+	bRepo := repository.NewBoardRepository(db)
+	transaction := repository.NewTransaction(db)
+	tu := usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo,
+		usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub), transaction)
+	bc := controller.BoardController{
+		BoardUsecase: usecase.NewBoardUsecase(bRepo, tu, transaction),
+	}
+	r.POST("/boards", bc.Create)
+	r.GET("/boards", bc.FetchAllBoardByUserID)
+	r.GET("/boards/:boardID", bc.FetchBoardByID)
+	r.PUT("/boards/:boardID", bc.Update)
+	r.DELETE("/boards/:boardID", bc.Delete)
+	r.POST("/boards/:boardID/move", bc.Move)
+}
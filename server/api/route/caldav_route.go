@@ -0,0 +1,59 @@
+package route
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/api/middleware"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+// NewCalDAVRouter registers the CalDAV server on the public router, CalDAV clients
+// authenticate with an app password instead of the session.
+func NewCalDAVRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, r *gin.RouterGroup) {
+	tRepo := repository.NewTaskRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
+	crRepo := repository.NewCalDAVResourceRepository(db)
+	apRepo := repository.NewAppPasswordRepository(db)
+	uRepo := repository.NewUserReposiotry(db)
+	transaction := repository.NewTransaction(db)
+	tu := usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo,
+		usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub), transaction)
+	dc := controller.CalDAVController{
+		CalDAVUsecase: usecase.NewCalDAVUsecase(tu, teRepo, crRepo, transaction),
+	}
+
+	// service discovery (RFC 6764)
+	wellKnown := func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, controller.CalDAVPrincipalPath)
+	}
+	r.GET("/.well-known/caldav", wellKnown)
+	r.Handle("PROPFIND", "/.well-known/caldav", wellKnown)
+
+	// both paths with and without the trailing slash are registered, clients do not follow redirects of PROPFIND
+	dav := r.Group("/caldav")
+	dav.Use(middleware.BasicAuthMiddleware(usecase.NewAppPasswordUsecase(apRepo, uRepo)))
+	for _, path := range []string{"", "/"} {
+		dav.OPTIONS(path, dc.Options)
+		dav.Handle("PROPFIND", path, dc.PropfindPrincipal)
+	}
+	for _, path := range []string{"/tasks", "/tasks/"} {
+		dav.OPTIONS(path, dc.Options)
+		dav.Handle("PROPFIND", path, dc.PropfindCollection)
+		dav.Handle("REPORT", path, dc.Report)
+	}
+	dav.OPTIONS("/tasks/:name", dc.Options)
+	dav.Handle("PROPFIND", "/tasks/:name", dc.PropfindObject)
+	dav.GET("/tasks/:name", dc.Get)
+	dav.PUT("/tasks/:name", dc.Put)
+	dav.DELETE("/tasks/:name", dc.Delete)
+}
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+// NewCalendarRouter registers the feed management on the private router and the feed itself
+// on the public router, since calendar clients authenticate with the token in the URL.
+func NewCalendarRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, publicRouter, privateRouter *gin.RouterGroup) {
+	tRepo := repository.NewTaskRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
+	cfRepo := repository.NewCalendarFeedRepository(db)
+	transaction := repository.NewTransaction(db)
+	tu := usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo,
+		usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub), transaction)
+	cc := controller.CalendarController{
+		CalendarFeedUsecase: usecase.NewCalendarFeedUsecase(cfRepo, tu),
+	}
+	privateRouter.POST("/calendar/feed", cc.CreateFeed)
+	privateRouter.DELETE("/calendar/feed", cc.RevokeFeed)
+	publicRouter.GET("/calendar/:token", cc.FetchFeed)
+}
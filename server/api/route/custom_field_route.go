@@ -0,0 +1,23 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewCustomFieldRouter(timeout time.Duration, db *gorm.DB, adminUserIDs []int, r *gin.RouterGroup) {
+	fRepo := repository.NewCustomFieldRepository(db)
+	transaction := repository.NewTransaction(db)
+	fc := controller.CustomFieldController{
+		CustomFieldUsecase: usecase.NewCustomFieldUsecase(fRepo, adminUserIDs, transaction),
+	}
+	r.POST("/fields", fc.Create)
+	r.GET("/fields", fc.FetchFields)
+	r.PUT("/fields/:fieldID", fc.Update)
+	r.DELETE("/fields/:fieldID", fc.Delete)
+}
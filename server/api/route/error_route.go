@@ -0,0 +1,13 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+)
+
+// NewErrorRouter serves the catalog of the error codes, which are the types of the problem details.
+func NewErrorRouter(r *gin.RouterGroup) {
+	ec := controller.ErrorController{}
+	r.GET("/errors", ec.FetchCatalog)
+	r.GET("/errors/:code", ec.FetchDefinition)
+}
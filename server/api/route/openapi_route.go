@@ -0,0 +1,241 @@
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/api/response"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/openapi"
+	"gorm.io/gorm"
+)
+
+// NewOpenAPIRouter serves the specification of the API and Swagger UI rendering it.
+func NewOpenAPIRouter(r *gin.RouterGroup) {
+	oc := controller.OpenAPIController{
+		Document: NewOpenAPIDocument(),
+	}
+	r.GET("/openapi.json", oc.FetchSpec)
+	r.GET("/docs", oc.SwaggerUI)
+}
+
+// eventStreamQuery is the query of GET /events, the Last-Event-ID header takes precedence.
+type eventStreamQuery struct {
+	LastEventID int64 `form:"lastEventID"`
+}
+
+// apiRoutes describe the routes registered under controller.APIBasePath, keep them in
+// sync with the routers when a route is added.
+var apiRoutes = []openapi.Route{
+	// auth
+	{Method: http.MethodPost, Path: "/signup", ID: "signup", Summary: "Create a user", Tag: "auth", Public: true,
+		Body: domain.SignupRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/login", ID: "login", Summary: "Log in and redirect to the tasks", Tag: "auth", Public: true,
+		Body: domain.LoginRequest{}, Status: http.StatusFound},
+
+	// specification
+	{Method: http.MethodGet, Path: "/openapi.json", ID: "fetchSpec", Summary: "Fetch this specification", Tag: "meta", Public: true,
+		ContentTypes: []string{"application/json"}},
+	{Method: http.MethodGet, Path: "/docs", ID: "swaggerUI", Summary: "Render this specification with Swagger UI", Tag: "meta", Public: true,
+		ContentTypes: []string{"text/html"}},
+	{Method: http.MethodGet, Path: "/errors", ID: "listErrors", Summary: "List the error codes and the messages of the validation rules", Tag: "meta", Public: true,
+		Query: domain.ErrorCatalogRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/errors/:code", ID: "getError", Summary: "Describe an error code", Tag: "meta", Public: true,
+		URI: domain.ErrorDefinitionRequest{}, Query: domain.ErrorDefinitionRequest{}, Response: domain.SuccessResponse{}},
+
+	// tasks
+	{Method: http.MethodPost, Path: "/tasks", ID: "createTask", Summary: "Create a task", Tag: "tasks",
+		Body: domain.TaskCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/tasks", ID: "listTasks", Summary: "List the tasks of the user", Tag: "tasks",
+		Query: domain.TaskFilter{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/tasks/bulk", ID: "bulkUpdateTasks", Summary: "Apply an operation to many tasks", Tag: "tasks",
+		Body: domain.TaskBulkRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/tasks/quick", ID: "quickAddTask", Summary: "Parse a quick-add line", Tag: "tasks",
+		Body: domain.TaskQuickAddRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/tasks/export", ID: "exportTasks", Summary: "Export the tasks", Tag: "tasks",
+		Query: domain.TaskExportRequest{}, ContentTypes: []string{"text/csv", "application/json", "text/markdown"}},
+	{Method: http.MethodPost, Path: "/tasks/import", ID: "importTasks", Summary: "Import tasks from a file", Tag: "tasks",
+		Form: domain.TaskImportRequest{}, File: "file", Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/tasks/:taskID", ID: "getTask", Summary: "Fetch a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/tasks/:taskID", ID: "updateTask", Summary: "Update a task", Tag: "tasks",
+		URI: domain.TaskUpdateRequest{}, Body: domain.TaskUpdateRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/tasks/:taskID/completed", ID: "completeTask", Summary: "Complete or reopen a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Body: domain.TaskCompleteRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/tasks/:taskID", ID: "deleteTask", Summary: "Move a task to the trash", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/tasks/:taskID/share", ID: "shareTask", Summary: "Share a task with a user", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Body: domain.TaskShareRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/tasks/:taskID/assignees", ID: "assignTask", Summary: "Replace the assignees of a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Body: domain.TaskAssignRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/tasks/:taskID/labels", ID: "setTaskLabels", Summary: "Replace the labels of a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Body: domain.TaskLabelRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/tasks/:taskID/subtasks", ID: "addSubtask", Summary: "Add a subtask to a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Body: domain.TaskSubtaskCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/tasks/:taskID/activity", ID: "listTaskActivities", Summary: "List the activities of a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/tasks/:taskID/restore", ID: "restoreTask", Summary: "Restore a task from the trash", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/tasks/:taskID/archive", ID: "archiveTask", Summary: "Archive a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/tasks/:taskID/unarchive", ID: "unarchiveTask", Summary: "Unarchive a task", Tag: "tasks",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/trash", ID: "listTrash", Summary: "List the tasks in the trash", Tag: "tasks",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/search", ID: "searchTasks", Summary: "Search the tasks", Tag: "tasks",
+		Query: domain.TaskSearchRequest{}, Response: domain.SuccessResponse{}},
+
+	// time tracking
+	{Method: http.MethodPost, Path: "/tasks/:taskID/timer", ID: "startTimer", Summary: "Start the timer of a task", Tag: "time tracking",
+		URI: domain.TaskFetchRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/tasks/:taskID/timer", ID: "stopTimer", Summary: "Stop the timer of a task", Tag: "time tracking",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/tasks/:taskID/time-entries", ID: "listTimeEntries", Summary: "List the time entries of a task", Tag: "time tracking",
+		URI: domain.TaskFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/timer", ID: "getRunningTimer", Summary: "Fetch the running timer of the user", Tag: "time tracking",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/timesheet", ID: "getTimesheet", Summary: "Fetch the timesheet of a period", Tag: "time tracking",
+		Query: domain.TimesheetRequest{}, Response: domain.SuccessResponse{}},
+
+	// statistics
+	{Method: http.MethodGet, Path: "/stats", ID: "getTaskStats", Summary: "Fetch the statistics of the tasks", Tag: "statistics",
+		Query: domain.TaskStatsRequest{}, Response: domain.SuccessResponse{}, ContentTypes: []string{"application/json", "text/csv"}},
+
+	// events
+	{Method: http.MethodGet, Path: "/events", ID: "streamEvents", Summary: "Stream the task events as server-sent events", Tag: "events",
+		Query: eventStreamQuery{}, ContentTypes: []string{"text/event-stream"}},
+
+	// views
+	{Method: http.MethodPost, Path: "/views", ID: "createView", Summary: "Save a view", Tag: "views",
+		Body: domain.TaskViewCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/views", ID: "listViews", Summary: "List the built-in, the saved and the shared views", Tag: "views",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/views/:viewID/shared", ID: "shareView", Summary: "Share a saved view with the workspace", Tag: "views",
+		URI: domain.TaskViewShareRequest{}, Body: domain.TaskViewShareRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/views/:viewID", ID: "deleteView", Summary: "Delete a saved view", Tag: "views",
+		URI: domain.TaskViewDeleteRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/views/:viewID/tasks", ID: "listViewTasks", Summary: "List the tasks of a view", Tag: "views",
+		URI: domain.TaskViewFetchRequest{}, Response: domain.SuccessResponse{}},
+
+	// boards
+	{Method: http.MethodPost, Path: "/boards", ID: "createBoard", Summary: "Create a board", Tag: "boards",
+		Body: domain.BoardCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/boards", ID: "listBoards", Summary: "List the boards", Tag: "boards",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/boards/:boardID", ID: "getBoard", Summary: "Fetch a board with its cards", Tag: "boards",
+		URI: domain.BoardFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/boards/:boardID", ID: "updateBoard", Summary: "Update a board", Tag: "boards",
+		URI: domain.BoardFetchRequest{}, Body: domain.BoardCreateRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/boards/:boardID", ID: "deleteBoard", Summary: "Delete a board", Tag: "boards",
+		URI: domain.BoardFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/boards/:boardID/move", ID: "moveCard", Summary: "Move a card on a board", Tag: "boards",
+		URI: domain.BoardFetchRequest{}, Body: domain.BoardMoveRequest{}, Response: domain.SuccessResponse{}},
+
+	// templates
+	{Method: http.MethodPost, Path: "/templates", ID: "createTemplate", Summary: "Create a task template", Tag: "templates",
+		Body: domain.TaskTemplateCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/templates", ID: "listTemplates", Summary: "List the task templates", Tag: "templates",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/templates/:templateID", ID: "getTemplate", Summary: "Fetch a task template", Tag: "templates",
+		URI: domain.TaskTemplateFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/templates/:templateID", ID: "deleteTemplate", Summary: "Delete a task template", Tag: "templates",
+		URI: domain.TaskTemplateFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/templates/:templateID/instantiate", ID: "instantiateTemplate", Summary: "Create tasks from a template", Tag: "templates",
+		URI: domain.TaskTemplateFetchRequest{}, Body: domain.TaskTemplateInstantiateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+
+	// custom fields
+	{Method: http.MethodPost, Path: "/fields", ID: "createCustomField", Summary: "Define a custom field", Tag: "custom fields",
+		Body: domain.CustomFieldCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/fields", ID: "listCustomFields", Summary: "List the custom fields", Tag: "custom fields",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/fields/:fieldID", ID: "updateCustomField", Summary: "Update a custom field", Tag: "custom fields",
+		URI: domain.CustomFieldFetchRequest{}, Body: domain.CustomFieldUpdateRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/fields/:fieldID", ID: "deleteCustomField", Summary: "Delete a custom field", Tag: "custom fields",
+		URI: domain.CustomFieldFetchRequest{}, Response: domain.SuccessResponse{}},
+
+	// calendar
+	{Method: http.MethodPost, Path: "/calendar/feed", ID: "createCalendarFeed", Summary: "Issue the calendar feed URL", Tag: "calendar",
+		Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/calendar/feed", ID: "revokeCalendarFeed", Summary: "Revoke the calendar feed URL", Tag: "calendar",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/calendar/:token", ID: "getCalendarFeed", Summary: "Fetch the iCalendar feed, the token in the path authenticates the user", Tag: "calendar", Public: true,
+		Query: domain.CalendarFeedRequest{}, ContentTypes: []string{"text/calendar"}},
+	{Method: http.MethodPost, Path: "/app-passwords", ID: "createAppPassword", Summary: "Create an app password for CalDAV clients", Tag: "calendar",
+		Body: domain.AppPasswordCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/app-passwords", ID: "listAppPasswords", Summary: "List the app passwords", Tag: "calendar",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/app-passwords/:appPasswordID", ID: "deleteAppPassword", Summary: "Delete an app password", Tag: "calendar",
+		URI: domain.AppPasswordDeleteRequest{}, Response: domain.SuccessResponse{}},
+
+	// webhooks
+	{Method: http.MethodPost, Path: "/webhooks", ID: "createWebhook", Summary: "Register a webhook", Tag: "webhooks",
+		Body: domain.WebhookCreateRequest{}, Status: http.StatusCreated, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/webhooks", ID: "listWebhooks", Summary: "List the webhooks", Tag: "webhooks",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodDelete, Path: "/webhooks/:webhookID", ID: "deleteWebhook", Summary: "Delete a webhook", Tag: "webhooks",
+		URI: domain.WebhookFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodGet, Path: "/webhooks/:webhookID/deliveries", ID: "listWebhookDeliveries", Summary: "List the deliveries of a webhook", Tag: "webhooks",
+		URI: domain.WebhookFetchRequest{}, Response: domain.SuccessResponse{}},
+	{Method: http.MethodPost, Path: "/webhooks/:webhookID/deliveries/:deliveryID/redeliver", ID: "redeliverWebhook", Summary: "Send a delivery again", Tag: "webhooks",
+		URI: domain.WebhookRedeliverRequest{}, Status: http.StatusAccepted, Response: domain.SuccessResponse{}},
+
+	// settings
+	{Method: http.MethodGet, Path: "/settings", ID: "getSettings", Summary: "Fetch the settings of the user", Tag: "settings",
+		Response: domain.SuccessResponse{}},
+	{Method: http.MethodPut, Path: "/settings", ID: "updateSettings", Summary: "Update the settings of the user", Tag: "settings",
+		Body: domain.UserSettingUpdateRequest{}, Response: domain.SuccessResponse{}},
+}
+
+// NewOpenAPIDocument builds the specification of the routes under controller.APIBasePath.
+func NewOpenAPIDocument() *openapi.Document {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:   "Task Management API",
+		Version: "1.0.0",
+	}, domain.ErrorResponse{})
+	b.Server(controller.APIBasePath)
+	b.ErrorContentType(response.ProblemContentType)
+	b.SecurityScheme("session", openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "cookie",
+		Name:        "sessionid",
+		Description: "the session cookie set by POST /login",
+	})
+	b.Define(domain.DateOnly{}, openapi.Schema{Type: "string", Format: "date"})
+	b.Define(gorm.DeletedAt{}, openapi.Schema{Type: "string", Format: "date-time", Nullable: true})
+
+	for _, route := range apiRoutes {
+		b.Add(route)
+	}
+
+	// the codes of the errors are the ErrorCodes of myerror
+	b.Component("ErrorCode", errorCodeSchema())
+	for _, name := range []string{"ErrorResponse", "ErrorItem", "ErrorDefinition"} {
+		if schema := b.Schema(name); schema != nil {
+			schema.Properties["code"] = openapi.Ref("ErrorCode")
+		}
+	}
+	return b.Document()
+}
+
+func errorCodeSchema() *openapi.Schema {
+	codes := make([]int, 0, len(myerror.ErrMessages))
+	for code := range myerror.ErrMessages {
+		codes = append(codes, int(code))
+	}
+	sort.Ints(codes)
+
+	schema := &openapi.Schema{Type: "integer"}
+	lines := make([]string, len(codes))
+	for i, code := range codes {
+		schema.Enum = append(schema.Enum, code)
+		lines[i] = fmt.Sprintf("- %d: %s", code, myerror.ErrMessages[myerror.ErrorCode(code)])
+	}
+	schema.Description = "1000s are request errors, 2000s conflicts with the current state, " +
+		"3000s missing resources and failed queries, 9999 an unexpected error.\n\n" + strings.Join(lines, "\n")
+	return schema
+}
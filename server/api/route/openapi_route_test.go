@@ -0,0 +1,87 @@
+package route_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/api/route"
+	"github.com/keitatwr/task-management-app/bootstrap"
+	"github.com/keitatwr/task-management-app/internal/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	route.Setup(time.Second, &bootstrap.Application{Env: &bootstrap.Env{}}, r)
+	return r
+}
+
+func TestOpenAPIDocumentMatchesRoutes(t *testing.T) {
+	r := setupRouter()
+
+	var registered []string
+	for _, info := range r.Routes() {
+		path, ok := strings.CutPrefix(info.Path, controller.APIBasePath)
+		if !ok {
+			// CalDAV is served at the root
+			continue
+		}
+		path, _ = openapi.Path(path)
+		registered = append(registered, strings.ToLower(info.Method)+" "+path)
+	}
+
+	var specified []string
+	for path, item := range route.NewOpenAPIDocument().Paths {
+		for method, op := range item {
+			specified = append(specified, method+" "+path)
+
+			var params []string
+			for _, param := range op.Parameters {
+				if param.In == "path" {
+					params = append(params, param.Name)
+				}
+			}
+			assert.Len(t, params, strings.Count(path, "{"), "path parameters of %s %s", method, path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(specified)
+	assert.Equal(t, registered, specified)
+}
+
+func TestFetchOpenAPIDocument(t *testing.T) {
+	r := setupRouter()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc openapi.Document
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/tasks/{taskID}")
+	assert.Contains(t, doc.Components.Schemas, "ErrorCode")
+	assert.Equal(t, "#/components/schemas/ErrorCode", doc.Components.Schemas["ErrorResponse"].Properties["code"].Ref)
+	assert.Equal(t, "#/components/schemas/ErrorCode", doc.Components.Schemas["ErrorDefinition"].Properties["code"].Ref)
+
+	create := doc.Paths["/tasks"]["post"]
+	assert.Equal(t, "#/components/schemas/TaskCreateRequest", create.RequestBody.Content["application/json"].Schema.Ref)
+	request := doc.Components.Schemas["TaskCreateRequest"]
+	assert.Equal(t, []string{"description", "dueDate", "title"}, request.Required)
+	assert.Equal(t, "date", request.Properties["dueDate"].Format)
+	assert.Equal(t, []any{"none", "low", "medium", "high", "urgent"}, request.Properties["priority"].Enum)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "swagger-ui")
+}
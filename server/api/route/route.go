@@ -7,11 +7,15 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
 	"github.com/keitatwr/task-management-app/api/middleware"
-	"gorm.io/gorm"
+	"github.com/keitatwr/task-management-app/bootstrap"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
 )
 
-func Setup(timeout time.Duration, db *gorm.DB, r *gin.Engine) {
+func Setup(timeout time.Duration, app *bootstrap.Application, r *gin.Engine) {
+	db := app.Postgres
 	r.Use(gin.Recovery())
 	r.Use(middleware.LoggingMiddleware(
 		middleware.NewLoggerConfig(
@@ -19,12 +23,30 @@ func Setup(timeout time.Duration, db *gorm.DB, r *gin.Engine) {
 			middleware.WithClientErrorLogLevel(slog.LevelWarn),
 			middleware.WithServerErrorLogLevel(slog.LevelError),
 		)))
+	r.Use(middleware.ErrorMiddleware(
+		middleware.WithUserLocale(usecase.NewUserSettingUsecase(repository.NewUserSettingRepository(db))),
+	))
 	store := cookie.NewStore([]byte("secret"))
 	r.Use(sessions.Sessions("sessionid", store))
-	publicRouter := r.Group("")
+	// CalDAV clients discover the server at the root, it is not a part of the versioned API
+	NewCalDAVRouter(timeout, db, app.EventHub, r.Group(""))
+	publicRouter := r.Group(controller.APIBasePath)
 	NewSignupRouter(timeout, db, publicRouter)
 	NewLoginRouter(timeout, db, publicRouter)
-	privateRouter := r.Group("")
+	NewOpenAPIRouter(publicRouter)
+	NewErrorRouter(publicRouter)
+	privateRouter := r.Group(controller.APIBasePath)
 	privateRouter.Use(middleware.AuthMiddleware())
-	NewTaskRouter(timeout, db, privateRouter)
+	NewTaskRouter(timeout, db, app.EventHub, privateRouter)
+	NewTaskViewRouter(timeout, db, app.EventHub, privateRouter)
+	NewBoardRouter(timeout, db, app.EventHub, privateRouter)
+	NewTaskTemplateRouter(timeout, db, app.EventHub, privateRouter)
+	NewCustomFieldRouter(timeout, db, app.Env.AdminUserIDs, privateRouter)
+	NewTimeEntryRouter(timeout, db, app.EventHub, privateRouter)
+	NewTaskStatsRouter(timeout, db, privateRouter)
+	NewTaskEventRouter(timeout, db, app.EventHub, privateRouter)
+	NewWebhookRouter(timeout, db, privateRouter)
+	NewUserSettingRouter(timeout, db, privateRouter)
+	NewCalendarRouter(timeout, db, app.EventHub, publicRouter, privateRouter)
+	NewAppPasswordRouter(timeout, db, privateRouter)
 }
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewTaskEventRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, r *gin.RouterGroup) {
+	teRepo := repository.NewTaskEventRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	transaction := repository.NewTransaction(db)
+	ec := controller.TaskEventController{
+		TaskEventUsecase: usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub),
+	}
+	r.GET("/events", ec.Stream)
+}
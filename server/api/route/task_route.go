@@ -5,21 +5,43 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
 	"github.com/keitatwr/task-management-app/repository"
 	"github.com/keitatwr/task-management-app/usecase"
 	"gorm.io/gorm"
 )
 
-func NewTaskRouter(timeout time.Duration, db *gorm.DB, r *gin.RouterGroup) {
+func NewTaskRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, r *gin.RouterGroup) {
 	tRepo := repository.NewTaskRepository(db)
 	tpRepo := repository.NewTaskPermissionRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
 	transaction := repository.NewTransaction(db)
 	tc := controller.TaskController{
-		TaskUsecase: usecase.NewTaskUsecase(tRepo, tpRepo, transaction),
+		TaskUsecase: usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo,
+			usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub), transaction),
+		UserSettingUsecase: usecase.NewUserSettingUsecase(usRepo),
 	}
 	r.POST("/tasks", tc.Create)
 	r.GET("/tasks", tc.FetchAllTaskByUserID)
+	r.POST("/tasks/bulk", tc.Bulk)
+	r.POST("/tasks/quick", tc.QuickAdd)
+	r.GET("/tasks/export", tc.Export)
+	r.POST("/tasks/import", tc.Import)
 	r.GET("/tasks/:taskID", tc.FetchTaskByTaskID)
 	r.PUT("/tasks/:taskID", tc.Update)
+	r.PUT("/tasks/:taskID/completed", tc.Complete)
 	r.DELETE("/tasks/:taskID", tc.Delete)
+	r.POST("/tasks/:taskID/share", tc.Share)
+	r.PUT("/tasks/:taskID/assignees", tc.Assign)
+	r.PUT("/tasks/:taskID/labels", tc.SetLabels)
+	r.POST("/tasks/:taskID/subtasks", tc.AddSubtask)
+	r.GET("/tasks/:taskID/activity", tc.FetchActivitiesByTaskID)
+	r.POST("/tasks/:taskID/restore", tc.Restore)
+	r.POST("/tasks/:taskID/archive", tc.Archive)
+	r.POST("/tasks/:taskID/unarchive", tc.Unarchive)
+	r.GET("/trash", tc.FetchTrashByUserID)
+	r.GET("/search", tc.Search)
 }
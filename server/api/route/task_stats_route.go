@@ -0,0 +1,20 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewTaskStatsRouter(timeout time.Duration, db *gorm.DB, r *gin.RouterGroup) {
+	sRepo := repository.NewTaskStatsRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	sc := controller.TaskStatsController{
+		TaskStatsUsecase: usecase.NewTaskStatsUsecase(sRepo, usRepo),
+	}
+	r.GET("/stats", sc.FetchStats)
+}
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewTaskTemplateRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, r *gin.RouterGroup) {
+	tRepo := repository.NewTaskRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
+	ttRepo := repository.NewTaskTemplateRepository(db)
+	transaction := repository.NewTransaction(db)
+	tu := usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo,
+		usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub), transaction)
+	tc := controller.TaskTemplateController{
+		TaskTemplateUsecase: usecase.NewTaskTemplateUsecase(ttRepo, tu, usRepo, transaction),
+	}
+	r.POST("/templates", tc.Create)
+	r.GET("/templates", tc.FetchAllTemplateByUserID)
+	r.GET("/templates/:templateID", tc.FetchTemplateByID)
+	r.DELETE("/templates/:templateID", tc.Delete)
+	r.POST("/templates/:templateID/instantiate", tc.Instantiate)
+}
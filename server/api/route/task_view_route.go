@@ -0,0 +1,33 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewTaskViewRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, r *gin.RouterGroup) {
+	tRepo := repository.NewTaskRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
+	tvRepo := repository.NewTaskViewRepository(db)
+	transaction := repository.NewTransaction(db)
+	tu := usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo,
+		usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub), transaction)
+	vc := controller.TaskViewController{
+		TaskViewUsecase: usecase.NewTaskViewUsecase(tvRepo, tu),
+	}
+	r.POST("/views", vc.Create)
+	r.GET("/views", vc.FetchAllViewByUserID)
+	r.PUT("/views/:viewID/shared", vc.Share)
+	r.DELETE("/views/:viewID", vc.Delete)
+	r.GET("/views/:viewID/tasks", vc.FetchTasksByView)
+}
@@ -0,0 +1,33 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewTimeEntryRouter(timeout time.Duration, db *gorm.DB, hub *eventstream.Hub, r *gin.RouterGroup) {
+	tRepo := repository.NewTaskRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
+	tiRepo := repository.NewTimeEntryRepository(db)
+	transaction := repository.NewTransaction(db)
+	tu := usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo,
+		usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, hub), transaction)
+	tc := controller.TimeEntryController{
+		TimeEntryUsecase: usecase.NewTimeEntryUsecase(tiRepo, tu, transaction),
+	}
+	r.POST("/tasks/:taskID/timer", tc.Start)
+	r.DELETE("/tasks/:taskID/timer", tc.Stop)
+	r.GET("/tasks/:taskID/time-entries", tc.FetchEntriesByTaskID)
+	r.GET("/timer", tc.FetchRunningEntry)
+	r.GET("/timesheet", tc.FetchTimesheet)
+}
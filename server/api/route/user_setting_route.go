@@ -0,0 +1,20 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewUserSettingRouter(timeout time.Duration, db *gorm.DB, r *gin.RouterGroup) {
+	usRepo := repository.NewUserSettingRepository(db)
+	uc := controller.UserSettingController{
+		UserSettingUsecase: usecase.NewUserSettingUsecase(usRepo),
+	}
+	r.GET("/settings", uc.FetchSetting)
+	r.PUT("/settings", uc.Update)
+}
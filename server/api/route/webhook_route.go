@@ -0,0 +1,25 @@
+package route
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keitatwr/task-management-app/api/controller"
+	"github.com/keitatwr/task-management-app/internal/webhook"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+	"gorm.io/gorm"
+)
+
+func NewWebhookRouter(timeout time.Duration, db *gorm.DB, r *gin.RouterGroup) {
+	wRepo := repository.NewWebhookRepository(db)
+	wdRepo := repository.NewWebhookDeliveryRepository(db)
+	wc := controller.WebhookController{
+		WebhookUsecase: usecase.NewWebhookUsecase(wRepo, wdRepo, webhook.NewHTTPSender(webhook.DefaultTimeout)),
+	}
+	r.POST("/webhooks", wc.Create)
+	r.GET("/webhooks", wc.FetchAllWebhookByUserID)
+	r.DELETE("/webhooks/:webhookID", wc.Delete)
+	r.GET("/webhooks/:webhookID/deliveries", wc.FetchDeliveries)
+	r.POST("/webhooks/:webhookID/deliveries/:deliveryID/redeliver", wc.Redeliver)
+}
@@ -1,10 +1,14 @@
 package bootstrap
 
-import "gorm.io/gorm"
+import (
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"gorm.io/gorm"
+)
 
 type Application struct {
 	Env      *Env
 	Postgres *gorm.DB
+	EventHub *eventstream.Hub
 }
 
 func App() (*Application, error) {
@@ -18,5 +22,6 @@ func App() (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
+	app.EventHub = eventstream.NewHub()
 	return app, nil
 }
@@ -4,10 +4,17 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+const (
+	defaultTrashRetentionDays = 30
+	defaultSMTPPort           = "587"
+	defaultDBTimeZone         = "Asia/Tokyo"
+)
+
 type Env struct {
 	ServerAddress  string
 	Port           string
@@ -17,6 +24,19 @@ type Env struct {
 	DBUser         string
 	DBPass         string
 	DBName         string
+	// DBTimeZone is the timezone of the database sessions. The dates are not affected by
+	// it, it only changes how the database shows the timestamps.
+	DBTimeZone string
+	// TrashRetentionDays is how long deleted tasks stay in the trash before they are purged.
+	TrashRetentionDays int
+	// AdminUserIDs are the users who manage the settings of the workspace such as the custom fields.
+	AdminUserIDs []int
+	// SMTPHost is the server the emails are sent through, the emails are only logged when it is empty.
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
 }
 
 func NewEnv() (*Env, error) {
@@ -40,6 +60,36 @@ func NewEnv() (*Env, error) {
 		return nil, err
 	}
 
+	retentionDays := defaultTrashRetentionDays
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		retentionDays, err = strToInt(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var adminUserIDs []int
+	for _, v := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		id, err := strToInt(v)
+		if err != nil {
+			return nil, err
+		}
+		adminUserIDs = append(adminUserIDs, id)
+	}
+
+	dbTimeZone := os.Getenv("POSTGRES_TIMEZONE")
+	if dbTimeZone == "" {
+		dbTimeZone = defaultDBTimeZone
+	}
+
+	smtpPort := os.Getenv("SMTP_PORT")
+	if smtpPort == "" {
+		smtpPort = defaultSMTPPort
+	}
+
 	return &Env{
 		ServerAddress:  os.Getenv("SERVER_ADDRESS"),
 		Port:           os.Getenv("PORT"),
@@ -49,6 +99,16 @@ func NewEnv() (*Env, error) {
 		DBUser:         os.Getenv("POSTGRES_USER"),
 		DBPass:         os.Getenv("POSTGRES_PASSWORD"),
 		DBName:         os.Getenv("POSTGRES_DB"),
+		DBTimeZone:     dbTimeZone,
+
+		TrashRetentionDays: retentionDays,
+		AdminUserIDs:       adminUserIDs,
+
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     smtpPort,
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		MailFrom:     os.Getenv("MAIL_FROM"),
 	}, nil
 }
 
@@ -1,20 +1,31 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func NewPostgresDatabase(env *Env) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Tokyo",
-		env.DBHost, env.DBUser, env.DBPass, env.DBName, env.DBPort)
 	// logger.Info(nil, "connecting to database")
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(postgresDSN(env)), &gorm.Config{})
 	if err != nil {
 		// logger.Errorf(nil, "failed to connect to database: %v", err)
 		return nil, err
 	}
 	return db, nil
 }
+
+// NewPostgresListenerConn opens a dedicated connection for LISTEN,
+// it can not be shared with the gorm pool because it is held open.
+func NewPostgresListenerConn(ctx context.Context, env *Env) (*pgx.Conn, error) {
+	return pgx.Connect(ctx, postgresDSN(env))
+}
+
+func postgresDSN(env *Env) string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=%s",
+		env.DBHost, env.DBUser, env.DBPass, env.DBName, env.DBPort, env.DBTimeZone)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -15,6 +16,7 @@ import (
 	"github.com/keitatwr/task-management-app/api/route"
 	"github.com/keitatwr/task-management-app/bootstrap"
 	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/worker"
 )
 
 const logDir = "log"
@@ -50,17 +52,27 @@ func main() {
 		os.Exit(1)
 	}
 	env := app.Env
-	db := app.Postgres
 	timeout := time.Duration(env.ContextTimeout) * time.Second
 
+	// cancelled on shutdown to stop the workers and the open event streams
+	baseCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	logger.I(nil, "starting workers...")
+	worker.Start(baseCtx, app)
+
 	logger.I(nil, "set up server...")
 	gin.SetMode(gin.DebugMode)
 	router := gin.New()
-	route.Setup(timeout, db, router)
+	route.Setup(timeout, app, router)
 	server := &http.Server{
 		Addr:    env.ServerAddress,
 		Handler: router,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
+	server.RegisterOnShutdown(stop)
 
 	idleConnsClosed := make(chan struct{})
 
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AppPassword is a personal credential for the clients which cannot log in with the
+// session, such as CalDAV clients. They authenticate with the user's email and the
+// app password by Basic authentication. The password is only shown once when it is created.
+type AppPassword struct {
+	ID           int       `json:"id"`
+	UserID       int       `json:"userID"`
+	Name         string    `json:"name"`
+	Password     string    `json:"password,omitempty" gorm:"-"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type AppPasswordRepository interface {
+	Create(ctx context.Context, appPassword *AppPassword) error
+	FetchAppPasswordsByUserID(ctx context.Context, userID int) ([]AppPassword, error)
+	FetchAppPasswordByHash(ctx context.Context, userID int, passwordHash string) (*AppPassword, error)
+	Delete(ctx context.Context, appPasswordID, userID int) error
+}
+
+type AppPasswordUsecase interface {
+	Create(ctx context.Context, userID int, name string) (*AppPassword, error)
+	FetchAppPasswordsByUserID(ctx context.Context, userID int) ([]AppPassword, error)
+	Delete(ctx context.Context, appPasswordID, userID int) error
+	// Authenticate returns the user of the email if the password is one of the user's app passwords.
+	Authenticate(ctx context.Context, email, password string) (*User, error)
+}
+
+type AppPasswordCreateRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type AppPasswordDeleteRequest struct {
+	ID int `uri:"appPasswordID"`
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type BoardColumnKind string
+
+const (
+	// BoardColumnStatus holds the tasks of a status, the value is open or completed.
+	BoardColumnStatus BoardColumnKind = "status"
+	// BoardColumnLabel holds the tasks having the label given by the value.
+	BoardColumnLabel BoardColumnKind = "label"
+)
+
+const (
+	BoardStatusOpen      = "open"
+	BoardStatusCompleted = "completed"
+)
+
+// Board is a kanban board of the tasks the user can read. A task is shown in the
+// first label column whose label it has, otherwise in the first status column of
+// its status, and not on the board when no column matches.
+type Board struct {
+	ID        int           `json:"id"`
+	UserID    int           `json:"userID"`
+	Name      string        `json:"name"`
+	Columns   []BoardColumn `json:"columns" gorm:"serializer:json"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type BoardColumn struct {
+	Name  string          `json:"name" binding:"required,max=100"`
+	Kind  BoardColumnKind `json:"kind" binding:"required,oneof=status label"`
+	Value string          `json:"value" binding:"required,max=50"`
+	// Tasks are the cards of the column in the order of their rank, they are only
+	// filled by the board detail.
+	Tasks []Task `json:"tasks,omitempty"`
+}
+
+// Matches reports whether the task belongs to the column when no earlier column takes it.
+func (c BoardColumn) Matches(task Task) bool {
+	switch c.Kind {
+	case BoardColumnLabel:
+		return task.Labels.Contains(c.Value)
+	case BoardColumnStatus:
+		return task.Completed == (c.Value == BoardStatusCompleted)
+	}
+	return false
+}
+
+// BoardCard holds the position of a task on a board. The cards are ordered by the
+// lexicographic rank within a column, the tasks without a card come last.
+type BoardCard struct {
+	BoardID int    `json:"boardID"`
+	TaskID  int    `json:"taskID"`
+	Column  int    `json:"column" gorm:"-"`
+	Rank    string `json:"rank"`
+}
+
+type BoardRepository interface {
+	Create(ctx context.Context, board *Board) error
+	FetchBoardByID(ctx context.Context, boardID int) (*Board, error)
+	FetchBoardsByUserID(ctx context.Context, userID int) ([]Board, error)
+	Update(ctx context.Context, board *Board) error
+	Delete(ctx context.Context, boardID int) error
+	// Lock locks the board until the end of the transaction, the moves on a board are serialized.
+	Lock(ctx context.Context, boardID int) error
+	FetchCardsByBoardID(ctx context.Context, boardID int) ([]BoardCard, error)
+	SaveCards(ctx context.Context, cards ...BoardCard) error
+}
+
+type BoardUsecase interface {
+	Create(ctx context.Context, userID int, name string, columns []BoardColumn) (*Board, error)
+	FetchBoardsByUserID(ctx context.Context, userID int) ([]Board, error)
+	FetchBoardByID(ctx context.Context, boardID, userID int) (*Board, error)
+	Update(ctx context.Context, boardID, userID int, name string, columns []BoardColumn) (*Board, error)
+	Delete(ctx context.Context, boardID, userID int) error
+	Move(ctx context.Context, boardID, userID, taskID, column, position int) (*BoardCard, error)
+}
+
+// BoardCreateRequest creates a board, or replaces the name and the columns of a board on update.
+type BoardCreateRequest struct {
+	Name    string        `json:"name" binding:"required,max=100"`
+	Columns []BoardColumn `json:"columns" binding:"required,min=1,max=20,dive"`
+}
+
+type BoardFetchRequest struct {
+	ID int `uri:"boardID"`
+}
+
+// BoardMoveRequest moves the task to the position in the column, both counted from 0.
+// A position past the last card moves the task to the end of the column.
+type BoardMoveRequest struct {
+	TaskID   int  `json:"taskID" binding:"required"`
+	Column   *int `json:"column" binding:"required,min=0"`
+	Position *int `json:"position" binding:"required,min=0"`
+}
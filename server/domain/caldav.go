@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CalDAVObject is a task exposed as a calendar object resource of the CalDAV task collection.
+type CalDAVObject struct {
+	// Name is the resource name in the collection, e.g. "task-1.ics".
+	Name string
+	UID  string
+	Task Task
+}
+
+// CalDAVResource keeps the resource name and UID chosen by the CalDAV client that
+// created the task. The name belongs to the user of the client, the task is named after
+// its ID for the other users as well as the tasks created by the API.
+type CalDAVResource struct {
+	TaskID    int `gorm:"primaryKey;autoIncrement:false"`
+	UserID    int
+	Name      string
+	UID       string
+	CreatedAt time.Time
+}
+
+// CalDAVChanges are the changes of the task collection since a sync token. The tasks which
+// were deleted, archived or are no longer shared with the user are listed in Deleted.
+type CalDAVChanges struct {
+	Changed   []CalDAVObject
+	Deleted   []string
+	SyncToken int64
+}
+
+type CalDAVResourceRepository interface {
+	Create(ctx context.Context, resource *CalDAVResource) error
+	FetchResourceByName(ctx context.Context, userID int, name string) (*CalDAVResource, error)
+	FetchResourcesByTaskID(ctx context.Context, taskIDs ...int) ([]CalDAVResource, error)
+}
+
+type CalDAVUsecase interface {
+	// FetchSyncToken returns the ID of the user's latest task event, which is used as the ctag and the sync token.
+	FetchSyncToken(ctx context.Context, userID int) (int64, error)
+	FetchObjects(ctx context.Context, userID int) ([]CalDAVObject, error)
+	FetchObjectByName(ctx context.Context, userID int, name string) (*CalDAVObject, error)
+	FetchChanges(ctx context.Context, userID int, syncToken int64) (*CalDAVChanges, error)
+	// Put creates or updates the task of the resource, it returns true when the task is created.
+	Put(ctx context.Context, userID int, name, uid string, task Task) (*CalDAVObject, bool, error)
+	Delete(ctx context.Context, userID int, name string) error
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CalendarFeed is the secret URL of a user's iCalendar feed. Calendar clients cannot
+// use the session, so the token in the URL authenticates the feed instead. Only the
+// hash of the token is stored and a user has at most one feed.
+type CalendarFeed struct {
+	UserID    int       `json:"userID" gorm:"primaryKey;autoIncrement:false"`
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CalendarComponent string
+
+const (
+	CalendarComponentEvent CalendarComponent = "vevent"
+	CalendarComponentTodo  CalendarComponent = "vtodo"
+)
+
+// CalendarFeedRequest is the query string of the feed URL. The tasks are narrowed
+// down by the same filter as the task list.
+type CalendarFeedRequest struct {
+	TaskFilter
+	// Component renders the tasks as all-day events (default) or as to-dos.
+	Component CalendarComponent `form:"component" binding:"omitempty,oneof=vevent vtodo"`
+}
+
+type CalendarFeedRepository interface {
+	Save(ctx context.Context, feed *CalendarFeed) error
+	FetchFeedByTokenHash(ctx context.Context, tokenHash string) (*CalendarFeed, error)
+	DeleteByUserID(ctx context.Context, userID int) error
+}
+
+type CalendarFeedUsecase interface {
+	// CreateFeed issues a new token of the user's feed, the previous URL stops working.
+	CreateFeed(ctx context.Context, userID int) (string, error)
+	RevokeFeed(ctx context.Context, userID int) error
+	FetchTasksByToken(ctx context.Context, token string, filter TaskFilter) ([]Task, error)
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldText        CustomFieldType = "text"
+	CustomFieldNumber      CustomFieldType = "number"
+	CustomFieldDate        CustomFieldType = "date"
+	CustomFieldSelect      CustomFieldType = "select"
+	CustomFieldMultiSelect CustomFieldType = "multiSelect"
+	CustomFieldUser        CustomFieldType = "user"
+)
+
+// CustomField is a typed field the admins add to every task of the workspace. The
+// values of a task are stored under the key, which never changes once the field
+// is created. Options are the choices of the select and multiSelect fields.
+type CustomField struct {
+	ID        int             `json:"id"`
+	Key       string          `json:"key"`
+	Name      string          `json:"name"`
+	Type      CustomFieldType `json:"type"`
+	Options   StringList      `json:"options"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// CustomFieldValues are the values of the custom fields of a task keyed by the field
+// key. It is stored as a jsonb object so that a value can be matched with @>.
+type CustomFieldValues map[string]any
+
+func (v *CustomFieldValues) Scan(value interface{}) error {
+	switch b := value.(type) {
+	case []byte:
+		return json.Unmarshal(b, v)
+	case string:
+		return json.Unmarshal([]byte(b), v)
+	case nil:
+		*v = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for CustomFieldValues: %T", value)
+	}
+}
+
+func (v CustomFieldValues) Value() (driver.Value, error) {
+	if v == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+type CustomFieldRepository interface {
+	Create(ctx context.Context, field *CustomField) error
+	FetchFields(ctx context.Context) ([]CustomField, error)
+	FetchFieldByID(ctx context.Context, fieldID int) (*CustomField, error)
+	Update(ctx context.Context, field *CustomField) error
+	Delete(ctx context.Context, fieldID int) error
+	// RemoveTaskValues removes the values of the field from all the tasks, the deleted ones included.
+	RemoveTaskValues(ctx context.Context, key string) error
+}
+
+type CustomFieldUsecase interface {
+	Create(ctx context.Context, userID int, field CustomField) (*CustomField, error)
+	FetchFields(ctx context.Context) ([]CustomField, error)
+	Update(ctx context.Context, fieldID, userID int, name string, options []string) (*CustomField, error)
+	Delete(ctx context.Context, fieldID, userID int) error
+}
+
+type CustomFieldCreateRequest struct {
+	Key     string          `json:"key" binding:"required,max=50"`
+	Name    string          `json:"name" binding:"required,max=100"`
+	Type    CustomFieldType `json:"type" binding:"required,oneof=text number date select multiSelect user"`
+	Options []string        `json:"options" binding:"max=100,unique,dive,required,max=100"`
+}
+
+// CustomFieldUpdateRequest renames the field or replaces its options, the key and the type cannot be changed.
+type CustomFieldUpdateRequest struct {
+	Name    string   `json:"name" binding:"required,max=100"`
+	Options []string `json:"options" binding:"max=100,unique,dive,required,max=100"`
+}
+
+type CustomFieldFetchRequest struct {
+	ID int `uri:"fieldID"`
+}
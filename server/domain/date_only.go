@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateOnly is a calendar date without a time of day or a timezone, such as a due date.
+// The date is held at midnight UTC, so that the same day is always the same value
+// whatever the timezone of the server, the database session or the user.
+type DateOnly struct {
+	time.Time
+}
+
+// NewDateOnly parses a date formatted as "2006-01-02".
+func NewDateOnly(s string) (DateOnly, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return DateOnly{}, err
+	}
+	return DateOnly{Time: t}, nil
+}
+
+// MustDateOnly is like NewDateOnly but panics when the date is invalid, for the
+// dates written in the code.
+func MustDateOnly(s string) DateOnly {
+	d, err := NewDateOnly(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DateOf returns the date of t in the timezone of t.
+func DateOf(t time.Time) DateOnly {
+	year, month, day := t.Date()
+	return DateOnly{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// Today returns the current date in loc.
+func Today(loc *time.Location) DateOnly {
+	return DateOf(time.Now().In(loc))
+}
+
+func (d DateOnly) AddDays(n int) DateOnly {
+	return DateOnly{Time: d.Time.AddDate(0, 0, n)}
+}
+
+func (d DateOnly) String() string {
+	return d.Time.Format(time.DateOnly)
+}
+
+// UnmarshalJSON reads a "2006-01-02" string, null leaves the date unchanged.
+func (d *DateOnly) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return &time.ParseError{Value: string(b), Layout: time.DateOnly, LayoutElem: "2006", ValueElem: string(b)}
+	}
+	parsed, err := NewDateOnly(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d DateOnly) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalText reads the date of a query parameter.
+func (d *DateOnly) UnmarshalText(b []byte) error {
+	parsed, err := NewDateOnly(string(b))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d DateOnly) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Scan reads a DATE column. A time is cut to its date in its own timezone, so that the
+// timezone of the database session does not move the date.
+func (d *DateOnly) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = DateOnly{}
+	case time.Time:
+		*d = DateOf(v)
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into DateOnly", value)
+	}
+	return nil
+}
+
+// Value writes the date as a "2006-01-02" string rather than a time, which the database
+// would convert to a date in the timezone of the session.
+func (d DateOnly) Value() (driver.Value, error) {
+	return d.String(), nil
+}
@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDateOnly(t *testing.T) {
+	d, err := domain.NewDateOnly("2024-12-31")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), d.Time)
+
+	_, err = domain.NewDateOnly("2024-02-30")
+	assert.Error(t, err)
+}
+
+func TestDateOnlyScan(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	want := domain.MustDateOnly("2024-12-31")
+
+	tests := []struct {
+		title     string
+		value     any
+		want      domain.DateOnly
+		wantError bool
+	}{
+		{"time in utc", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), want, false},
+		// midnight in Tokyo is still the previous day in UTC
+		{"time in another timezone", time.Date(2024, 12, 31, 0, 0, 0, 0, tokyo), want, false},
+		{"string", "2024-12-31", want, false},
+		{"bytes", []byte("2024-12-31"), want, false},
+		{"null", nil, domain.DateOnly{}, false},
+		{"invalid string", "31/12/2024", domain.DateOnly{}, true},
+		{"unsupported type", 20241231, domain.DateOnly{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			var d domain.DateOnly
+			err := d.Scan(tt.value)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestDateOnlyJSON(t *testing.T) {
+	var v struct {
+		DueDate domain.DateOnly `json:"dueDate"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"dueDate":"2024-12-31"}`), &v))
+	assert.Equal(t, domain.MustDateOnly("2024-12-31"), v.DueDate)
+
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"dueDate":"2024-12-31"}`, string(b))
+
+	var parseErr *time.ParseError
+	assert.ErrorAs(t, json.Unmarshal([]byte(`{"dueDate":"2024-12-31T09:00:00Z"}`), &v), &parseErr)
+	assert.ErrorAs(t, json.Unmarshal([]byte(`{"dueDate":20241231}`), &v), &parseErr)
+
+	value, err := domain.MustDateOnly("2024-12-31").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-12-31", value)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DigestRecipient is a user who receives the daily digest with the settings of the digest.
+type DigestRecipient struct {
+	UserID     int
+	Name       string
+	Email      string
+	DigestHour int
+	Timezone   string
+	Locale     string
+}
+
+// DigestDelivery records the digest sent to a user for a day, so that the digest is
+// not sent twice when the server restarts or runs on several replicas.
+type DigestDelivery struct {
+	UserID     int      `gorm:"primaryKey;autoIncrement:false"`
+	DigestDate DateOnly `gorm:"primaryKey"`
+	CreatedAt  time.Time
+}
+
+type DigestRepository interface {
+	// FetchRecipients returns the users who have not disabled the digest, defaults
+	// fills the settings of the users without a stored setting.
+	FetchRecipients(ctx context.Context, defaults *UserSetting) ([]DigestRecipient, error)
+	// FetchDueTasks returns the open tasks of the user due on today or before, the
+	// same tasks as the ones listed by GET /tasks.
+	FetchDueTasks(ctx context.Context, userID int, today DateOnly) ([]Task, error)
+	// Claim records the digest of the day, ok is false when it has already been recorded.
+	Claim(ctx context.Context, userID int, date DateOnly) (ok bool, err error)
+	// Release removes the record of a digest which failed to be sent so that it is retried.
+	Release(ctx context.Context, userID int, date DateOnly) error
+}
+
+type DigestUsecase interface {
+	// SendDueDigests sends the digest to the users whose send hour has come today and
+	// who have not received it yet, it returns the number of digests sent.
+	SendDueDigests(ctx context.Context, now time.Time) (int, error)
+}
@@ -1,12 +1,62 @@
 package domain
 
+// ErrorResponse is the problem details of RFC 7807, rendered as application/problem+json.
 type ErrorResponse struct {
-	Message string      `json:"message"`
-	Errors  []ErrorItem `json:"errors"`
+	// Type identifies the error code, Title is the message of the code in the locale of the
+	// request. Detail is not localized, it is meant for the developers.
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Instance is the trace ID of the request, which is logged with the error.
+	Instance string `json:"instance,omitempty"`
+	Code     int    `json:"code"`
+	// Errors are the fields which failed the validation.
+	Errors []FieldError `json:"errors,omitempty"`
 }
 
+// FieldError is a field failing a validation rule, Field is the JSON name or the parameter
+// name of the field and Message is in the locale of the request.
+type FieldError struct {
+	Field string `json:"field"`
+	// Rule is the validation rule such as required or max, Param its parameter.
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ErrorItem is the error of an item of a batch, such as a task of a bulk operation.
 type ErrorItem struct {
 	Code        int    `json:"code"`
 	Message     string `json:"message"`
 	Description string `json:"description,omitempty"`
 }
+
+// ErrorCatalog lists the error codes and the messages of the validation rules in a
+// locale, so that the clients can render the errors without a request.
+type ErrorCatalog struct {
+	Locale string            `json:"locale"`
+	Errors []ErrorDefinition `json:"errors"`
+	// Rules are the messages of FieldError keyed by the rule, the rules comparing a size
+	// have the keys such as "max.string" and "max.items" as well. {field} and {param} are
+	// replaced with the field and the parameter of the rule.
+	Rules map[string]string `json:"rules"`
+}
+
+// ErrorDefinition describes an error code, Type is the type of its problem details.
+type ErrorDefinition struct {
+	Code   int    `json:"code"`
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+}
+
+type ErrorCatalogRequest struct {
+	// Locale defaults to the one of Accept-Language.
+	Locale string `form:"locale" binding:"omitempty,oneof=en ja"`
+}
+
+type ErrorDefinitionRequest struct {
+	Code   int    `uri:"code" binding:"required"`
+	Locale string `form:"locale" binding:"omitempty,oneof=en ja"`
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// IntList is stored as a jsonb array so that it can be matched with @>.
+type IntList []int
+
+func (l IntList) Contains(v int) bool {
+	for _, i := range l {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *IntList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for IntList: %T", value)
+	}
+}
+
+func (l IntList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// StringList is stored as a jsonb array so that it can be matched with @>.
+type StringList []string
+
+func (l StringList) Contains(v string) bool {
+	for _, s := range l {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for StringList: %T", value)
+	}
+}
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
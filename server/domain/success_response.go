@@ -3,4 +3,33 @@ package domain
 type SuccessResponse struct {
 	Message string `json:"message,omitempty"`
 	Tasks   []Task `json:"tasks,omitempty"`
+
+	Results     []TaskSearchResult `json:"results,omitempty"`
+	BulkResults []TaskBulkResult   `json:"bulkResults,omitempty"`
+	Imports     []TaskImportResult `json:"imports,omitempty"`
+	Activities  []TaskActivity     `json:"activities,omitempty"`
+	Setting     *UserSetting       `json:"setting,omitempty"`
+	Views       []TaskView         `json:"views,omitempty"`
+	CalendarURL string             `json:"calendarURL,omitempty"`
+	QuickAdd    *TaskQuickAdd      `json:"quickAdd,omitempty"`
+
+	Boards []Board    `json:"boards,omitempty"`
+	Card   *BoardCard `json:"card,omitempty"`
+
+	Templates []TaskTemplate `json:"templates,omitempty"`
+
+	CustomFields []CustomField `json:"customFields,omitempty"`
+
+	TimeEntries []TimeEntry `json:"timeEntries,omitempty"`
+	Timesheet   *Timesheet  `json:"timesheet,omitempty"`
+
+	Stats *TaskStats `json:"stats,omitempty"`
+
+	AppPasswords []AppPassword `json:"appPasswords,omitempty"`
+
+	Webhooks   []Webhook         `json:"webhooks,omitempty"`
+	Deliveries []WebhookDelivery `json:"deliveries,omitempty"`
+
+	Catalog    *ErrorCatalog    `json:"catalog,omitempty"`
+	Definition *ErrorDefinition `json:"definition,omitempty"`
 }
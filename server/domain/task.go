@@ -2,64 +2,154 @@ package domain
 
 import (
 	"context"
-	"database/sql/driver"
-	"fmt"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type Task struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Completed   bool      `json:"completed"`
-	CreatedBy   int       `json:"createdBy"`
-	DueDate     DateOnly  `json:"dueDate"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Completed   bool   `json:"completed"`
+	CreatedBy   int    `json:"createdBy"`
+	// ParentID is the task the subtask belongs to, nil for a top-level task.
+	ParentID *int         `json:"parentID"`
+	DueDate  DateOnly     `json:"dueDate"`
+	Priority TaskPriority `json:"priority"`
+	// EstimateMinutes is the estimated effort, nil when the task is not estimated.
+	EstimateMinutes *int `json:"estimateMinutes"`
+	// Assignees are the users who must do the task, every assignee can read the task.
+	Assignees IntList    `json:"assignees"`
+	Labels    StringList `json:"labels"`
+	// CustomFields are the values of the custom fields of the workspace keyed by the field key.
+	CustomFields CustomFieldValues `json:"customFields"`
+	// TrackedSeconds is the time tracked on the task by all the users, it is only
+	// filled by the task list and the task detail.
+	TrackedSeconds int64          `json:"trackedSeconds" gorm:"->"`
+	CompletedAt    *time.Time     `json:"completedAt"`
+	ArchivedAt     *time.Time     `json:"archivedAt"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	DeletedAt      gorm.DeletedAt `json:"deletedAt"`
 }
 
-type DateOnly struct {
-	time.Time
-}
+type TaskPriority string
 
-func NewDateOnly(t string) DateOnly {
-	parseTime, _ := time.Parse("2006-01-02", t)
-	return DateOnly{Time: parseTime}
-}
+const (
+	TaskPriorityNone   TaskPriority = "none"
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
 
-func (d *DateOnly) UnmarshalJSON(b []byte) error {
-	parsedTime, err := time.Parse(`"2006-01-02"`, string(b))
-	if err != nil {
-		return &time.ParseError{Value: string(b), Layout: "2006-01-02", LayoutElem: "2006", ValueElem: string(b)}
-	}
-	d.Time = parsedTime
-	return nil
-}
+// TaskSort orders the task list. priority lists the most important tasks first and
+// then the ones due earlier, dueDate the tasks due earlier first and createdAt the
+// newest tasks first.
+type TaskSort string
 
-func (d DateOnly) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf(`"%s"`, d.Time.Format("2006-01-02"))), nil
-}
+const (
+	TaskSortPriority  TaskSort = "priority"
+	TaskSortDueDate   TaskSort = "dueDate"
+	TaskSortCreatedAt TaskSort = "createdAt"
+)
 
-func (d *DateOnly) Scan(value interface{}) error {
-	d.Time = value.(time.Time)
-	return nil
+// TaskSearchResult is a task matched by the full-text search. The highlights are
+// the title and an excerpt of the description with the matched words wrapped in <mark>.
+type TaskSearchResult struct {
+	Task
+	Rank                 float64 `json:"rank"`
+	TitleHighlight       string  `json:"titleHighlight"`
+	DescriptionHighlight string  `json:"descriptionHighlight"`
 }
 
-func (d DateOnly) Value() (driver.Value, error) {
-	return d.Time, nil
+type TaskOwner string
+
+const (
+	TaskOwnerMe     TaskOwner = "me"
+	TaskOwnerOthers TaskOwner = "others"
+)
+
+type TaskAssignee string
+
+const (
+	TaskAssigneeMe   TaskAssignee = "me"
+	TaskAssigneeNone TaskAssignee = "none"
+)
+
+// TaskFilter narrows down the tasks listed by GET /tasks. Saved views store it as JSON,
+// so a view uses the same fields as the query string of the task list.
+type TaskFilter struct {
+	// Archived lists the archived tasks instead of the active ones.
+	Archived  bool  `json:"archived,omitempty" form:"archived"`
+	Completed *bool `json:"completed,omitempty" form:"completed"`
+	// Overdue lists the uncompleted tasks whose due date has passed.
+	Overdue bool `json:"overdue,omitempty" form:"overdue"`
+	// DueWithinDays lists the tasks due from today to this many days later, 0 lists the tasks due today.
+	DueWithinDays *int `json:"dueWithinDays,omitempty" form:"dueWithinDays" binding:"omitempty,min=0,max=365"`
+	// Owner lists the tasks created by the user ("me") or shared with the user by others ("others").
+	Owner TaskOwner `json:"owner,omitempty" form:"owner" binding:"omitempty,oneof=me others"`
+	// Assignee lists the tasks assigned to the user ("me") or to nobody ("none").
+	Assignee TaskAssignee `json:"assignee,omitempty" form:"assignee" binding:"omitempty,oneof=me none"`
+	// Label lists the tasks having the label.
+	Label string `json:"label,omitempty" form:"label" binding:"omitempty,max=50"`
+	// Priority lists the tasks of any of the priorities.
+	Priority []TaskPriority `json:"priority,omitempty" form:"priority" binding:"omitempty,dive,oneof=none low medium high urgent"`
+	Sort     TaskSort       `json:"sort,omitempty" form:"sort" binding:"omitempty,oneof=priority dueDate createdAt"`
+	// Field lists the tasks whose custom field has the value, written as key:value such as
+	// customer:acme. A multiSelect field matches when the value is one of its options and
+	// a user field takes "me" for the user.
+	Field []string `json:"field,omitempty" form:"field" binding:"omitempty,max=10,dive,required,max=200"`
+	// SortField orders the tasks by the value of the custom field, the tasks without a
+	// value come last. It takes precedence over Sort.
+	SortField string `json:"sortField,omitempty" form:"sortField" binding:"omitempty,max=50"`
+
+	// FieldMatches are the Field conditions typed by the definitions of the fields,
+	// they are filled by the usecase and matched with @> by the repository.
+	FieldMatches []CustomFieldValues `json:"-" form:"-"`
+	// Today is the current date in the user's timezone, it is filled by the usecase
+	// for Overdue and DueWithinDays.
+	Today DateOnly `json:"-" form:"-"`
 }
 
 type TaskRepository interface {
 	Create(ctx context.Context, task *Task) (int, error)
-	FetchAllTaskByTaskID(ctx context.Context, taskIDs ...int) ([]Task, error)
+	FetchAllTaskByTaskID(ctx context.Context, userID int, filter TaskFilter, taskIDs ...int) ([]Task, error)
 	FetchTaskByTaskID(ctx context.Context, taskID int) (*Task, error)
 	Update(ctx context.Context, taskID int, updateFields map[string]any) error
 	Delete(ctx context.Context, taskID int) error
+	FetchDeletedTasksByTaskID(ctx context.Context, taskIDs ...int) ([]Task, error)
+	Restore(ctx context.Context, taskID int) error
+	FetchTaskIDsDeletedBefore(ctx context.Context, before time.Time, limit int) ([]int, error)
+	Purge(ctx context.Context, taskIDs ...int) error
+	FetchTaskIDsToAutoArchive(ctx context.Context, userID int, completedBefore time.Time) ([]int, error)
+	Search(ctx context.Context, query string, limit int, taskIDs ...int) ([]TaskSearchResult, error)
+	// IndexSearchVector computes the full-text search vector of the task.
+	IndexSearchVector(ctx context.Context, taskID int) error
+	FetchAllTaskInBatches(ctx context.Context, batchSize int, fn func([]Task) error, taskIDs ...int) error
 }
 
 type TaskUsecase interface {
-	Create(ctx context.Context, title string, description string, userID int, due_date DateOnly) error
-	FetchAllTaskByUserID(ctx context.Context, userID int) ([]Task, error)
+	Create(ctx context.Context, title string, description string, userID int, due_date DateOnly, priority TaskPriority, estimateMinutes *int,
+		customFields CustomFieldValues) (int, error)
+	FetchAllTaskByUserID(ctx context.Context, userID int, filter TaskFilter) ([]Task, error)
 	FetchTaskByTaskID(ctx context.Context, taskID, userID int) (*Task, error)
-	Update(ctx context.Context, taskID, userID int, title, description string, due_date DateOnly) error
+	Update(ctx context.Context, taskID, userID int, title, description string, due_date DateOnly, priority TaskPriority, estimateMinutes *int,
+		customFields CustomFieldValues) error
+	Complete(ctx context.Context, taskID, userID int, completed bool) error
 	Delete(ctx context.Context, taskID, userID int) error
+	Share(ctx context.Context, taskID, userID, targetUserID int, canEdit bool) error
+	Assign(ctx context.Context, taskID, userID int, assigneeIDs []int) error
+	SetLabels(ctx context.Context, taskID, userID int, labels []string) error
+	AddSubtask(ctx context.Context, parentID, userID int, title, description string) (int, error)
+	FetchActivitiesByTaskID(ctx context.Context, taskID, userID int) ([]TaskActivity, error)
+	FetchTrashByUserID(ctx context.Context, userID int) ([]Task, error)
+	Restore(ctx context.Context, taskID, userID int) error
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int, error)
+	Archive(ctx context.Context, taskID, userID int, archived bool) error
+	AutoArchive(ctx context.Context, userID int, completedFor time.Duration) (int, error)
+	Search(ctx context.Context, userID int, query string, limit int) ([]TaskSearchResult, error)
+	Bulk(ctx context.Context, userID int, taskIDs []int, filter *TaskFilter, op TaskBulkOperation, allOrNothing bool) ([]TaskBulkResult, error)
+	Export(ctx context.Context, userID int, fn func([]Task) error) error
+	Import(ctx context.Context, userID int, records []TaskImportRecord, dryRun bool) ([]TaskImportResult, error)
 }
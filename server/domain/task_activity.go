@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+type TaskActivityAction string
+
+const (
+	TaskActivityCreated           TaskActivityAction = "created"
+	TaskActivityUpdated           TaskActivityAction = "updated"
+	TaskActivityCompleted         TaskActivityAction = "completed"
+	TaskActivityReopened          TaskActivityAction = "reopened"
+	TaskActivityDeleted           TaskActivityAction = "deleted"
+	TaskActivityRestored          TaskActivityAction = "restored"
+	TaskActivityArchived          TaskActivityAction = "archived"
+	TaskActivityUnarchived        TaskActivityAction = "unarchived"
+	TaskActivityPermissionGranted TaskActivityAction = "permission_granted"
+	TaskActivityAssigned          TaskActivityAction = "assigned"
+	TaskActivityLabeled           TaskActivityAction = "labeled"
+)
+
+// FieldChange holds the value of a field before and after a mutation.
+// Before is null for the fields set by the creation.
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// TaskActivity is an entry of the audit trail of a task.
+type TaskActivity struct {
+	ID        int64                  `json:"id"`
+	TaskID    int                    `json:"taskID"`
+	ActorID   int                    `json:"actorID"`
+	Action    TaskActivityAction     `json:"action"`
+	Changes   map[string]FieldChange `json:"changes" gorm:"serializer:json"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// Diff returns the fields whose value differs between before and after.
+// A field missing on one side is reported with a null value.
+func Diff(before, after map[string]any) map[string]FieldChange {
+	changes := map[string]FieldChange{}
+	for field, a := range after {
+		b, ok := before[field]
+		if ok && reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes[field] = FieldChange{Before: b, After: a}
+	}
+	for field, b := range before {
+		if _, ok := after[field]; !ok {
+			changes[field] = FieldChange{Before: b}
+		}
+	}
+	return changes
+}
+
+type TaskActivityRepository interface {
+	Create(ctx context.Context, activity *TaskActivity) error
+	FetchActivitiesByTaskID(ctx context.Context, taskID int) ([]TaskActivity, error)
+	DeleteByTaskID(ctx context.Context, taskIDs ...int) error
+}
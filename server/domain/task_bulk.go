@@ -0,0 +1,50 @@
+package domain
+
+type TaskBulkAction string
+
+const (
+	TaskBulkComplete    TaskBulkAction = "complete"
+	TaskBulkDelete      TaskBulkAction = "delete"
+	TaskBulkMoveDueDate TaskBulkAction = "move_due_date"
+	TaskBulkShare       TaskBulkAction = "share"
+	TaskBulkAddLabel    TaskBulkAction = "add_label"
+	TaskBulkRemoveLabel TaskBulkAction = "remove_label"
+)
+
+// TaskBulkOperation is the operation applied to every task of a bulk request.
+type TaskBulkOperation struct {
+	Action TaskBulkAction `json:"action" binding:"required,oneof=complete delete move_due_date share add_label remove_label"`
+	// Completed is the state set by complete, true when omitted.
+	Completed *bool `json:"completed"`
+	// DueDate is the due date set by move_due_date, ShiftDays moves the due date by the days instead.
+	DueDate   *DateOnly `json:"dueDate"`
+	ShiftDays int       `json:"shiftDays"`
+	// UserID and CanEdit are the permission granted by share.
+	UserID  int  `json:"userID"`
+	CanEdit bool `json:"canEdit"`
+	// Label is the label added by add_label or removed by remove_label.
+	Label string `json:"label" binding:"omitempty,max=50"`
+}
+
+type TaskBulkStatus string
+
+const (
+	TaskBulkSucceeded  TaskBulkStatus = "succeeded"
+	TaskBulkFailed     TaskBulkStatus = "failed"
+	TaskBulkRolledBack TaskBulkStatus = "rolled_back"
+)
+
+type TaskBulkResult struct {
+	TaskID int            `json:"taskID"`
+	Status TaskBulkStatus `json:"status"`
+	Error  *ErrorItem     `json:"error,omitempty"`
+}
+
+// TaskBulkRequest selects the tasks by TaskIDs or by Filter. With AllOrNothing
+// nothing is changed when the operation fails for any of the tasks.
+type TaskBulkRequest struct {
+	TaskIDs      []int             `json:"taskIDs" binding:"required_without=Filter,max=500"`
+	Filter       *TaskFilter       `json:"filter"`
+	Operation    TaskBulkOperation `json:"operation"`
+	AllOrNothing bool              `json:"allOrNothing"`
+}
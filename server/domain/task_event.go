@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type TaskEventType string
+
+const (
+	TaskEventCreated           TaskEventType = "task.created"
+	TaskEventUpdated           TaskEventType = "task.updated"
+	TaskEventCompleted         TaskEventType = "task.completed"
+	TaskEventDeleted           TaskEventType = "task.deleted"
+	TaskEventRestored          TaskEventType = "task.restored"
+	TaskEventArchived          TaskEventType = "task.archived"
+	TaskEventUnarchived        TaskEventType = "task.unarchived"
+	TaskEventPermissionGranted TaskEventType = "permission.granted"
+	TaskEventAssigned          TaskEventType = "task.assigned"
+)
+
+// TaskEvent is a domain event of a task. It is stored in the outbox (task_events)
+// in the same transaction as the change and relayed to the handlers afterwards.
+type TaskEvent struct {
+	ID           int64         `json:"id"`
+	Type         TaskEventType `json:"type"`
+	TaskID       int           `json:"taskID"`
+	Task         Task          `json:"task" gorm:"serializer:json;column:payload"`
+	Recipients   IntList       `json:"-"`
+	DispatchedAt *time.Time    `json:"-"`
+	// Attempts counts the relays a handler failed on the event, FailedAt is set when
+	// the relay gave up on the event and LastError holds the error of the last attempt.
+	Attempts  int        `json:"-" gorm:"<-:update"`
+	LastError string     `json:"-" gorm:"<-:update"`
+	FailedAt  *time.Time `json:"-" gorm:"<-:update"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type TaskEventRepository interface {
+	Create(ctx context.Context, event *TaskEvent) error
+	FetchEventByID(ctx context.Context, id int64) (*TaskEvent, error)
+	FetchEventsAfterID(ctx context.Context, userID int, lastEventID int64) ([]TaskEvent, error)
+	FetchLatestEventID(ctx context.Context, userID int) (int64, error)
+	FetchNextPendingEvent(ctx context.Context) (*TaskEvent, error)
+	MarkDispatched(ctx context.Context, eventID int64) error
+	MarkProcessed(ctx context.Context, handler string, eventID int64) (bool, error)
+	// MarkAttemptFailed counts a failed relay of the event, failed takes the event out
+	// of the pending events.
+	MarkAttemptFailed(ctx context.Context, eventID int64, lastError string, failed bool) error
+	Notify(ctx context.Context, eventID int64) error
+}
+
+// TaskEventHandler consumes the events relayed from the outbox.
+// Events are delivered at least once, Handle runs in a savepoint of the relay
+// transaction with the record that the handler processed the event, so the changes
+// Handle makes through ctx are committed or rolled back with the record. Handle is
+// skipped for the events the handler has already processed.
+type TaskEventHandler interface {
+	Name() string
+	Handle(ctx context.Context, event TaskEvent) error
+}
+
+// TaskAuditLog is the record of a task event kept by the "audit" event handler.
+type TaskAuditLog struct {
+	EventID    int64         `json:"eventID" gorm:"primaryKey;autoIncrement:false"`
+	Type       TaskEventType `json:"type"`
+	TaskID     int           `json:"taskID"`
+	Task       Task          `json:"task" gorm:"serializer:json;column:payload"`
+	Recipients IntList       `json:"recipients"`
+	OccurredAt time.Time     `json:"occurredAt"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+type TaskAuditLogRepository interface {
+	// Create records the log, a log already recorded for the event is kept as it is.
+	Create(ctx context.Context, log *TaskAuditLog) error
+}
+
+type TaskNotificationRepository interface {
+	// MarkAssigneeNotified records that the assignee has been notified of the task
+	// and returns false when it had already been recorded.
+	MarkAssigneeNotified(ctx context.Context, taskID, userID int) (bool, error)
+}
+
+type TaskEventSubscription struct {
+	// Missed holds the events stored after the requested last event ID.
+	Missed []TaskEvent
+	Events <-chan TaskEvent
+	Close  func()
+}
+
+type TaskEventUsecase interface {
+	Publish(ctx context.Context, eventType TaskEventType, task Task) error
+	Subscribe(ctx context.Context, userID int, lastEventID int64) (*TaskEventSubscription, error)
+	Dispatch(ctx context.Context, eventID int64) error
+	Relay(ctx context.Context) (int, error)
+}
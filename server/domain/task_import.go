@@ -0,0 +1,42 @@
+package domain
+
+// TaskImportRecord is a row of an imported file before it is validated. A record
+// with a TaskID matches an existing task, which is only completed or uncompleted
+// as Completed tells, the other records create new tasks.
+type TaskImportRecord struct {
+	Row         int
+	TaskID      int
+	Title       string
+	Description string
+	DueDate     string
+	Completed   *bool
+}
+
+type TaskImportStatus string
+
+const (
+	TaskImportValid   TaskImportStatus = "valid"
+	TaskImportInvalid TaskImportStatus = "invalid"
+	TaskImportCreated TaskImportStatus = "created"
+	// TaskImportUpdated and TaskImportUnchanged tell whether the completion of a matched task is changed.
+	TaskImportUpdated   TaskImportStatus = "updated"
+	TaskImportUnchanged TaskImportStatus = "unchanged"
+)
+
+type TaskImportResult struct {
+	Row    int              `json:"row"`
+	Status TaskImportStatus `json:"status"`
+	Errors []string         `json:"errors,omitempty"`
+}
+
+type TaskExportRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json markdown"`
+}
+
+// TaskImportRequest is sent as multipart/form-data together with the file.
+// Mapping is a JSON object mapping the task fields to the columns of the file.
+type TaskImportRequest struct {
+	Format  string `form:"format" binding:"omitempty,oneof=csv json markdown"`
+	Mapping string `form:"mapping"`
+	DryRun  bool   `form:"dryRun"`
+}
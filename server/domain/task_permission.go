@@ -14,6 +14,8 @@ type TaskPermissionRepository interface {
 	GrantPermission(ctx context.Context, taskPermission *TaskPermission) error
 	FetchTaskIDByUserID(ctx context.Context, id int, canEdit, canRead bool) ([]int, error)
 	FetchPermissionByTaskID(ctx context.Context, taskID, userID int) (*TaskPermission, error)
+	FetchUserIDByTaskID(ctx context.Context, taskID int) ([]int, error)
+	DeleteByTaskID(ctx context.Context, taskIDs ...int) error
 	// GetPermissionByUserID(ctx context.Context, taskID, userID int) (*TaskPermission, error)
 	// Update(ctx context.Context, taskPermission *TaskPermission) error
 }
@@ -0,0 +1,19 @@
+package domain
+
+// TaskQuickAddRequest is a task written in a single line such as
+// "Deploy API next friday #backend !high @alice". The relative dates are
+// resolved in Timezone, an IANA name, or in the user's timezone when it is empty.
+type TaskQuickAddRequest struct {
+	Text     string `json:"text" binding:"required,max=500"`
+	Timezone string `json:"timezone" binding:"omitempty,timezone"`
+}
+
+// TaskQuickAdd is the task parsed from a quick-add line. It is not saved, the
+// client confirms or edits it and creates the task.
+type TaskQuickAdd struct {
+	Title     string       `json:"title"`
+	DueDate   *DateOnly    `json:"dueDate,omitempty"`
+	Labels    []string     `json:"labels,omitempty"`
+	Priority  TaskPriority `json:"priority,omitempty"`
+	Assignees []string     `json:"assignees,omitempty"`
+}
@@ -1,18 +1,56 @@
 package domain
 
 type TaskCreateRequest struct {
-	Title       string   `json:"title" binding:"required"`
-	Description string   `json:"description" binding:"required"`
-	DueDate     DateOnly `json:"dueDate" binding:"required"`
+	Title           string       `json:"title" binding:"required"`
+	Description     string       `json:"description" binding:"required"`
+	DueDate         DateOnly     `json:"dueDate" binding:"required"`
+	Priority        TaskPriority `json:"priority" binding:"omitempty,oneof=none low medium high urgent"`
+	EstimateMinutes *int         `json:"estimateMinutes" binding:"omitempty,min=0,max=100000"`
+	// CustomFields are validated against the custom fields of the workspace.
+	CustomFields CustomFieldValues `json:"customFields"`
+}
+
+type TaskSubtaskCreateRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
 }
 
 type TaskUpdateRequest struct {
-	ID          int      `uri:"taskID"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	DueDate     DateOnly `json:"dueDate"`
+	ID              int          `uri:"taskID"`
+	Title           string       `json:"title"`
+	Description     string       `json:"description"`
+	DueDate         DateOnly     `json:"dueDate"`
+	Priority        TaskPriority `json:"priority" binding:"omitempty,oneof=none low medium high urgent"`
+	EstimateMinutes *int         `json:"estimateMinutes" binding:"omitempty,min=0,max=100000"`
+	// CustomFields set the values of the given custom fields, the values of the other fields
+	// are kept and a null value removes the value of the field.
+	CustomFields CustomFieldValues `json:"customFields"`
+}
+
+type TaskSearchRequest struct {
+	Query string `form:"q" binding:"required,max=200"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
 }
 
 type TaskFetchRequest struct {
 	ID int `uri:"taskID"`
 }
+
+type TaskCompleteRequest struct {
+	Completed *bool `json:"completed" binding:"required"`
+}
+
+type TaskShareRequest struct {
+	UserID  int  `json:"userID" binding:"required"`
+	CanEdit bool `json:"canEdit"`
+}
+
+// TaskAssignRequest replaces the assignees of the task, an empty list unassigns everyone.
+type TaskAssignRequest struct {
+	UserIDs []int `json:"userIDs" binding:"required,max=20,unique,dive,min=1"`
+}
+
+// TaskLabelRequest replaces the labels of the task, an empty list removes all the labels.
+type TaskLabelRequest struct {
+	Labels []string `json:"labels" binding:"required,max=20,dive,required,max=50"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TaskStats are the statistics of the tasks listed by GET /tasks, the archived and
+// deleted tasks are not counted.
+type TaskStats struct {
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
+	// Overdue are the open tasks whose due date has passed.
+	Overdue int `json:"overdue"`
+	// DueThisWeek are the open tasks due from today to the end of the week on Sunday.
+	DueThisWeek int `json:"dueThisWeek"`
+	// AverageCompletionHours is the average time from the creation to the completion of
+	// the completed tasks, nil when no task has been completed.
+	AverageCompletionHours *float64 `json:"averageCompletionHours"`
+	// Trend is the number of tasks completed on each day of the window, the oldest first.
+	Trend []TaskStatsDay `json:"trend"`
+}
+
+type TaskStatsDay struct {
+	Date      DateOnly `json:"date"`
+	Completed int      `json:"completed"`
+}
+
+// TaskStatsCounts are the counts of TaskStats computed in one query.
+type TaskStatsCounts struct {
+	Open                     int
+	Completed                int
+	Overdue                  int
+	DueThisWeek              int
+	AverageCompletionSeconds *float64
+}
+
+type TaskStatsRepository interface {
+	// FetchCounts counts the tasks the user can read, today and weekEnd bound the overdue
+	// and the due this week tasks.
+	FetchCounts(ctx context.Context, userID int, owner TaskOwner, today, weekEnd DateOnly) (*TaskStatsCounts, error)
+	// FetchCompletionTrend counts the tasks completed on each day in loc from the start of
+	// from, the days without a completed task are omitted.
+	FetchCompletionTrend(ctx context.Context, userID int, owner TaskOwner, from DateOnly, loc *time.Location) ([]TaskStatsDay, error)
+}
+
+type TaskStatsUsecase interface {
+	FetchStats(ctx context.Context, userID int, owner TaskOwner, days int) (*TaskStats, error)
+}
+
+type TaskStatsFormat string
+
+const (
+	TaskStatsFormatJSON TaskStatsFormat = "json"
+	TaskStatsFormatCSV  TaskStatsFormat = "csv"
+)
+
+type TaskStatsRequest struct {
+	// Days is the window of the trend ending today, 30 days by default.
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+	// Owner counts the tasks created by the user ("me") or shared with the user by others ("others").
+	Owner  TaskOwner       `form:"owner" binding:"omitempty,oneof=me others"`
+	Format TaskStatsFormat `form:"format" binding:"omitempty,oneof=json csv"`
+}
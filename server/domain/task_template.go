@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TaskTemplate is a named skeleton of a task and its subtasks. The texts and the
+// labels may hold placeholders such as {{client}} filled in on instantiation, and
+// DueOffset such as "+3 days" gives the due date relative to the day of instantiation.
+type TaskTemplate struct {
+	ID          int                   `json:"id"`
+	UserID      int                   `json:"userID"`
+	Name        string                `json:"name"`
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	Subtasks    []TaskTemplateSubtask `json:"subtasks" gorm:"serializer:json"`
+	Labels      StringList            `json:"labels"`
+	DueOffset   string                `json:"dueOffset"`
+	CreatedAt   time.Time             `json:"createdAt"`
+}
+
+type TaskTemplateSubtask struct {
+	Title       string `json:"title" binding:"required,max=255"`
+	Description string `json:"description"`
+}
+
+type TaskTemplateRepository interface {
+	Create(ctx context.Context, template *TaskTemplate) error
+	FetchTemplateByID(ctx context.Context, templateID int) (*TaskTemplate, error)
+	FetchTemplatesByUserID(ctx context.Context, userID int) ([]TaskTemplate, error)
+	Delete(ctx context.Context, templateID int) error
+}
+
+type TaskTemplateUsecase interface {
+	Create(ctx context.Context, userID int, template TaskTemplate) (*TaskTemplate, error)
+	FetchTemplatesByUserID(ctx context.Context, userID int) ([]TaskTemplate, error)
+	FetchTemplateByID(ctx context.Context, templateID, userID int) (*TaskTemplate, error)
+	Delete(ctx context.Context, templateID, userID int) error
+	// Instantiate creates the task and its subtasks of the template, the task comes first.
+	Instantiate(ctx context.Context, templateID, userID int, variables map[string]string) ([]Task, error)
+}
+
+type TaskTemplateCreateRequest struct {
+	Name        string                `json:"name" binding:"required,max=100"`
+	Title       string                `json:"title" binding:"required,max=255"`
+	Description string                `json:"description"`
+	Subtasks    []TaskTemplateSubtask `json:"subtasks" binding:"max=50,dive"`
+	Labels      []string              `json:"labels" binding:"max=20,dive,required,max=50"`
+	DueOffset   string                `json:"dueOffset" binding:"max=20"`
+}
+
+type TaskTemplateFetchRequest struct {
+	ID int `uri:"templateID"`
+}
+
+// TaskTemplateInstantiateRequest gives the values of the placeholders by their names.
+type TaskTemplateInstantiateRequest struct {
+	Variables map[string]string `json:"variables"`
+}
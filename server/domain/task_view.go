@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TaskView is a named task filter. The built-in views are identified by their Key
+// and the views saved by a user by their ID. A shared view is listed to every user of
+// the workspace, it runs on the tasks of the user running it, not of its owner.
+type TaskView struct {
+	ID        int        `json:"id,omitempty"`
+	Key       string     `json:"key,omitempty" gorm:"-"`
+	UserID    int        `json:"userID,omitempty"`
+	Name      string     `json:"name"`
+	Filter    TaskFilter `json:"filter" gorm:"serializer:json"`
+	Shared    bool       `json:"shared"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// BuiltInTaskViews returns the views every user has.
+func BuiltInTaskViews() []TaskView {
+	today, week := 0, 7
+	return []TaskView{
+		{Key: "today", Name: "Today", Filter: TaskFilter{DueWithinDays: &today}},
+		{Key: "overdue", Name: "Overdue", Filter: TaskFilter{Overdue: true}},
+		{Key: "upcoming", Name: "Upcoming 7 days", Filter: TaskFilter{DueWithinDays: &week}},
+		{Key: "shared", Name: "Shared with me", Filter: TaskFilter{Owner: TaskOwnerOthers}},
+		{Key: "mine", Name: "Created by me", Filter: TaskFilter{Owner: TaskOwnerMe}},
+	}
+}
+
+type TaskViewRepository interface {
+	Create(ctx context.Context, view *TaskView) error
+	FetchViewByID(ctx context.Context, viewID int) (*TaskView, error)
+	// FetchViewsByUserID returns the views saved by the user and the views shared by the others.
+	FetchViewsByUserID(ctx context.Context, userID int) ([]TaskView, error)
+	SetShared(ctx context.Context, viewID int, shared bool) error
+	Delete(ctx context.Context, viewID int) error
+}
+
+type TaskViewUsecase interface {
+	Create(ctx context.Context, userID int, name string, filter TaskFilter, shared bool) (*TaskView, error)
+	FetchViewsByUserID(ctx context.Context, userID int) ([]TaskView, error)
+	// Share shares the view with the workspace or stops sharing it, only its owner can.
+	Share(ctx context.Context, viewID, userID int, shared bool) (*TaskView, error)
+	Delete(ctx context.Context, viewID, userID int) error
+	FetchTasksByView(ctx context.Context, view string, userID int) ([]Task, error)
+}
+
+type TaskViewCreateRequest struct {
+	Name   string     `json:"name" binding:"required,max=100"`
+	Filter TaskFilter `json:"filter"`
+	Shared bool       `json:"shared"`
+}
+
+type TaskViewShareRequest struct {
+	ID     int   `uri:"viewID"`
+	Shared *bool `json:"shared" binding:"required"`
+}
+
+type TaskViewFetchRequest struct {
+	// ID is the key of a built-in view or the ID of a saved view.
+	ID string `uri:"viewID" binding:"required"`
+}
+
+type TaskViewDeleteRequest struct {
+	ID int `uri:"viewID"`
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TimeEntry is a period a user worked on a task, tracked by a timer. A user has
+// at most one running timer.
+type TimeEntry struct {
+	ID        int       `json:"id"`
+	TaskID    int       `json:"taskID"`
+	TaskTitle string    `json:"taskTitle,omitempty" gorm:"->"`
+	UserID    int       `json:"userID"`
+	StartedAt time.Time `json:"startedAt"`
+	// StoppedAt is nil while the timer is running.
+	StoppedAt *time.Time `json:"stoppedAt"`
+}
+
+// Seconds returns the tracked time, up to now for a running timer.
+func (e TimeEntry) Seconds(now time.Time) int64 {
+	end := now
+	if e.StoppedAt != nil {
+		end = *e.StoppedAt
+	}
+	return int64(end.Sub(e.StartedAt) / time.Second)
+}
+
+// Timesheet is the time tracked by a user from From to To, both inclusive.
+// The time is divided into days at midnight UTC.
+type Timesheet struct {
+	From         DateOnly        `json:"from"`
+	To           DateOnly        `json:"to"`
+	TotalSeconds int64           `json:"totalSeconds"`
+	Days         []TimesheetDay  `json:"days"`
+	Tasks        []TimesheetTask `json:"tasks"`
+}
+
+type TimesheetDay struct {
+	Date    DateOnly `json:"date"`
+	Seconds int64    `json:"seconds"`
+}
+
+type TimesheetTask struct {
+	TaskID  int    `json:"taskID"`
+	Title   string `json:"title"`
+	Seconds int64  `json:"seconds"`
+}
+
+type TimeEntryRepository interface {
+	Create(ctx context.Context, entry *TimeEntry) error
+	FetchRunningEntryByUserID(ctx context.Context, userID int) (*TimeEntry, error)
+	Stop(ctx context.Context, entryID int, stoppedAt time.Time) error
+	FetchEntriesByTaskID(ctx context.Context, taskID int) ([]TimeEntry, error)
+	// FetchEntriesByUserID returns the entries of the user overlapping the period from from to to.
+	FetchEntriesByUserID(ctx context.Context, userID int, from, to time.Time) ([]TimeEntry, error)
+}
+
+type TimeEntryUsecase interface {
+	Start(ctx context.Context, taskID, userID int) (*TimeEntry, error)
+	Stop(ctx context.Context, taskID, userID int) (*TimeEntry, error)
+	FetchRunningEntry(ctx context.Context, userID int) (*TimeEntry, error)
+	FetchEntriesByTaskID(ctx context.Context, taskID, userID int) ([]TimeEntry, error)
+	FetchTimesheet(ctx context.Context, userID int, from, to DateOnly) (*Timesheet, error)
+}
+
+type TimesheetRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02" time_utc:"1" binding:"required"`
+	To   time.Time `form:"to" time_format:"2006-01-02" time_utc:"1" binding:"required"`
+}
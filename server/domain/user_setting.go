@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UserSetting holds the preferences of a user. A user without a stored
+// setting uses the defaults of NewUserSetting.
+type UserSetting struct {
+	UserID int `json:"userID" gorm:"primaryKey;autoIncrement:false"`
+	// AutoArchiveDays archives the user's tasks completed more than this many days ago, 0 disables it.
+	AutoArchiveDays int `json:"autoArchiveDays"`
+	// DigestEnabled sends the daily digest email of the overdue and the due today tasks.
+	DigestEnabled bool `json:"digestEnabled"`
+	// DigestHour is the hour of the day the digest is sent at, in the user's timezone.
+	DigestHour int `json:"digestHour"`
+	// Timezone is the IANA name of the user's timezone such as "Asia/Tokyo".
+	Timezone string `json:"timezone"`
+	// Locale is the language of the emails and the error messages, "en" or "ja".
+	Locale    string    `json:"locale"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func NewUserSetting(userID int) *UserSetting {
+	return &UserSetting{
+		UserID:        userID,
+		DigestEnabled: true,
+		DigestHour:    8,
+		Timezone:      "Asia/Tokyo",
+		Locale:        "ja",
+	}
+}
+
+// Location returns the user's timezone, the default timezone when it is unknown.
+func (s *UserSetting) Location() *time.Location {
+	if loc, err := time.LoadLocation(s.Timezone); err == nil {
+		return loc
+	}
+	loc, err := time.LoadLocation(NewUserSetting(s.UserID).Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// UserSettingUpdateRequest updates the given settings, the omitted ones are kept.
+type UserSettingUpdateRequest struct {
+	AutoArchiveDays *int    `json:"autoArchiveDays" binding:"omitempty,min=0,max=3650"`
+	DigestEnabled   *bool   `json:"digestEnabled"`
+	DigestHour      *int    `json:"digestHour" binding:"omitempty,min=0,max=23"`
+	Timezone        *string `json:"timezone" binding:"omitnil,timezone"`
+	Locale          *string `json:"locale" binding:"omitnil,oneof=en ja"`
+}
+
+type UserSettingRepository interface {
+	FetchSettingByUserID(ctx context.Context, userID int) (*UserSetting, error)
+	Save(ctx context.Context, setting *UserSetting) error
+	FetchAutoArchiveSettings(ctx context.Context) ([]UserSetting, error)
+}
+
+type UserSettingUsecase interface {
+	FetchSettingByUserID(ctx context.Context, userID int) (*UserSetting, error)
+	Update(ctx context.Context, userID int, request UserSettingUpdateRequest) (*UserSetting, error)
+	FetchAutoArchiveSettings(ctx context.Context) ([]UserSetting, error)
+}
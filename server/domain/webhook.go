@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// WebhookEventAll subscribes a webhook to every event type.
+const WebhookEventAll = "*"
+
+type Webhook struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"userID"`
+	URL        string     `json:"url"`
+	Secret     string     `json:"secret,omitempty"`
+	EventTypes StringList `json:"eventTypes"`
+	Active     bool       `json:"active"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID             int64                 `json:"id"`
+	WebhookID      int                   `json:"webhookID"`
+	EventType      TaskEventType         `json:"eventType"`
+	Payload        json.RawMessage       `json:"payload" gorm:"type:jsonb"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt"`
+	LastStatusCode int                   `json:"lastStatusCode,omitempty"`
+	LastError      string                `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time             `json:"createdAt"`
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	FetchWebhookByID(ctx context.Context, webhookID int) (*Webhook, error)
+	FetchWebhooksByUserID(ctx context.Context, userID int) ([]Webhook, error)
+	FetchSubscribedWebhooks(ctx context.Context, eventType TaskEventType, userIDs ...int) ([]Webhook, error)
+	Delete(ctx context.Context, webhookID int) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	FetchDeliveryByID(ctx context.Context, deliveryID int64) (*WebhookDelivery, error)
+	FetchDeliveriesByWebhookID(ctx context.Context, webhookID int) ([]WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	Update(ctx context.Context, deliveryID int64, updateFields map[string]any) error
+}
+
+type WebhookUsecase interface {
+	Register(ctx context.Context, userID int, url string, eventTypes []string) (*Webhook, error)
+	FetchWebhooksByUserID(ctx context.Context, userID int) ([]Webhook, error)
+	Delete(ctx context.Context, webhookID, userID int) error
+	FetchDeliveries(ctx context.Context, webhookID, userID int) ([]WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID int, deliveryID int64, userID int) error
+	Enqueue(ctx context.Context, event TaskEvent) error
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+type WebhookCreateRequest struct {
+	URL        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,dive,oneof=* task.created task.updated task.completed task.deleted task.restored task.archived task.unarchived permission.granted task.assigned"`
+}
+
+type WebhookFetchRequest struct {
+	ID int `uri:"webhookID"`
+}
+
+type WebhookRedeliverRequest struct {
+	ID         int   `uri:"webhookID"`
+	DeliveryID int64 `uri:"deliveryID"`
+}
@@ -0,0 +1,59 @@
+// Package cache keeps values in memory for a short time, for the results that are
+// expensive to compute and may be slightly stale such as the statistics.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is a map whose entries expire after the TTL. It is safe for concurrent use.
+// The expired entries are dropped when they are read or when a value is set.
+type Cache[K comparable, V any] struct {
+	TTL time.Duration
+	// Now returns the current time, it is replaced in the tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries map[K]entry[V]
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		TTL:     ttl,
+		Now:     time.Now,
+		entries: map[K]entry[V]{},
+	}
+}
+
+// Get returns the value of the key, ok is false when there is none or it has expired.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return value, false
+	}
+	if !c.Now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		return value, false
+	}
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.Now()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = entry[V]{value: value, expiresAt: now.Add(c.TTL)}
+}
@@ -0,0 +1,28 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/internal/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+	c := cache.New[string, int](time.Minute)
+	c.Now = func() time.Time { return now }
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	// the entry expires after the TTL
+	now = now.Add(time.Minute)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,148 @@
+package caldav_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/internal/caldav"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePropfind(t *testing.T) {
+	tests := []struct {
+		title       string
+		body        string
+		wantRequest caldav.PropRequest
+		wantError   bool
+	}{
+		{
+			"empty body",
+			"",
+			caldav.PropRequest{AllProp: true},
+			false,
+		},
+		{
+			"prop",
+			`<?xml version="1.0"?><d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/">` +
+				`<d:prop><d:getetag/><cs:getctag/></d:prop></d:propfind>`,
+			caldav.PropRequest{Names: []xml.Name{caldav.GetETag, caldav.GetCTag}},
+			false,
+		},
+		{
+			"allprop",
+			`<propfind xmlns="DAV:"><allprop/></propfind>`,
+			caldav.PropRequest{AllProp: true},
+			false,
+		},
+		{
+			"unexpected element",
+			`<propertyupdate xmlns="DAV:"/>`,
+			caldav.PropRequest{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			request, err := caldav.ParsePropfind(strings.NewReader(tt.body))
+
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRequest, request)
+			}
+		})
+	}
+}
+
+func TestParseReport(t *testing.T) {
+	tests := []struct {
+		title      string
+		body       string
+		wantReport *caldav.Report
+		wantError  bool
+	}{
+		{
+			"calendar-multiget",
+			`<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+				`<d:prop><d:getetag/><c:calendar-data/></d:prop>` +
+				`<d:href>/caldav/tasks/task-1.ics</d:href><d:href>/caldav/tasks/abc.ics</d:href></c:calendar-multiget>`,
+			&caldav.Report{
+				Name:  caldav.ReportCalendarMultiget,
+				Prop:  caldav.PropRequest{Names: []xml.Name{caldav.GetETag, caldav.CalendarData}},
+				Hrefs: []string{"/caldav/tasks/task-1.ics", "/caldav/tasks/abc.ics"},
+			},
+			false,
+		},
+		{
+			"sync-collection",
+			`<d:sync-collection xmlns:d="DAV:"><d:sync-token>urn:task-management-app:sync:3</d:sync-token>` +
+				`<d:sync-level>1</d:sync-level><d:prop><d:getetag/></d:prop></d:sync-collection>`,
+			&caldav.Report{
+				Name:      caldav.ReportSyncCollection,
+				Prop:      caldav.PropRequest{Names: []xml.Name{caldav.GetETag}},
+				SyncToken: "urn:task-management-app:sync:3",
+			},
+			false,
+		},
+		{
+			"unsupported report",
+			`<c:free-busy-query xmlns:c="urn:ietf:params:xml:ns:caldav"/>`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			report, err := caldav.ParseReport(strings.NewReader(tt.body))
+
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantReport, report)
+			}
+		})
+	}
+}
+
+func TestWriteMultistatus(t *testing.T) {
+	request := caldav.PropRequest{Names: []xml.Name{caldav.GetETag, {Space: "http://apple.com/ns/ical/", Local: "calendar-color"}}}
+	responses := []caldav.Response{
+		caldav.NewResponse("/caldav/tasks/task-1.ics", request, []caldav.Property{
+			caldav.Text(caldav.GetETag, `"abc"`),
+			caldav.Text(caldav.GetContentType, "text/calendar"),
+		}),
+		{Href: "/caldav/tasks/task-2.ics", Status: http.StatusNotFound},
+	}
+
+	var b strings.Builder
+	err := caldav.WriteMultistatus(&b, responses, caldav.FormatSyncToken(5))
+
+	assert.NoError(t, err)
+	assert.Equal(t, xml.Header+
+		`<d:multistatus xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/" xmlns:c="urn:ietf:params:xml:ns:caldav">`+
+		`<d:response><d:href>/caldav/tasks/task-1.ics</d:href>`+
+		`<d:propstat><d:prop><d:getetag>&#34;abc&#34;</d:getetag></d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>`+
+		`<d:propstat><d:prop><x:calendar-color xmlns:x="http://apple.com/ns/ical/"/></d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>`+
+		`</d:response>`+
+		`<d:response><d:href>/caldav/tasks/task-2.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status></d:response>`+
+		`<d:sync-token>urn:task-management-app:sync:5</d:sync-token></d:multistatus>`, b.String())
+}
+
+func TestParseSyncToken(t *testing.T) {
+	token, err := caldav.ParseSyncToken(caldav.FormatSyncToken(42))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), token)
+
+	token, err = caldav.ParseSyncToken("")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), token)
+
+	_, err = caldav.ParseSyncToken("42")
+	assert.Error(t, err)
+}
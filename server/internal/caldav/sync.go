@@ -0,0 +1,49 @@
+package caldav
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+// syncTokenPrefix makes the sync token a URI as required by RFC 6578.
+const syncTokenPrefix = "urn:task-management-app:sync:"
+
+func FormatSyncToken(token int64) string {
+	return syncTokenPrefix + strconv.FormatInt(token, 10)
+}
+
+// ParseSyncToken returns 0 for an empty token, which is the initial sync.
+func ParseSyncToken(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	token, err := strconv.ParseInt(strings.TrimPrefix(s, syncTokenPrefix), 10, 64)
+	if err != nil || !strings.HasPrefix(s, syncTokenPrefix) || token < 0 {
+		return 0, fmt.Errorf("caldav: invalid sync token %q", s)
+	}
+	return token, nil
+}
+
+// ETag returns the entity tag of the calendar object, which changes when one of the
+// fields written to the VTODO changes.
+func ETag(object domain.CalDAVObject) string {
+	task := object.Task
+	completedAt := ""
+	if task.CompletedAt != nil {
+		completedAt = task.CompletedAt.UTC().String()
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		object.UID,
+		task.Title,
+		task.Description,
+		strconv.FormatBool(task.Completed),
+		task.DueDate.Format("2006-01-02"),
+		completedAt,
+	}, "\x00")))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -0,0 +1,264 @@
+// Package caldav reads and writes the WebDAV XML bodies of the CalDAV task collection
+// (RFC 4791) and its sync (RFC 6578).
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	NamespaceDAV            = "DAV:"
+	NamespaceCalDAV         = "urn:ietf:params:xml:ns:caldav"
+	NamespaceCalendarServer = "http://calendarserver.org/ns/"
+)
+
+// prefixes are declared on the multistatus element, the properties of the other
+// namespaces declare their namespace themselves.
+var prefixes = map[string]string{
+	NamespaceDAV:            "d",
+	NamespaceCalDAV:         "c",
+	NamespaceCalendarServer: "cs",
+}
+
+// Property names used by the task collection.
+var (
+	ResourceType                  = xml.Name{Space: NamespaceDAV, Local: "resourcetype"}
+	DisplayName                   = xml.Name{Space: NamespaceDAV, Local: "displayname"}
+	GetETag                       = xml.Name{Space: NamespaceDAV, Local: "getetag"}
+	GetContentType                = xml.Name{Space: NamespaceDAV, Local: "getcontenttype"}
+	CurrentUserPrincipal          = xml.Name{Space: NamespaceDAV, Local: "current-user-principal"}
+	PrincipalURL                  = xml.Name{Space: NamespaceDAV, Local: "principal-URL"}
+	SyncToken                     = xml.Name{Space: NamespaceDAV, Local: "sync-token"}
+	SupportedReportSet            = xml.Name{Space: NamespaceDAV, Local: "supported-report-set"}
+	CalendarHomeSet               = xml.Name{Space: NamespaceCalDAV, Local: "calendar-home-set"}
+	CalendarData                  = xml.Name{Space: NamespaceCalDAV, Local: "calendar-data"}
+	SupportedCalendarComponentSet = xml.Name{Space: NamespaceCalDAV, Local: "supported-calendar-component-set"}
+	GetCTag                       = xml.Name{Space: NamespaceCalendarServer, Local: "getctag"}
+)
+
+// Report names.
+const (
+	ReportCalendarQuery    = "calendar-query"
+	ReportCalendarMultiget = "calendar-multiget"
+	ReportSyncCollection   = "sync-collection"
+)
+
+// PropRequest is the properties requested by PROPFIND or REPORT. AllProp requests every
+// property except the expensive ones, such as calendar-data.
+type PropRequest struct {
+	AllProp bool
+	Names   []xml.Name
+}
+
+// Report is the body of a REPORT request. The filters of calendar-query are not
+// evaluated, the collection only holds VTODO, so every task is returned.
+type Report struct {
+	Name      string
+	Prop      PropRequest
+	Hrefs     []string
+	SyncToken string
+}
+
+type anyElement struct {
+	XMLName xml.Name
+}
+
+type propElement struct {
+	Names []anyElement `xml:",any"`
+}
+
+type requestBody struct {
+	XMLName   xml.Name
+	AllProp   *struct{}    `xml:"DAV: allprop"`
+	Prop      *propElement `xml:"DAV: prop"`
+	Hrefs     []string     `xml:"DAV: href"`
+	SyncToken string       `xml:"DAV: sync-token"`
+}
+
+func (b requestBody) propRequest() PropRequest {
+	if b.Prop == nil {
+		return PropRequest{AllProp: true}
+	}
+	request := PropRequest{AllProp: b.AllProp != nil}
+	for _, name := range b.Prop.Names {
+		request.Names = append(request.Names, name.XMLName)
+	}
+	return request
+}
+
+// ParsePropfind reads the body of PROPFIND, an empty body requests all the properties.
+func ParsePropfind(r io.Reader) (PropRequest, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return PropRequest{}, err
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return PropRequest{AllProp: true}, nil
+	}
+
+	var body requestBody
+	if err := xml.Unmarshal(b, &body); err != nil {
+		return PropRequest{}, err
+	}
+	if body.XMLName.Space != NamespaceDAV || body.XMLName.Local != "propfind" {
+		return PropRequest{}, fmt.Errorf("caldav: unexpected element %s", body.XMLName.Local)
+	}
+	return body.propRequest(), nil
+}
+
+func ParseReport(r io.Reader) (*Report, error) {
+	var body requestBody
+	if err := xml.NewDecoder(r).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Name:      body.XMLName.Local,
+		Prop:      body.propRequest(),
+		Hrefs:     body.Hrefs,
+		SyncToken: strings.TrimSpace(body.SyncToken),
+	}
+	switch {
+	case body.XMLName.Space == NamespaceCalDAV && report.Name == ReportCalendarQuery,
+		body.XMLName.Space == NamespaceCalDAV && report.Name == ReportCalendarMultiget,
+		body.XMLName.Space == NamespaceDAV && report.Name == ReportSyncCollection:
+		return report, nil
+	default:
+		return nil, fmt.Errorf("caldav: unsupported report %s", report.Name)
+	}
+}
+
+// Property is a property with its value, Value is the inner XML of the property element.
+type Property struct {
+	Name  xml.Name
+	Value string
+}
+
+// Text returns a property of a text value.
+func Text(name xml.Name, text string) Property {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(text))
+	return Property{Name: name, Value: b.String()}
+}
+
+// Href returns a property of a href, such as current-user-principal.
+func Href(name xml.Name, href string) Property {
+	return Property{Name: name, Value: "<d:href>" + escape(href) + "</d:href>"}
+}
+
+// Raw returns a property of an XML value which uses the prefixes d, c and cs.
+func Raw(name xml.Name, value string) Property {
+	return Property{Name: name, Value: value}
+}
+
+// Response is a resource of a multistatus. A response with a Status and no properties
+// reports a resource without its properties, such as a deleted resource in a sync report.
+type Response struct {
+	Href       string
+	Properties []Property
+	NotFound   []xml.Name
+	Status     int
+}
+
+// NewResponse returns the response of the requested properties of the resource.
+func NewResponse(href string, request PropRequest, properties []Property) Response {
+	response := Response{Href: href}
+	if request.AllProp && len(request.Names) == 0 {
+		for _, property := range properties {
+			if property.Name != CalendarData {
+				response.Properties = append(response.Properties, property)
+			}
+		}
+		return response
+	}
+
+	byName := make(map[xml.Name]Property, len(properties))
+	for _, property := range properties {
+		byName[property.Name] = property
+	}
+	for _, name := range request.Names {
+		if property, ok := byName[name]; ok {
+			response.Properties = append(response.Properties, property)
+		} else {
+			response.NotFound = append(response.NotFound, name)
+		}
+	}
+	return response
+}
+
+// WriteMultistatus writes a 207 multistatus body, the sync token is only written for sync reports.
+func WriteMultistatus(w io.Writer, responses []Response, syncToken string) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<d:multistatus`)
+	namespaces := make([]string, 0, len(prefixes))
+	for namespace := range prefixes {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		fmt.Fprintf(&b, ` xmlns:%s="%s"`, prefixes[namespace], escape(namespace))
+	}
+	b.WriteString(">")
+
+	for _, response := range responses {
+		b.WriteString("<d:response><d:href>" + escape(response.Href) + "</d:href>")
+		if response.Status != 0 {
+			b.WriteString("<d:status>" + statusLine(response.Status) + "</d:status>")
+		}
+		if len(response.Properties) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, property := range response.Properties {
+				writeElement(&b, property.Name, property.Value)
+			}
+			b.WriteString("</d:prop><d:status>" + statusLine(http.StatusOK) + "</d:status></d:propstat>")
+		}
+		if len(response.NotFound) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, name := range response.NotFound {
+				writeElement(&b, name, "")
+			}
+			b.WriteString("</d:prop><d:status>" + statusLine(http.StatusNotFound) + "</d:status></d:propstat>")
+		}
+		b.WriteString("</d:response>")
+	}
+	if syncToken != "" {
+		b.WriteString("<d:sync-token>" + escape(syncToken) + "</d:sync-token>")
+	}
+	b.WriteString("</d:multistatus>")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeElement(b *strings.Builder, name xml.Name, value string) {
+	tag := name.Local
+	declaration := ""
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		tag = "x:" + name.Local
+		declaration = ` xmlns:x="` + escape(name.Space) + `"`
+	}
+	if value == "" {
+		b.WriteString("<" + tag + declaration + "/>")
+		return
+	}
+	b.WriteString("<" + tag + declaration + ">" + value + "</" + tag + ">")
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package digest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	d := digest.Digest{
+		Name:     "alice",
+		Date:     time.Date(2024, 12, 2, 0, 0, 0, 0, time.UTC),
+		Overdue:  []domain.Task{{Title: "write <report>", DueDate: domain.MustDateOnly("2024-12-01")}},
+		DueToday: []domain.Task{{Title: "buy milk", DueDate: domain.MustDateOnly("2024-12-02")}},
+	}
+
+	tests := []struct {
+		title       string
+		locale      string
+		wantSubject string
+		wantText    string
+		wantHTML    string
+	}{
+		{
+			"en",
+			"en",
+			"Tasks for Dec 2, 2024: 1 overdue, 1 due today",
+			"Hi alice,\n\nOverdue tasks:\n- write <report> (due Dec 1, 2024)\n\nTasks due today:\n- buy milk\n\n" +
+				"You can change the send hour or stop these emails in your settings.\n",
+			"<li>write &lt;report&gt; (due Dec 1, 2024)</li>",
+		},
+		{
+			"ja",
+			"ja",
+			"2024年12月2日のタスク: 期限切れ 1 件、今日が期限 1 件",
+			"alice さん\n\n期限切れのタスク:\n- write <report> (期限: 2024年12月1日)\n\n今日が期限のタスク:\n- buy milk\n\n" +
+				"送信時刻の変更や配信の停止は設定から行えます。\n",
+			"<li>write &lt;report&gt; (期限: 2024年12月1日)</li>",
+		},
+		{
+			"unknown locale falls back on en",
+			"fr",
+			"Tasks for Dec 2, 2024: 1 overdue, 1 due today",
+			"Hi alice,\n\nOverdue tasks:\n- write <report> (due Dec 1, 2024)\n\nTasks due today:\n- buy milk\n\n" +
+				"You can change the send hour or stop these emails in your settings.\n",
+			"<li>buy milk</li>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			msg, err := digest.Render(tt.locale, d)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSubject, msg.Subject)
+			assert.Equal(t, tt.wantText, msg.Text)
+			assert.Contains(t, msg.HTML, tt.wantHTML)
+		})
+	}
+}
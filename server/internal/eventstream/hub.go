@@ -0,0 +1,64 @@
+package eventstream
+
+import (
+	"sync"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+const bufferSize = 32
+
+type subscriber struct {
+	userID int
+	ch     chan domain.TaskEvent
+}
+
+// Hub fans task events out to the streams connected to this server.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[*subscriber]struct{}{},
+	}
+}
+
+func (h *Hub) Subscribe(userID int) (<-chan domain.TaskEvent, func()) {
+	s := &subscriber{
+		userID: userID,
+		ch:     make(chan domain.TaskEvent, bufferSize),
+	}
+
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers, s)
+			h.mu.Unlock()
+			close(s.ch)
+		})
+	}
+	return s.ch, unsubscribe
+}
+
+func (h *Hub) Broadcast(event domain.TaskEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for s := range h.subscribers {
+		if !event.Recipients.Contains(s.userID) {
+			continue
+		}
+		// a slow client must not block the others, it can resume with Last-Event-ID
+		select {
+		case s.ch <- event:
+		default:
+		}
+	}
+}
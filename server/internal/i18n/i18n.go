@@ -0,0 +1,62 @@
+// Package i18n holds the English and Japanese messages of the error codes and the
+// validation rules, and chooses the locale of a request.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used when neither the request nor the user names a supported locale.
+const DefaultLocale = "en"
+
+// Locales are the supported locales, the same as UserSetting.Locale.
+var Locales = []string{"en", "ja"}
+
+// Supported reports whether the locale has the catalogs.
+func Supported(locale string) bool {
+	for _, l := range Locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate returns the supported locale the Accept-Language header prefers most,
+// e.g. "ja" for "ja-JP,ja;q=0.9,en;q=0.8". It returns false when the header names
+// none of the supported locales.
+func Negotiate(acceptLanguage string) (string, bool) {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		// only the primary language matters, "ja-JP" is "ja"
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !Supported(primary) {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{locale: primary, q: q})
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	// the stable sort keeps the order of the header among the same weights
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].locale, true
+}
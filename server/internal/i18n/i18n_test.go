@@ -0,0 +1,91 @@
+package i18n_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/internal/i18n"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		title          string
+		acceptLanguage string
+		wantLocale     string
+		wantOK         bool
+	}{
+		{"empty", "", "", false},
+		{"region", "ja-JP", "ja", true},
+		{"first of the same weights", "en-US,ja", "en", true},
+		{"highest weight", "en;q=0.5,ja-JP;q=0.9,fr", "ja", true},
+		{"unsupported only", "fr-FR,de;q=0.8", "", false},
+		{"skip unsupported", "fr-FR,ja;q=0.7,en;q=0.3", "ja", true},
+		{"refused", "ja;q=0,en;q=0.1", "en", true},
+		{"wildcard", "*", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			locale, ok := i18n.Negotiate(tt.acceptLanguage)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantLocale, locale)
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	assert.Equal(t, "task not found", i18n.ErrorMessage("en", myerror.CodeTaskNotFound))
+	assert.Equal(t, "タスクが見つかりません", i18n.ErrorMessage("ja", myerror.CodeTaskNotFound))
+	assert.Equal(t, "task not found", i18n.ErrorMessage("fr", myerror.CodeTaskNotFound))
+
+	// every code is translated
+	for code, message := range myerror.ErrMessages {
+		for _, locale := range i18n.Locales {
+			if locale == i18n.DefaultLocale {
+				continue
+			}
+			assert.NotEqual(t, message, i18n.ErrorMessage(locale, code), "code %d is not translated into %s", code, locale)
+		}
+	}
+}
+
+func TestFieldMessage(t *testing.T) {
+	tests := []struct {
+		title  string
+		locale string
+		rule   string
+		param  string
+		kind   reflect.Kind
+		want   string
+	}{
+		{"required", "en", "required", "", reflect.String, "title is required"},
+		{"required ja", "ja", "required", "", reflect.String, "titleは必須です"},
+		{"max of a string", "en", "max", "100", reflect.String, "title must be at most 100 characters long"},
+		{"max of a string ja", "ja", "max", "100", reflect.String, "titleは100文字以内で入力してください"},
+		{"max of a slice", "en", "max", "20", reflect.Slice, "title must contain at most 20 items"},
+		{"max of a number", "ja", "max", "23", reflect.Int, "titleは23以下で入力してください"},
+		{"oneof", "en", "oneof", "en ja", reflect.String, "title must be one of [en ja]"},
+		{"unknown rule", "ja", "uuid", "", reflect.String, "titleが正しくありません"},
+		{"unknown locale", "fr", "required", "", reflect.String, "title is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.FieldMessage(tt.locale, "title", tt.rule, tt.param, tt.kind))
+		})
+	}
+}
+
+func TestRuleMessages(t *testing.T) {
+	// the catalogs have the same rules
+	en := i18n.RuleMessages("en")
+	for _, locale := range i18n.Locales {
+		messages := i18n.RuleMessages(locale)
+		assert.Len(t, messages, len(en))
+		for rule := range en {
+			assert.Contains(t, messages, rule, "rule %s is not translated into %s", rule, locale)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+// errorMessages translate the messages of myerror.ErrMessages, which are the English ones.
+var errorMessages = map[string]map[myerror.ErrorCode]string{
+	"ja": {
+		// 1000
+		myerror.CodeValidtaionFailed:    "入力内容に誤りがあります",
+		myerror.CodeContextUserNotFound: "ユーザー情報を取得できませんでした",
+		myerror.CodeHashPasswordFailed:  "パスワードの処理に失敗しました",
+		myerror.CodeCreateSessionFailed: "セッションの作成に失敗しました",
+		myerror.CodeNoLogin:             "ログインしていません",
+
+		// 2000
+		myerror.CodeUserAlreadyExists:        "このユーザーは既に登録されています",
+		myerror.CodeInvalidPassword:          "パスワードが正しくありません",
+		myerror.CodeTaskArchived:             "タスクはアーカイブされています",
+		myerror.CodePreconditionFailed:       "タスクが他の操作で更新されています",
+		myerror.CodeTimerAlreadyRunning:      "タイマーは既に動いています",
+		myerror.CodeCustomFieldAlreadyExists: "カスタムフィールドは既に存在します",
+
+		// 3000
+		myerror.CodeQueryFailed:             "データベースの操作に失敗しました",
+		myerror.CodeTaskNotFound:            "タスクが見つかりません",
+		myerror.CodeUserNotFound:            "ユーザーが見つかりません",
+		myerror.CodeGrantPermissionFailed:   "権限の付与に失敗しました",
+		myerror.CodePermissionNotFound:      "権限がありません",
+		myerror.CodePermissionDenied:        "この操作は許可されていません",
+		myerror.CodeTransactionNotFound:     "トランザクションを取得できませんでした",
+		myerror.CodeTaskEventNotFound:       "タスクイベントが見つかりません",
+		myerror.CodeWebhookNotFound:         "Webhookが見つかりません",
+		myerror.CodeWebhookDeliveryNotFound: "Webhookの配信履歴が見つかりません",
+		myerror.CodeTaskViewNotFound:        "ビューが見つかりません",
+		myerror.CodeCalendarFeedNotFound:    "カレンダーフィードが見つかりません",
+		myerror.CodeAppPasswordNotFound:     "アプリパスワードが見つかりません",
+		myerror.CodeCalDAVObjectNotFound:    "カレンダーのオブジェクトが見つかりません",
+		myerror.CodeTimeEntryNotFound:       "作業記録が見つかりません",
+		myerror.CodeBoardNotFound:           "ボードが見つかりません",
+		myerror.CodeTaskTemplateNotFound:    "テンプレートが見つかりません",
+		myerror.CodeCustomFieldNotFound:     "カスタムフィールドが見つかりません",
+		myerror.CodeErrorDefinitionNotFound: "エラーコードが見つかりません",
+
+		// 9999
+		myerror.CodeUnExpected: "予期しないエラーが発生しました",
+	},
+}
+
+// ruleMessages are keyed by the validator tags, the rules comparing a size are keyed by
+// the tag and the kind of the field as well, e.g. "max.string" for the length of a
+// string and "max.items" for the number of items. {field} and {param} are replaced with
+// the name of the field and the parameter of the rule. "type" is not a validator tag,
+// it is the value of a JSON field of the wrong type.
+var ruleMessages = map[string]map[string]string{
+	"en": {
+		"required":         "{field} is required",
+		"required_without": "{field} is required when {param} is not present",
+		"min":              "{field} must be {param} or greater",
+		"min.string":       "{field} must be at least {param} characters long",
+		"min.items":        "{field} must contain at least {param} items",
+		"max":              "{field} must be {param} or less",
+		"max.string":       "{field} must be at most {param} characters long",
+		"max.items":        "{field} must contain at most {param} items",
+		"oneof":            "{field} must be one of [{param}]",
+		"email":            "{field} must be a valid email address",
+		"url":              "{field} must be a valid URL",
+		"unique":           "{field} must not contain duplicate values",
+		"timezone":         "{field} must be an IANA timezone such as Asia/Tokyo",
+		"type":             "{field} must be of type {param}",
+		"default":          "{field} is invalid",
+	},
+	"ja": {
+		"required":         "{field}は必須です",
+		"required_without": "{param}を指定しない場合、{field}は必須です",
+		"min":              "{field}は{param}以上で入力してください",
+		"min.string":       "{field}は{param}文字以上で入力してください",
+		"min.items":        "{field}は{param}件以上指定してください",
+		"max":              "{field}は{param}以下で入力してください",
+		"max.string":       "{field}は{param}文字以内で入力してください",
+		"max.items":        "{field}は{param}件以内で指定してください",
+		"oneof":            "{field}は[{param}]のいずれかを指定してください",
+		"email":            "{field}には有効なメールアドレスを入力してください",
+		"url":              "{field}には有効なURLを入力してください",
+		"unique":           "{field}に重複した値は指定できません",
+		"timezone":         "{field}にはAsia/TokyoのようなIANAタイムゾーンを指定してください",
+		"type":             "{field}は{param}型で指定してください",
+		"default":          "{field}が正しくありません",
+	},
+}
+
+// ErrorMessage returns the message of the error code in the locale, in English when
+// the locale does not translate it.
+func ErrorMessage(locale string, code myerror.ErrorCode) string {
+	if message, ok := errorMessages[locale][code]; ok {
+		return message
+	}
+	return myerror.ErrMessages[code]
+}
+
+// RuleMessages returns the messages of the validation rules in the locale, see
+// FieldMessage for the keys and the placeholders.
+func RuleMessages(locale string) map[string]string {
+	messages, ok := ruleMessages[locale]
+	if !ok {
+		messages = ruleMessages[DefaultLocale]
+	}
+	copied := make(map[string]string, len(messages))
+	for rule, message := range messages {
+		copied[rule] = message
+	}
+	return copied
+}
+
+// FieldMessage returns the message of the field failing the rule in the locale, the
+// kind of the field chooses the message of the rules comparing a size.
+func FieldMessage(locale, field, rule, param string, kind reflect.Kind) string {
+	messages, ok := ruleMessages[locale]
+	if !ok {
+		messages = ruleMessages[DefaultLocale]
+	}
+	message, ok := messages[rule+sizeSuffix(kind)]
+	if !ok {
+		message, ok = messages[rule]
+	}
+	if !ok {
+		message = messages["default"]
+	}
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(message)
+}
+
+// sizeSuffix returns the suffix of the rule keys for the kinds measured by a length.
+func sizeSuffix(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return ".string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return ".items"
+	default:
+		return ""
+	}
+}
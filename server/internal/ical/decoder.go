@@ -0,0 +1,206 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+var ErrNoTodo = errors.New("ical: no VTODO component")
+
+// Todo is a VTODO read from a calendar object. Task holds the fields mapped to the task:
+// SUMMARY, DESCRIPTION, DUE, STATUS and COMPLETED.
+type Todo struct {
+	UID  string
+	Task domain.Task
+}
+
+// Property is a content line of the form NAME;PARAM=VALUE:VALUE.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// ParseTodo reads the first VTODO of the calendar object. The properties of the
+// components nested in it, such as VALARM, are ignored.
+func ParseTodo(r io.Reader) (*Todo, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		todo  *Todo
+		stack []string
+	)
+	for _, line := range unfold(string(b)) {
+		if line == "" {
+			continue
+		}
+		prop, err := ParseProperty(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.Value))
+			if todo == nil && strings.EqualFold(prop.Value, ComponentVTODO) {
+				todo = &Todo{Task: domain.Task{Priority: domain.TaskPriorityNone}}
+			}
+			continue
+		case "END":
+			if len(stack) == 0 || !strings.EqualFold(stack[len(stack)-1], prop.Value) {
+				return nil, fmt.Errorf("ical: unexpected END:%s", prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+			if strings.EqualFold(prop.Value, ComponentVTODO) {
+				return todo, nil
+			}
+			continue
+		}
+
+		if todo == nil || stack[len(stack)-1] != ComponentVTODO {
+			continue
+		}
+		if err := todo.set(prop); err != nil {
+			return nil, err
+		}
+	}
+	if todo != nil {
+		return nil, fmt.Errorf("ical: missing END:%s", ComponentVTODO)
+	}
+	return nil, ErrNoTodo
+}
+
+func (t *Todo) set(prop Property) error {
+	switch prop.Name {
+	case "UID":
+		t.UID = Unescape(prop.Value)
+	case "SUMMARY":
+		t.Task.Title = Unescape(prop.Value)
+	case "DESCRIPTION":
+		t.Task.Description = Unescape(prop.Value)
+	case "STATUS":
+		t.Task.Completed = strings.EqualFold(prop.Value, "COMPLETED")
+	case "DUE":
+		// a due date-time is cut to the date, tasks only have a due date
+		if len(prop.Value) < len(dateFormat) {
+			return fmt.Errorf("ical: invalid DUE %q", prop.Value)
+		}
+		due, err := time.Parse(dateFormat, prop.Value[:len(dateFormat)])
+		if err != nil {
+			return fmt.Errorf("ical: invalid DUE %q", prop.Value)
+		}
+		t.Task.DueDate = domain.DateOnly{Time: due}
+	case "PRIORITY":
+		value, err := strconv.Atoi(prop.Value)
+		if err != nil || value < 0 || value > 9 {
+			return fmt.Errorf("ical: invalid PRIORITY %q", prop.Value)
+		}
+		t.Task.Priority = priorityOf(value)
+	case "COMPLETED":
+		completedAt, err := time.Parse(timeFormat, strings.TrimSuffix(prop.Value, "Z")+"Z")
+		if err != nil {
+			return fmt.Errorf("ical: invalid COMPLETED %q", prop.Value)
+		}
+		t.Task.Completed = true
+		t.Task.CompletedAt = &completedAt
+	}
+	return nil
+}
+
+// ParseProperty parses an unfolded content line. The parameter values may be quoted.
+func ParseProperty(line string) (Property, error) {
+	prop := Property{Params: map[string]string{}}
+
+	quoted := false
+	nameEnd, valueStart := -1, -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if quoted {
+			continue
+		}
+		if r == ';' && nameEnd < 0 {
+			nameEnd = i
+		}
+		if r == ':' {
+			valueStart = i
+			break
+		}
+	}
+	if valueStart < 0 {
+		return prop, fmt.Errorf("ical: invalid content line %q", line)
+	}
+	if nameEnd < 0 {
+		nameEnd = valueStart
+	}
+	prop.Name = strings.ToUpper(line[:nameEnd])
+	prop.Value = line[valueStart+1:]
+
+	if nameEnd < valueStart {
+		for _, param := range splitParams(line[nameEnd+1 : valueStart]) {
+			key, value, _ := strings.Cut(param, "=")
+			prop.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop, nil
+}
+
+func splitParams(s string) []string {
+	var params []string
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ';' && !quoted:
+			params = append(params, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(params, s[start:])
+}
+
+// Unescape reverses Escape.
+func Unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+			} else {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		escaped = false
+		if r == 'n' || r == 'N' {
+			b.WriteRune('\n')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// unfold joins the folded lines, accepting LF line breaks as well as CRLF.
+func unfold(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\n ", "")
+	s = strings.ReplaceAll(s, "\n\t", "")
+	return strings.Split(s, "\n")
+}
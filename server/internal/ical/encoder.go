@@ -0,0 +1,84 @@
+// Package ical writes and reads the iCalendar format (RFC 5545) of tasks.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the maximum length of a content line without the line break.
+const maxLineOctets = 75
+
+// Encoder writes content lines, folding the long ones. The first write error is kept and returned by Flush.
+type Encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+func (e *Encoder) Begin(component string) {
+	e.Line("BEGIN:" + component)
+}
+
+func (e *Encoder) End(component string) {
+	e.Line("END:" + component)
+}
+
+// Property writes a property whose value is already in the iCalendar format, e.g. a date.
+func (e *Encoder) Property(name, value string) {
+	e.Line(name + ":" + value)
+}
+
+// Text writes a property of the TEXT type, escaping the value.
+func (e *Encoder) Text(name, value string) {
+	e.Line(name + ":" + Escape(value))
+}
+
+// Line writes a content line, folded into lines of at most 75 octets.
+func (e *Encoder) Line(line string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.WriteString(Fold(line) + "\r\n")
+}
+
+func (e *Encoder) Flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// Escape escapes a TEXT value.
+func Escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// Fold splits a content line longer than 75 octets with CRLF followed by a space,
+// without splitting a multi-byte UTF-8 character.
+func Fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	n := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			// the leading space of a continuation line counts toward its length
+			limit = maxLineOctets - 1
+			n = 0
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
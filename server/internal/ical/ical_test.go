@@ -0,0 +1,213 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/ical"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `a\\b\; c\, d\ne`, ical.Escape("a\\b; c, d\ne"))
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		title string
+		line  string
+		want  string
+	}{
+		{
+			"short line",
+			"SUMMARY:buy milk",
+			"SUMMARY:buy milk",
+		},
+		{
+			"long line",
+			"DESCRIPTION:" + strings.Repeat("a", 70),
+			"DESCRIPTION:" + strings.Repeat("a", 63) + "\r\n " + strings.Repeat("a", 7),
+		},
+		{
+			"multi-byte characters are not split",
+			"SUMMARY:" + strings.Repeat("あ", 30),
+			"SUMMARY:" + strings.Repeat("あ", 22) + "\r\n " + strings.Repeat("あ", 8),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got := ical.Fold(tt.line)
+			assert.Equal(t, tt.want, got)
+			for _, line := range strings.Split(got, "\r\n") {
+				assert.LessOrEqual(t, len(line), 75)
+			}
+		})
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	now := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2024, 12, 20, 18, 30, 0, 0, time.UTC)
+	tasks := []domain.Task{
+		{ID: 1, Title: "buy milk", Description: "2 bottles, low fat", DueDate: domain.MustDateOnly("2024-12-31"), CreatedAt: now},
+		{ID: 2, Title: "write report", Completed: true, DueDate: domain.MustDateOnly("2024-12-20"), CompletedAt: &completedAt, CreatedAt: now},
+	}
+
+	tests := []struct {
+		title     string
+		component string
+		want      []string
+	}{
+		{
+			"vevent",
+			ical.ComponentVEVENT,
+			[]string{
+				"BEGIN:VEVENT",
+				"UID:task-1@task-management-app",
+				"DTSTAMP:20241201T090000Z",
+				"CREATED:20241201T090000Z",
+				"SUMMARY:buy milk",
+				`DESCRIPTION:2 bottles\, low fat`,
+				"DTSTART;VALUE=DATE:20241231",
+				"DTEND;VALUE=DATE:20250101",
+				"TRANSP:TRANSPARENT",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:task-2@task-management-app",
+				"DTSTAMP:20241201T090000Z",
+				"CREATED:20241201T090000Z",
+				"SUMMARY:✓ write report",
+				"DTSTART;VALUE=DATE:20241220",
+				"DTEND;VALUE=DATE:20241221",
+				"TRANSP:TRANSPARENT",
+				"END:VEVENT",
+			},
+		},
+		{
+			"vtodo",
+			ical.ComponentVTODO,
+			[]string{
+				"BEGIN:VTODO",
+				"UID:task-1@task-management-app",
+				"DTSTAMP:20241201T090000Z",
+				"CREATED:20241201T090000Z",
+				"SUMMARY:buy milk",
+				`DESCRIPTION:2 bottles\, low fat`,
+				"DUE;VALUE=DATE:20241231",
+				"STATUS:NEEDS-ACTION",
+				"END:VTODO",
+				"BEGIN:VTODO",
+				"UID:task-2@task-management-app",
+				"DTSTAMP:20241201T090000Z",
+				"CREATED:20241201T090000Z",
+				"SUMMARY:write report",
+				"DUE;VALUE=DATE:20241220",
+				"STATUS:COMPLETED",
+				"PERCENT-COMPLETE:100",
+				"COMPLETED:20241220T183000Z",
+				"END:VTODO",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := ical.WriteCalendar(ical.NewEncoder(&buf), "Tasks", tt.component, tasks, now)
+
+			assert.NoError(t, err)
+			want := append([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//task-management-app//tasks//EN",
+				"CALSCALE:GREGORIAN",
+				"X-WR-CALNAME:Tasks",
+			}, tt.want...)
+			want = append(want, "END:VCALENDAR", "")
+			assert.Equal(t, strings.Join(want, "\r\n"), buf.String())
+		})
+	}
+}
+
+func TestParseTodo(t *testing.T) {
+	completedAt := time.Date(2024, 12, 20, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		title     string
+		body      string
+		wantTodo  *ical.Todo
+		wantError bool
+	}{
+		{
+			"needs action",
+			"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\nUID:abc-123\r\nSUMMARY:buy milk\\, eggs\r\n" +
+				"DESCRIPTION:2 bottles\\nlow fat\r\nDUE;VALUE=DATE:20241231\r\nPRIORITY:2\r\nSTATUS:NEEDS-ACTION\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			&ical.Todo{UID: "abc-123", Task: domain.Task{
+				Title:       "buy milk, eggs",
+				Description: "2 bottles\nlow fat",
+				DueDate:     domain.MustDateOnly("2024-12-31"),
+				Priority:    domain.TaskPriorityHigh,
+			}},
+			false,
+		},
+		{
+			"completed with a due date-time and an alarm",
+			"BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:abc-123\nSUMMARY:write a very long report which is folded \n into two lines\n" +
+				"DUE;TZID=\"Asia/Tokyo\":20241220T090000\nSTATUS:COMPLETED\nCOMPLETED:20241220T183000Z\n" +
+				"BEGIN:VALARM\nDESCRIPTION:reminder\nEND:VALARM\nEND:VTODO\nEND:VCALENDAR\n",
+			&ical.Todo{UID: "abc-123", Task: domain.Task{
+				Title:       "write a very long report which is folded into two lines",
+				Completed:   true,
+				CompletedAt: &completedAt,
+				DueDate:     domain.MustDateOnly("2024-12-20"),
+				Priority:    domain.TaskPriorityNone,
+			}},
+			false,
+		},
+		{
+			"no vtodo",
+			"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:meeting\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+			nil,
+			true,
+		},
+		{
+			"invalid due",
+			"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nDUE:tomorrow\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			todo, err := ical.ParseTodo(strings.NewReader(tt.body))
+
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTodo, todo)
+			}
+		})
+	}
+}
+
+func TestWriteTodoCalendarRoundTrip(t *testing.T) {
+	task := domain.Task{ID: 1, Title: "buy milk; eggs", Description: "a\\b\nc", DueDate: domain.MustDateOnly("2024-12-31"), Priority: domain.TaskPriorityUrgent}
+
+	var buf bytes.Buffer
+	err := ical.WriteTodoCalendar(ical.NewEncoder(&buf), "abc-123", task, time.Now())
+	assert.NoError(t, err)
+
+	todo, err := ical.ParseTodo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc-123", todo.UID)
+	assert.Equal(t, task.Title, todo.Task.Title)
+	assert.Equal(t, task.Description, todo.Task.Description)
+	assert.Equal(t, task.DueDate, todo.Task.DueDate)
+	assert.Equal(t, task.Priority, todo.Task.Priority)
+}
@@ -0,0 +1,126 @@
+package ical
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+const (
+	ComponentVTODO  = "VTODO"
+	ComponentVEVENT = "VEVENT"
+
+	prodID     = "-//task-management-app//tasks//EN"
+	dateFormat = "20060102"
+	timeFormat = "20060102T150405Z"
+)
+
+// priorityValues are the PRIORITY values of the priorities, 1 is the highest and 9 the lowest.
+// The priority none is written without PRIORITY.
+var priorityValues = map[domain.TaskPriority]int{
+	domain.TaskPriorityUrgent: 1,
+	domain.TaskPriorityHigh:   3,
+	domain.TaskPriorityMedium: 5,
+	domain.TaskPriorityLow:    7,
+}
+
+// priorityOf maps a PRIORITY value to the priority, the values 1-4 are high and 6-9
+// low as RFC 5545 tells, except that 1 is the urgent priority.
+func priorityOf(value int) domain.TaskPriority {
+	switch {
+	case value <= 0:
+		return domain.TaskPriorityNone
+	case value == 1:
+		return domain.TaskPriorityUrgent
+	case value <= 4:
+		return domain.TaskPriorityHigh
+	case value == 5:
+		return domain.TaskPriorityMedium
+	}
+	return domain.TaskPriorityLow
+}
+
+// UID returns the unique identifier of the task in the calendars.
+func UID(taskID int) string {
+	return fmt.Sprintf("task-%d@task-management-app", taskID)
+}
+
+// WriteCalendar writes the tasks as a VCALENDAR of all-day VTODO or VEVENT entries on their due dates.
+func WriteCalendar(e *Encoder, name, component string, tasks []domain.Task, now time.Time) error {
+	beginCalendar(e)
+	e.Text("X-WR-CALNAME", name)
+	for _, task := range tasks {
+		if component == ComponentVEVENT {
+			writeEvent(e, task, now)
+		} else {
+			writeTodo(e, UID(task.ID), task, now)
+		}
+	}
+	e.End("VCALENDAR")
+	return e.Flush()
+}
+
+// WriteTodoCalendar writes a VCALENDAR of a single VTODO, which is a calendar object resource
+// of CalDAV. The UID is given since the tasks created by CalDAV clients keep the client's UID.
+func WriteTodoCalendar(e *Encoder, uid string, task domain.Task, now time.Time) error {
+	beginCalendar(e)
+	writeTodo(e, uid, task, now)
+	e.End("VCALENDAR")
+	return e.Flush()
+}
+
+func beginCalendar(e *Encoder) {
+	e.Begin("VCALENDAR")
+	e.Property("VERSION", "2.0")
+	e.Text("PRODID", prodID)
+	e.Property("CALSCALE", "GREGORIAN")
+}
+
+// writeTodo writes the task as a VTODO due on its due date, with the completion status.
+func writeTodo(e *Encoder, uid string, task domain.Task, now time.Time) {
+	e.Begin(ComponentVTODO)
+	writeCommon(e, uid, task, task.Title, now)
+	if !task.DueDate.IsZero() {
+		e.Property("DUE;VALUE=DATE", task.DueDate.Format(dateFormat))
+	}
+	if value, ok := priorityValues[task.Priority]; ok {
+		e.Property("PRIORITY", strconv.Itoa(value))
+	}
+	if task.Completed {
+		e.Property("STATUS", "COMPLETED")
+		e.Property("PERCENT-COMPLETE", "100")
+		if task.CompletedAt != nil {
+			e.Property("COMPLETED", task.CompletedAt.UTC().Format(timeFormat))
+		}
+	} else {
+		e.Property("STATUS", "NEEDS-ACTION")
+	}
+	e.End(ComponentVTODO)
+}
+
+// writeEvent writes the task as an all-day VEVENT on its due date. Events have no
+// completion status, so the summary of a completed task is marked instead.
+func writeEvent(e *Encoder, task domain.Task, now time.Time) {
+	e.Begin(ComponentVEVENT)
+	summary := task.Title
+	if task.Completed {
+		summary = "✓ " + summary
+	}
+	writeCommon(e, UID(task.ID), task, summary, now)
+	e.Property("DTSTART;VALUE=DATE", task.DueDate.Format(dateFormat))
+	e.Property("DTEND;VALUE=DATE", task.DueDate.AddDate(0, 0, 1).Format(dateFormat))
+	e.Property("TRANSP", "TRANSPARENT")
+	e.End(ComponentVEVENT)
+}
+
+func writeCommon(e *Encoder, uid string, task domain.Task, summary string, now time.Time) {
+	e.Text("UID", uid)
+	e.Property("DTSTAMP", now.UTC().Format(timeFormat))
+	e.Property("CREATED", task.CreatedAt.UTC().Format(timeFormat))
+	e.Text("SUMMARY", summary)
+	if task.Description != "" {
+		e.Text("DESCRIPTION", task.Description)
+	}
+}
@@ -17,6 +17,10 @@ const (
 const (
 	CodeUserAlreadyExists ErrorCode = 2000 + iota
 	CodeInvalidPassword
+	CodeTaskArchived
+	CodePreconditionFailed
+	CodeTimerAlreadyRunning
+	CodeCustomFieldAlreadyExists
 )
 
 const (
@@ -27,6 +31,18 @@ const (
 	CodePermissionNotFound
 	CodePermissionDenied
 	CodeTransactionNotFound
+	CodeTaskEventNotFound
+	CodeWebhookNotFound
+	CodeWebhookDeliveryNotFound
+	CodeTaskViewNotFound
+	CodeCalendarFeedNotFound
+	CodeAppPasswordNotFound
+	CodeCalDAVObjectNotFound
+	CodeTimeEntryNotFound
+	CodeBoardNotFound
+	CodeTaskTemplateNotFound
+	CodeCustomFieldNotFound
+	CodeErrorDefinitionNotFound
 )
 
 const (
@@ -42,17 +58,33 @@ var ErrMessages = map[ErrorCode]string{
 	CodeNoLogin:             "user not logged in",
 
 	// 2000
-	CodeUserAlreadyExists: "user already exists",
-	CodeInvalidPassword:   "invalid password",
+	CodeUserAlreadyExists:        "user already exists",
+	CodeInvalidPassword:          "invalid password",
+	CodeTaskArchived:             "task is archived",
+	CodePreconditionFailed:       "precondition failed",
+	CodeTimerAlreadyRunning:      "timer already running",
+	CodeCustomFieldAlreadyExists: "custom field already exists",
 
 	// 3000
-	CodeQueryFailed:           "failed to execute query",
-	CodeTaskNotFound:          "task not found",
-	CodeUserNotFound:          "user not found",
-	CodeGrantPermissionFailed: "failed to grant permission",
-	CodePermissionNotFound:    "permission not found",
-	CodePermissionDenied:      "permission denied",
-	CodeTransactionNotFound:   "failed to get transaction from context",
+	CodeQueryFailed:             "failed to execute query",
+	CodeTaskNotFound:            "task not found",
+	CodeUserNotFound:            "user not found",
+	CodeGrantPermissionFailed:   "failed to grant permission",
+	CodePermissionNotFound:      "permission not found",
+	CodePermissionDenied:        "permission denied",
+	CodeTransactionNotFound:     "failed to get transaction from context",
+	CodeTaskEventNotFound:       "task event not found",
+	CodeWebhookNotFound:         "webhook not found",
+	CodeWebhookDeliveryNotFound: "webhook delivery not found",
+	CodeTaskViewNotFound:        "view not found",
+	CodeCalendarFeedNotFound:    "calendar feed not found",
+	CodeAppPasswordNotFound:     "app password not found",
+	CodeCalDAVObjectNotFound:    "calendar object not found",
+	CodeTimeEntryNotFound:       "time entry not found",
+	CodeBoardNotFound:           "board not found",
+	CodeTaskTemplateNotFound:    "template not found",
+	CodeCustomFieldNotFound:     "custom field not found",
+	CodeErrorDefinitionNotFound: "error code not found",
 
 	// 9999
 	CodeUnExpected: "unexpected error occurred",
@@ -67,17 +99,33 @@ var (
 	ErrNoLogin             = &AppError{Code: CodeNoLogin, Message: ErrMessages[CodeNoLogin]}
 
 	// 2000
-	ErrUserAlreadyExists = &AppError{Code: CodeUserAlreadyExists, Message: ErrMessages[CodeUserAlreadyExists]}
-	ErrInvalidPassword   = &AppError{Code: CodeInvalidPassword, Message: ErrMessages[CodeInvalidPassword]}
+	ErrUserAlreadyExists        = &AppError{Code: CodeUserAlreadyExists, Message: ErrMessages[CodeUserAlreadyExists]}
+	ErrInvalidPassword          = &AppError{Code: CodeInvalidPassword, Message: ErrMessages[CodeInvalidPassword]}
+	ErrTaskArchived             = &AppError{Code: CodeTaskArchived, Message: ErrMessages[CodeTaskArchived]}
+	ErrPreconditionFailed       = &AppError{Code: CodePreconditionFailed, Message: ErrMessages[CodePreconditionFailed]}
+	ErrTimerAlreadyRunning      = &AppError{Code: CodeTimerAlreadyRunning, Message: ErrMessages[CodeTimerAlreadyRunning]}
+	ErrCustomFieldAlreadyExists = &AppError{Code: CodeCustomFieldAlreadyExists, Message: ErrMessages[CodeCustomFieldAlreadyExists]}
 
 	// 3000
-	ErrQueryFailed         = &AppError{Code: CodeQueryFailed, Message: ErrMessages[CodeQueryFailed]}
-	ErrTaskNotFound        = &AppError{Code: CodeTaskNotFound, Message: ErrMessages[CodeTaskNotFound]}
-	ErrUserNotFound        = &AppError{Code: CodeUserNotFound, Message: ErrMessages[CodeUserNotFound]}
-	ErrTransactionNotFound = &AppError{Code: CodeTransactionNotFound, Message: ErrMessages[CodeTransactionNotFound]}
-	ErrGrantPermission     = &AppError{Code: CodeGrantPermissionFailed, Message: ErrMessages[CodeGrantPermissionFailed]}
-	ErrPermissionNotFound  = &AppError{Code: CodePermissionNotFound, Message: ErrMessages[CodePermissionNotFound]}
-	ErrPermissionDenied    = &AppError{Code: CodePermissionDenied, Message: ErrMessages[CodePermissionDenied]}
+	ErrQueryFailed             = &AppError{Code: CodeQueryFailed, Message: ErrMessages[CodeQueryFailed]}
+	ErrTaskNotFound            = &AppError{Code: CodeTaskNotFound, Message: ErrMessages[CodeTaskNotFound]}
+	ErrUserNotFound            = &AppError{Code: CodeUserNotFound, Message: ErrMessages[CodeUserNotFound]}
+	ErrTransactionNotFound     = &AppError{Code: CodeTransactionNotFound, Message: ErrMessages[CodeTransactionNotFound]}
+	ErrGrantPermission         = &AppError{Code: CodeGrantPermissionFailed, Message: ErrMessages[CodeGrantPermissionFailed]}
+	ErrPermissionNotFound      = &AppError{Code: CodePermissionNotFound, Message: ErrMessages[CodePermissionNotFound]}
+	ErrPermissionDenied        = &AppError{Code: CodePermissionDenied, Message: ErrMessages[CodePermissionDenied]}
+	ErrTaskEventNotFound       = &AppError{Code: CodeTaskEventNotFound, Message: ErrMessages[CodeTaskEventNotFound]}
+	ErrWebhookNotFound         = &AppError{Code: CodeWebhookNotFound, Message: ErrMessages[CodeWebhookNotFound]}
+	ErrWebhookDeliveryNotFound = &AppError{Code: CodeWebhookDeliveryNotFound, Message: ErrMessages[CodeWebhookDeliveryNotFound]}
+	ErrTaskViewNotFound        = &AppError{Code: CodeTaskViewNotFound, Message: ErrMessages[CodeTaskViewNotFound]}
+	ErrCalendarFeedNotFound    = &AppError{Code: CodeCalendarFeedNotFound, Message: ErrMessages[CodeCalendarFeedNotFound]}
+	ErrAppPasswordNotFound     = &AppError{Code: CodeAppPasswordNotFound, Message: ErrMessages[CodeAppPasswordNotFound]}
+	ErrCalDAVObjectNotFound    = &AppError{Code: CodeCalDAVObjectNotFound, Message: ErrMessages[CodeCalDAVObjectNotFound]}
+	ErrTimeEntryNotFound       = &AppError{Code: CodeTimeEntryNotFound, Message: ErrMessages[CodeTimeEntryNotFound]}
+	ErrBoardNotFound           = &AppError{Code: CodeBoardNotFound, Message: ErrMessages[CodeBoardNotFound]}
+	ErrTaskTemplateNotFound    = &AppError{Code: CodeTaskTemplateNotFound, Message: ErrMessages[CodeTaskTemplateNotFound]}
+	ErrCustomFieldNotFound     = &AppError{Code: CodeCustomFieldNotFound, Message: ErrMessages[CodeCustomFieldNotFound]}
+	ErrErrorDefinitionNotFound = &AppError{Code: CodeErrorDefinitionNotFound, Message: ErrMessages[CodeErrorDefinitionNotFound]}
 
 	// 9999
 	ErrUnExpected = &AppError{Code: CodeUnExpected, Message: ErrMessages[CodeUnExpected]}
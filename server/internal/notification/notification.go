@@ -0,0 +1,77 @@
+// Package notification renders the email telling a user that they have been assigned
+// to a task, in English or Japanese.
+package notification
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/mailer"
+)
+
+// DefaultLocale is used for the locales without templates.
+const DefaultLocale = "en"
+
+//go:embed templates
+var templateFS embed.FS
+
+// dateLayouts format the due dates in each locale.
+var dateLayouts = map[string]string{
+	"en": "Jan 2, 2006",
+	"ja": "2006年1月2日",
+}
+
+type Assignment struct {
+	// Name is the name of the assignee.
+	Name string
+	Task domain.Task
+}
+
+type templates struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+var locales = map[string]templates{}
+
+func init() {
+	for locale, layout := range dateLayouts {
+		funcs := map[string]any{
+			"date": func(t time.Time) string { return t.Format(layout) },
+		}
+		locales[locale] = templates{
+			text: texttemplate.Must(texttemplate.New(locale+".txt.tmpl").Funcs(funcs).
+				ParseFS(templateFS, "templates/"+locale+".txt.tmpl")),
+			html: htmltemplate.Must(htmltemplate.New(locale+".html.tmpl").Funcs(funcs).
+				ParseFS(templateFS, "templates/"+locale+".html.tmpl")),
+		}
+	}
+}
+
+// Render renders the assignment in the locale, the recipient of the message is left empty.
+func Render(locale string, a Assignment) (*mailer.Message, error) {
+	t, ok := locales[locale]
+	if !ok {
+		t = locales[DefaultLocale]
+	}
+	var subject, text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", a); err != nil {
+		return nil, err
+	}
+	if err := t.text.Execute(&text, a); err != nil {
+		return nil, err
+	}
+	if err := t.html.Execute(&html, a); err != nil {
+		return nil, err
+	}
+	return &mailer.Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
@@ -0,0 +1,65 @@
+package notification_test
+
+import (
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/notification"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		title       string
+		locale      string
+		task        domain.Task
+		wantSubject string
+		wantText    string
+		wantHTML    string
+	}{
+		{
+			"en",
+			"en",
+			domain.Task{Title: "write <report>", DueDate: domain.MustDateOnly("2024-12-01")},
+			"You have been assigned to write <report>",
+			"Hi alice,\n\nYou have been assigned to the task \"write <report>\".\nIt is due Dec 1, 2024.\n\n" +
+				"You can find it in your task list.\n",
+			"<p>You have been assigned to the task &quot;write &lt;report&gt;&quot;.</p>",
+		},
+		{
+			"ja",
+			"ja",
+			domain.Task{Title: "write <report>", DueDate: domain.MustDateOnly("2024-12-01")},
+			"タスク「write <report>」の担当者になりました",
+			"alice さん\n\nタスク「write <report>」の担当者になりました。\n期限は 2024年12月1日 です。\n\n" +
+				"タスクの一覧から確認できます。\n",
+			"<p>期限は 2024年12月1日 です。</p>",
+		},
+		{
+			"without due date",
+			"en",
+			domain.Task{Title: "buy milk"},
+			"You have been assigned to buy milk",
+			"Hi alice,\n\nYou have been assigned to the task \"buy milk\".\n\nYou can find it in your task list.\n",
+			"<p>You can find it in your task list.</p>",
+		},
+		{
+			"unknown locale falls back on en",
+			"fr",
+			domain.Task{Title: "buy milk"},
+			"You have been assigned to buy milk",
+			"Hi alice,\n\nYou have been assigned to the task \"buy milk\".\n\nYou can find it in your task list.\n",
+			"<html lang=\"en\">",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			msg, err := notification.Render(tt.locale, notification.Assignment{Name: "alice", Task: tt.task})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSubject, msg.Subject)
+			assert.Equal(t, tt.wantText, msg.Text)
+			assert.Contains(t, msg.HTML, tt.wantHTML)
+		})
+	}
+}
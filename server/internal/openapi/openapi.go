@@ -0,0 +1,528 @@
+// Package openapi builds an OpenAPI 3.0 document from the routes of a gin router and
+// the Go types they bind and render. The schemas follow the json, form and uri tags
+// and the validator rules of the binding tag, so that they stay in sync with the code.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path keyed by the lower case method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+	// Security is an empty list for the operations which do not require the session.
+	Security *[]SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type SecurityRequirement map[string][]string
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Ref returns the schema referring to the component schema of the name.
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// Route describes an operation. URI, Query, Body and Form are zero values of the
+// types bound by the handler, Response is the type rendered on success.
+type Route struct {
+	Method string
+	Path   string // gin path such as /tasks/:taskID
+	// ID is the operationId, unique in the document.
+	ID      string
+	Summary string
+	Tag     string
+	// Public operations do not require the session.
+	Public bool
+
+	URI   any
+	Query any
+	Body  any
+	// Form is sent as multipart/form-data, File names the file part of the form.
+	Form any
+	File string
+
+	// Status is the success status, 200 by default.
+	Status   int
+	Response any
+	// ContentTypes are the media types of the response, application/json by default when
+	// Response is set. Only the application/json content is described by Response.
+	ContentTypes []string
+}
+
+// Builder collects the operations and the component schemas of a document.
+type Builder struct {
+	doc     Document
+	types   map[reflect.Type]*Schema
+	errType any
+	// errContentType is the media type of the error responses.
+	errContentType string
+}
+
+// NewBuilder returns a builder of a document. Errors are described by errorResponse,
+// which is rendered on every error status.
+func NewBuilder(info Info, errorResponse any) *Builder {
+	return &Builder{
+		doc: Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   map[string]PathItem{},
+			Components: Components{
+				Schemas: map[string]*Schema{},
+			},
+		},
+		types: map[reflect.Type]*Schema{
+			reflect.TypeOf(time.Time{}):        {Type: "string", Format: "date-time"},
+			reflect.TypeOf(json.RawMessage{}):  {},
+			reflect.TypeOf(time.Duration(0)):   {Type: "integer", Format: "int64"},
+			reflect.TypeOf((*any)(nil)).Elem(): {},
+		},
+		errType:        errorResponse,
+		errContentType: "application/json",
+	}
+}
+
+// ErrorContentType sets the media type of the error responses, application/json by default.
+func (b *Builder) ErrorContentType(contentType string) {
+	b.errContentType = contentType
+}
+
+// Define describes the type by the schema instead of its Go structure, such as a type
+// with its own JSON encoding.
+func (b *Builder) Define(v any, schema Schema) {
+	b.types[reflect.TypeOf(v)] = &schema
+}
+
+// Component adds a component schema which is not derived from a Go type.
+func (b *Builder) Component(name string, schema *Schema) {
+	b.doc.Components.Schemas[name] = schema
+}
+
+// Schema returns the component schema of the name, nil when it is not defined.
+func (b *Builder) Schema(name string) *Schema {
+	return b.doc.Components.Schemas[name]
+}
+
+func (b *Builder) Server(url string) {
+	b.doc.Servers = append(b.doc.Servers, Server{URL: url})
+}
+
+// SecurityScheme adds the scheme, which is required by the operations except the public ones.
+func (b *Builder) SecurityScheme(name string, scheme SecurityScheme) {
+	if b.doc.Components.SecuritySchemes == nil {
+		b.doc.Components.SecuritySchemes = map[string]SecurityScheme{}
+	}
+	b.doc.Components.SecuritySchemes[name] = scheme
+	b.doc.Security = append(b.doc.Security, SecurityRequirement{name: {}})
+}
+
+// Add adds the operation of the route.
+func (b *Builder) Add(route Route) {
+	path, names := Path(route.Path)
+	op := &Operation{
+		OperationID: route.ID,
+		Summary:     route.Summary,
+		Responses:   map[string]Response{},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+	if route.Public {
+		op.Security = &[]SecurityRequirement{}
+	}
+
+	// path parameters are taken from the path, the types from the uri tags
+	uriFields := map[string]*Schema{}
+	if route.URI != nil {
+		b.parameterFields(reflect.TypeOf(route.URI), "uri", uriFields, nil)
+	}
+	for _, name := range names {
+		schema, ok := uriFields[name]
+		if !ok {
+			schema = &Schema{Type: "string"}
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	if route.Query != nil {
+		fields := map[string]*Schema{}
+		var order []string
+		required := b.parameterFields(reflect.TypeOf(route.Query), "form", fields, &order)
+		for _, name := range order {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name: name, In: "query", Required: required[name], Schema: fields[name],
+			})
+		}
+	}
+
+	switch {
+	case route.Body != nil:
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+			"application/json": {Schema: b.schemaOf(reflect.TypeOf(route.Body))},
+		}}
+	case route.Form != nil:
+		form := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		var order []string
+		required := b.parameterFields(reflect.TypeOf(route.Form), "form", form.Properties, &order)
+		for _, name := range order {
+			if required[name] {
+				form.Required = append(form.Required, name)
+			}
+		}
+		if route.File != "" {
+			form.Properties[route.File] = &Schema{Type: "string", Format: "binary"}
+			form.Required = append(form.Required, route.File)
+		}
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+			"multipart/form-data": {Schema: form},
+		}}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	contentTypes := route.ContentTypes
+	if len(contentTypes) == 0 && route.Response != nil {
+		contentTypes = []string{"application/json"}
+	}
+	success := Response{Description: http.StatusText(status)}
+	for _, contentType := range contentTypes {
+		var schema *Schema
+		if contentType == "application/json" && route.Response != nil {
+			schema = b.schemaOf(reflect.TypeOf(route.Response))
+		}
+		if success.Content == nil {
+			success.Content = map[string]MediaType{}
+		}
+		success.Content[contentType] = MediaType{Schema: schema}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	errSchema := b.schemaOf(reflect.TypeOf(b.errType))
+	errResponse := func(description string) Response {
+		return Response{Description: description, Content: map[string]MediaType{
+			b.errContentType: {Schema: errSchema},
+		}}
+	}
+	if len(op.Parameters) > 0 || op.RequestBody != nil {
+		op.Responses["400"] = errResponse("the request is validation failed")
+	}
+	if !route.Public {
+		op.Responses["401"] = errResponse("the user is not logged in")
+	}
+	op.Responses["default"] = errResponse("the request failed, the codes of the errors tell the reason")
+
+	item := b.doc.Paths[path]
+	if item == nil {
+		item = PathItem{}
+		b.doc.Paths[path] = item
+	}
+	item[strings.ToLower(route.Method)] = op
+}
+
+// Document returns the document built so far.
+func (b *Builder) Document() *Document {
+	return &b.doc
+}
+
+// Path converts the gin path to the OpenAPI path and returns the names of its parameters.
+func Path(ginPath string) (string, []string) {
+	var names []string
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			names = append(names, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), names
+}
+
+// parameterFields collects the schemas of the fields tagged with tag, embedded structs
+// are flattened as gin binds them. It returns whether each field is required.
+func (b *Builder) parameterFields(t reflect.Type, tag string, fields map[string]*Schema, order *[]string) map[string]bool {
+	required := map[string]bool{}
+	t = deref(t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && deref(f.Type).Kind() == reflect.Struct {
+			for k, v := range b.parameterFields(f.Type, tag, fields, order) {
+				required[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		var schema *Schema
+		if layout := f.Tag.Get("time_format"); layout != "" && deref(f.Type) == reflect.TypeOf(time.Time{}) {
+			schema = &Schema{Type: "string", Format: "date-time"}
+			if layout == time.DateOnly {
+				schema.Format = "date"
+			}
+		} else {
+			schema = b.schemaOf(f.Type)
+		}
+		required[name] = applyBinding(schema, f.Type, f.Tag.Get("binding"))
+		fields[name] = schema
+		if order != nil {
+			*order = append(*order, name)
+		}
+	}
+	return required
+}
+
+// schemaOf returns the schema of the type, named structs are referred to as components.
+func (b *Builder) schemaOf(t reflect.Type) *Schema {
+	if schema, ok := b.types[t]; ok {
+		copied := *schema
+		return &copied
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := b.schemaOf(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := t.Name()
+		if _, ok := b.doc.Components.Schemas[name]; !ok {
+			// reserve the name first for the recursive types
+			b.doc.Components.Schemas[name] = &Schema{}
+			*b.doc.Components.Schemas[name] = *b.structSchema(t)
+		}
+		return Ref(name)
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaOf(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Interface:
+		return &Schema{}
+	default:
+		panic(fmt.Sprintf("openapi: unsupported type %s", t))
+	}
+}
+
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	b.addProperties(schema, t)
+	return schema
+}
+
+func (b *Builder) addProperties(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && deref(f.Type).Kind() == reflect.Struct {
+			if _, ok := b.types[f.Type]; !ok {
+				b.addProperties(schema, deref(f.Type))
+				continue
+			}
+		}
+		if name == "" {
+			// the fields bound from the path or the query are not in the body
+			if f.Tag.Get("uri") != "" || f.Tag.Get("form") != "" {
+				continue
+			}
+			name = f.Name
+		}
+		property := b.schemaOf(f.Type)
+		if applyBinding(property, f.Type, f.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	sort.Strings(schema.Required)
+}
+
+// applyBinding applies the validator rules to the schema and returns whether the field
+// is required. The rules after dive apply to the items.
+func applyBinding(schema *Schema, t reflect.Type, binding string) bool {
+	if binding == "" {
+		return false
+	}
+	rules, itemRules, dive := strings.Cut(binding, ",dive")
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		if rule == "required" {
+			required = true
+		}
+	}
+	if schema.Ref == "" {
+		applyRules(schema, deref(t), rules)
+	}
+	if dive && schema.Items != nil && schema.Items.Ref == "" {
+		applyRules(schema.Items, deref(deref(t).Elem()), strings.TrimPrefix(itemRules, ","))
+	}
+	return required
+}
+
+func applyRules(schema *Schema, t reflect.Type, rules string) {
+	for _, rule := range strings.Split(rules, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			switch t.Kind() {
+			case reflect.String:
+				if key == "min" {
+					schema.MinLength = &n
+				} else {
+					schema.MaxLength = &n
+				}
+			case reflect.Slice, reflect.Array, reflect.Map:
+				if key == "min" {
+					schema.MinItems = &n
+				} else {
+					schema.MaxItems = &n
+				}
+			default:
+				f := float64(n)
+				if key == "min" {
+					schema.Minimum = &f
+				} else {
+					schema.Maximum = &f
+				}
+			}
+		case "oneof":
+			for _, v := range strings.Fields(value) {
+				schema.Enum = append(schema.Enum, v)
+			}
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "unique":
+			schema.UniqueItems = true
+		case "timezone":
+			schema.Description = "IANA time zone name such as Asia/Tokyo"
+		}
+	}
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
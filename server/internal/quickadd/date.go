@@ -0,0 +1,190 @@
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// enPrefix drops the word introducing an English date, "due friday" is the same as "friday".
+	enPrefix = `(?i)(?:\b(?:due|by|on)\s+)?\b`
+	// jaSuffix drops "まで" following a date, "明日まで" is the same as "明日".
+	jaSuffix = `(?:までに|まで)?`
+
+	enMonth   = `(january|february|march|april|may|june|july|august|september|october|november|december|jan|feb|mar|apr|jun|jul|aug|sept?|oct|nov|dec)`
+	enWeekday = `(monday|tuesday|wednesday|thursday|friday|saturday|sunday|mon|tues?|wed|thu(?:rs)?|fri|sat|sun)`
+	jaWeekday = `([月火水木金土日])曜日?`
+)
+
+type datePattern struct {
+	re      *regexp.Regexp
+	resolve func(m []string, today time.Time) (time.Time, bool)
+}
+
+// datePatterns are tried in order, the more specific patterns come first so that
+// "next friday" is not read as "friday".
+var datePatterns = []datePattern{
+	{regexp.MustCompile(enPrefix + `(\d{4})[-/](\d{1,2})[-/](\d{1,2})\b` + jaSuffix), func(m []string, _ time.Time) (time.Time, bool) {
+		return date(atoi(m[1]), atoi(m[2]), atoi(m[3]))
+	}},
+	{regexp.MustCompile(`(\d{4})年(\d{1,2})月(\d{1,2})日` + jaSuffix), func(m []string, _ time.Time) (time.Time, bool) {
+		return date(atoi(m[1]), atoi(m[2]), atoi(m[3]))
+	}},
+	{regexp.MustCompile(`(\d{1,2})月(\d{1,2})日` + jaSuffix), func(m []string, today time.Time) (time.Time, bool) {
+		return monthDay(today, atoi(m[1]), atoi(m[2]))
+	}},
+	{regexp.MustCompile(enPrefix + `(\d{1,2})/(\d{1,2})\b` + jaSuffix), func(m []string, today time.Time) (time.Time, bool) {
+		return monthDay(today, atoi(m[1]), atoi(m[2]))
+	}},
+	{regexp.MustCompile(enPrefix + enMonth + `\.?\s+(\d{1,2})(?:st|nd|rd|th)?\b`), func(m []string, today time.Time) (time.Time, bool) {
+		return monthDay(today, month(m[1]), atoi(m[2]))
+	}},
+	{regexp.MustCompile(enPrefix + `(\d{1,2})(?:st|nd|rd|th)?\s+` + enMonth + `\b`), func(m []string, today time.Time) (time.Time, bool) {
+		return monthDay(today, month(m[2]), atoi(m[1]))
+	}},
+	{regexp.MustCompile(enPrefix + `(this|next)\s+` + enWeekday + `\b`), func(m []string, today time.Time) (time.Time, bool) {
+		weeks := 0
+		if strings.EqualFold(m[1], "next") {
+			weeks = 1
+		}
+		return weekdayOfWeek(today, weeks, enWeekdays[strings.ToLower(m[2][:3])]), true
+	}},
+	{regexp.MustCompile(`(今週|来週|再来週)の?` + jaWeekday + jaSuffix), func(m []string, today time.Time) (time.Time, bool) {
+		return weekdayOfWeek(today, jaWeeks[m[1]], jaWeekdays[m[2]]), true
+	}},
+	{regexp.MustCompile(enPrefix + `in\s+(\d+|an?|one)\s+(day|week|month)s?\b`), func(m []string, today time.Time) (time.Time, bool) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			n = 1
+		}
+		return after(today, n, strings.ToLower(m[2])), true
+	}},
+	{regexp.MustCompile(`(\d+)(日|週間|か月|ヶ月|カ月)後` + jaSuffix), func(m []string, today time.Time) (time.Time, bool) {
+		unit := map[string]string{"日": "day", "週間": "week"}[m[2]]
+		if unit == "" {
+			unit = "month"
+		}
+		return after(today, atoi(m[1]), unit), true
+	}},
+	{regexp.MustCompile(enPrefix + `next\s+(week|month)\b`), func(m []string, today time.Time) (time.Time, bool) {
+		if strings.EqualFold(m[1], "week") {
+			return weekdayOfWeek(today, 1, time.Monday), true
+		}
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, time.UTC), true
+	}},
+	{regexp.MustCompile(`(来週|再来週|来月)` + jaSuffix), func(m []string, today time.Time) (time.Time, bool) {
+		if m[1] == "来月" {
+			return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, time.UTC), true
+		}
+		return weekdayOfWeek(today, jaWeeks[m[1]], time.Monday), true
+	}},
+	// a weekday alone is the next one after today, the abbreviations are not read
+	// without "this" or "next" since "sun" or "wed" may well be a part of the title
+	{regexp.MustCompile(enPrefix + `(monday|tuesday|wednesday|thursday|friday|saturday|sunday)\b`), func(m []string, today time.Time) (time.Time, bool) {
+		return nextWeekday(today, enWeekdays[strings.ToLower(m[1][:3])]), true
+	}},
+	{regexp.MustCompile(jaWeekday + jaSuffix), func(m []string, today time.Time) (time.Time, bool) {
+		return nextWeekday(today, jaWeekdays[m[1]]), true
+	}},
+	{regexp.MustCompile(enPrefix + `(day after tomorrow|today|tonight|tomorrow|tmrw)\b`), func(m []string, today time.Time) (time.Time, bool) {
+		switch strings.ToLower(m[1]) {
+		case "tomorrow", "tmrw":
+			return today.AddDate(0, 0, 1), true
+		case "day after tomorrow":
+			return today.AddDate(0, 0, 2), true
+		}
+		return today, true
+	}},
+	{regexp.MustCompile(`(今日|本日|今夜|明日|あした|明後日|あさって)` + jaSuffix), func(m []string, today time.Time) (time.Time, bool) {
+		switch m[1] {
+		case "明日", "あした":
+			return today.AddDate(0, 0, 1), true
+		case "明後日", "あさって":
+			return today.AddDate(0, 0, 2), true
+		}
+		return today, true
+	}},
+}
+
+var (
+	enWeekdays = map[string]time.Weekday{
+		"mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+		"fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+	}
+	jaWeekdays = map[string]time.Weekday{
+		"月": time.Monday, "火": time.Tuesday, "水": time.Wednesday, "木": time.Thursday,
+		"金": time.Friday, "土": time.Saturday, "日": time.Sunday,
+	}
+	jaWeeks = map[string]int{"今週": 0, "来週": 1, "再来週": 2}
+)
+
+// parseDate returns the first date found in the text and the position of the matched text.
+func parseDate(text string, today time.Time) (time.Time, []int, bool) {
+	for _, p := range datePatterns {
+		loc := p.re.FindStringSubmatchIndex(text)
+		if loc == nil {
+			continue
+		}
+		m := make([]string, len(loc)/2)
+		for i := range m {
+			if loc[2*i] >= 0 {
+				m[i] = text[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		if t, ok := p.resolve(m, today); ok {
+			return t, loc[:2], true
+		}
+	}
+	return time.Time{}, nil, false
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func month(name string) int {
+	t, _ := time.Parse("Jan", strings.ToUpper(name[:1])+strings.ToLower(name[1:3]))
+	return int(t.Month())
+}
+
+// date returns false for a date which does not exist such as 2/30.
+func date(year, month, day int) (time.Time, bool) {
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	return t, t.Month() == time.Month(month) && t.Day() == day
+}
+
+// monthDay is the next date on the month and day, today or later.
+func monthDay(today time.Time, month, day int) (time.Time, bool) {
+	t, ok := date(today.Year(), month, day)
+	if ok && t.Before(today) {
+		return date(today.Year()+1, month, day)
+	}
+	return t, ok
+}
+
+// weekdayOfWeek is the weekday of the week the given weeks after this week, a week starts on Monday.
+func weekdayOfWeek(today time.Time, weeks int, weekday time.Weekday) time.Time {
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	return monday.AddDate(0, 0, 7*weeks+(int(weekday)+6)%7)
+}
+
+func nextWeekday(today time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+func after(today time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "week":
+		return today.AddDate(0, 0, 7*n)
+	case "month":
+		return today.AddDate(0, n, 0)
+	}
+	return today.AddDate(0, 0, n)
+}
@@ -0,0 +1,65 @@
+// Package quickadd parses a task written in a single line for the quick-add endpoint.
+//
+// The words starting with # are labels, ! a priority and @ an assignee. The first
+// date found in English or Japanese, such as "next friday", "jan 10", "明日" or
+// "来週金曜", is the due date. The rest of the line is the title.
+package quickadd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+var priorities = map[string]domain.TaskPriority{
+	"low":    domain.TaskPriorityLow,
+	"medium": domain.TaskPriorityMedium,
+	"med":    domain.TaskPriorityMedium,
+	"high":   domain.TaskPriorityHigh,
+	"urgent": domain.TaskPriorityUrgent,
+	"低":      domain.TaskPriorityLow,
+	"中":      domain.TaskPriorityMedium,
+	"高":      domain.TaskPriorityHigh,
+	"緊急":     domain.TaskPriorityUrgent,
+}
+
+// Parse parses the line, now is the current time in the user's timezone
+// which decides what "today" is.
+func Parse(text string, now time.Time) domain.TaskQuickAdd {
+	var result domain.TaskQuickAdd
+	var words []string
+	for _, word := range strings.Fields(text) {
+		name := strings.TrimRight(word[1:], ",.;:")
+		switch {
+		case name == "":
+			words = append(words, word)
+		case word[0] == '#':
+			result.Labels = appendUnique(result.Labels, name)
+		case word[0] == '@':
+			result.Assignees = appendUnique(result.Assignees, name)
+		case word[0] == '!' && priorities[strings.ToLower(name)] != "":
+			result.Priority = priorities[strings.ToLower(name)]
+		default:
+			words = append(words, word)
+		}
+	}
+
+	rest := strings.Join(words, " ")
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if dueDate, loc, ok := parseDate(rest, today); ok {
+		result.DueDate = &domain.DateOnly{Time: dueDate}
+		rest = rest[:loc[0]] + " " + rest[loc[1]:]
+	}
+	result.Title = strings.Join(strings.Fields(rest), " ")
+	return result
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
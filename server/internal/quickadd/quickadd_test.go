@@ -0,0 +1,59 @@
+package quickadd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/quickadd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday 2025-01-08 in Tokyo, still Tuesday in UTC
+	now := time.Date(2025, 1, 7, 20, 0, 0, 0, time.UTC).In(time.FixedZone("JST", 9*60*60))
+	dueDate := func(s string) *domain.DateOnly {
+		d := domain.MustDateOnly(s)
+		return &d
+	}
+
+	tests := []struct {
+		title string
+		text  string
+		now   time.Time
+		want  domain.TaskQuickAdd
+	}{
+		{
+			"all tokens",
+			"Deploy API next friday #backend !high @alice",
+			now,
+			domain.TaskQuickAdd{Title: "Deploy API", DueDate: dueDate("2025-01-17"), Labels: []string{"backend"}, Priority: "high", Assignees: []string{"alice"}},
+		},
+		{"weekday", "Write report friday", now, domain.TaskQuickAdd{Title: "Write report", DueDate: dueDate("2025-01-10")}},
+		{"this weekday", "Write report by this Fri", now, domain.TaskQuickAdd{Title: "Write report", DueDate: dueDate("2025-01-10")}},
+		{"tomorrow", "call mom tomorrow", now, domain.TaskQuickAdd{Title: "call mom", DueDate: dueDate("2025-01-09")}},
+		{"today in the timezone", "call mom today", now.UTC(), domain.TaskQuickAdd{Title: "call mom", DueDate: dueDate("2025-01-07")}},
+		{"in weeks", "Team lunch in 2 weeks", now, domain.TaskQuickAdd{Title: "Team lunch", DueDate: dueDate("2025-01-22")}},
+		{"next month", "Plan roadmap next month", now, domain.TaskQuickAdd{Title: "Plan roadmap", DueDate: dueDate("2025-02-01")}},
+		{"month and day", "pay rent due 2/1", now, domain.TaskQuickAdd{Title: "pay rent", DueDate: dueDate("2025-02-01")}},
+		{"past month and day is next year", "renew domain Jan 5th", now, domain.TaskQuickAdd{Title: "renew domain", DueDate: dueDate("2026-01-05")}},
+		{"iso date", "file taxes 2025-03-15", now, domain.TaskQuickAdd{Title: "file taxes", DueDate: dueDate("2025-03-15")}},
+		{"invalid date", "file taxes 2025-02-30", now, domain.TaskQuickAdd{Title: "file taxes 2025-02-30"}},
+		{
+			"japanese",
+			"明日までに資料作成 #docs @hanako",
+			now,
+			domain.TaskQuickAdd{Title: "資料作成", DueDate: dueDate("2025-01-09"), Labels: []string{"docs"}, Assignees: []string{"hanako"}},
+		},
+		{"japanese next week", "来週金曜 リリース !緊急", now, domain.TaskQuickAdd{Title: "リリース", DueDate: dueDate("2025-01-17"), Priority: "urgent"}},
+		{"japanese days after", "レビュー 3日後", now, domain.TaskQuickAdd{Title: "レビュー", DueDate: dueDate("2025-01-11")}},
+		{"japanese date", "請求書 1月31日まで", now, domain.TaskQuickAdd{Title: "請求書", DueDate: dueDate("2025-01-31")}},
+		{"no date", "Fix sun icon !foo", now, domain.TaskQuickAdd{Title: "Fix sun icon !foo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, quickadd.Parse(tt.text, tt.now))
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package rank generates the lexicographic ranks ordering the cards of a board.
+//
+// A rank is a string of base-36 digits compared byte by byte, so that a card is
+// moved by giving it a rank between its new neighbours without renumbering the
+// other cards. A rank never ends with "0", which keeps room below every rank.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalidRange = errors.New("rank: prev must be before next")
+
+// Between returns a rank sorting after prev and before next. An empty prev is the
+// beginning and an empty next the end of the list, so Between("", "") is the first rank.
+func Between(prev, next string) (string, error) {
+	if !valid(prev) || !valid(next) || (next != "" && prev >= next) {
+		return "", ErrInvalidRange
+	}
+	return midpoint(prev, next), nil
+}
+
+// After returns n ranks following prev in order.
+func After(prev string, n int) ([]string, error) {
+	ranks := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		r, err := Between(prev, "")
+		if err != nil {
+			return nil, err
+		}
+		ranks = append(ranks, r)
+		prev = r
+	}
+	return ranks, nil
+}
+
+// midpoint returns a string between a and b, b is empty for the end of the list.
+func midpoint(a, b string) string {
+	if b != "" {
+		// keep the common prefix, a is padded with zeros
+		n := 0
+		for n < len(b) && digitAt(a, n) == strings.IndexByte(digits, b[n]) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	lo := digitAt(a, 0)
+	hi := len(digits)
+	if b != "" {
+		hi = strings.IndexByte(digits, b[0])
+	}
+	if hi-lo > 1 {
+		return string(digits[(lo+hi)/2])
+	}
+	// the first digits are consecutive
+	if len(b) > 1 {
+		return b[:1]
+	}
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(digits[lo]) + midpoint(rest, "")
+}
+
+func digitAt(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	return strings.IndexByte(digits, s[i])
+}
+
+func valid(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(digits, s[i]) < 0 {
+			return false
+		}
+	}
+	return s == "" || s[len(s)-1] != '0'
+}
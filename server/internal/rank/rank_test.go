@@ -0,0 +1,65 @@
+package rank_test
+
+import (
+	"testing"
+
+	"github.com/keitatwr/task-management-app/internal/rank"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		title     string
+		prev      string
+		next      string
+		want      string
+		wantError error
+	}{
+		{"first rank", "", "", "i", nil},
+		{"after", "i", "", "r", nil},
+		{"before", "", "i", "9", nil},
+		{"between", "a", "c", "b", nil},
+		{"consecutive digits", "a", "b", "ai", nil},
+		{"common prefix", "1", "102", "101", nil},
+		{"after the last digit", "z", "", "zi", nil},
+		{"before a longer rank", "", "01", "00i", nil},
+		{"prev after next", "c", "a", "", rank.ErrInvalidRange},
+		{"same rank", "a", "a", "", rank.ErrInvalidRange},
+		{"trailing zero", "a0", "", "", rank.ErrInvalidRange},
+		{"invalid digit", "A", "", "", rank.ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			got, err := rank.Between(tt.prev, tt.next)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBetweenRepeatedly(t *testing.T) {
+	// moving cards to the same place keeps producing ranks in order
+	prev, next := "a", "b"
+	for i := 0; i < 200; i++ {
+		r, err := rank.Between(prev, next)
+		assert.NoError(t, err)
+		assert.Less(t, prev, r)
+		assert.Less(t, r, next)
+		if i%2 == 0 {
+			next = r
+		} else {
+			prev = r
+		}
+	}
+}
+
+func TestAfter(t *testing.T) {
+	ranks, err := rank.After("x", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"y", "z", "zi"}, ranks)
+}
@@ -0,0 +1,108 @@
+// Package taskio reads and writes tasks as CSV, JSON or a Markdown checklist for the import and export endpoints,
+// and writes the task statistics as CSV.
+package taskio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+const (
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// Columns are the columns of the exported tasks, the import reads the same names by default.
+var Columns = []string{"id", "title", "description", "dueDate", "completed", "completedAt", "archivedAt", "createdBy", "createdAt"}
+
+// Encoder writes the tasks one by one, Close must be called after the last task.
+type Encoder interface {
+	Encode(task domain.Task) error
+	Close() error
+}
+
+func NewEncoder(w io.Writer, format string) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVEncoder(w)
+	case FormatJSON:
+		return &jsonEncoder{w: w}, nil
+	case FormatMarkdown:
+		return &markdownEncoder{w: w}, nil
+	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) (*csvEncoder, error) {
+	e := &csvEncoder{w: csv.NewWriter(w)}
+	if err := e.w.Write(Columns); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *csvEncoder) Encode(task domain.Task) error {
+	return e.w.Write([]string{
+		strconv.Itoa(task.ID),
+		task.Title,
+		task.Description,
+		task.DueDate.Format("2006-01-02"),
+		strconv.FormatBool(task.Completed),
+		formatTime(task.CompletedAt),
+		formatTime(task.ArchivedAt),
+		strconv.Itoa(task.CreatedBy),
+		task.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonEncoder writes a JSON array without holding all the tasks in memory.
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(task domain.Task) error {
+	b, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	sep := ","
+	if e.count == 0 {
+		sep = "["
+	}
+	e.count++
+	_, err = fmt.Fprintf(e.w, "%s%s", sep, b)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if e.count == 0 {
+		_, err := io.WriteString(e.w, "[]")
+		return err
+	}
+	_, err := io.WriteString(e.w, "]")
+	return err
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,106 @@
+package taskio
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+// Mapping maps the fields of a task ("title", "description", "dueDate") to the
+// columns of a CSV file or the keys of the JSON objects. A field which is not
+// mapped is read from the column of the same name.
+type Mapping map[string]string
+
+func (m Mapping) column(field string) string {
+	if c, ok := m[field]; ok && c != "" {
+		return c
+	}
+	return field
+}
+
+// Decode reads the records to import. The rows are numbered from 1 without the CSV header,
+// the items of a Markdown checklist are numbered in the same way. The mapping is not used for Markdown.
+func Decode(r io.Reader, format string, mapping Mapping) ([]domain.TaskImportRecord, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(r, mapping)
+	case FormatJSON:
+		return decodeJSON(r, mapping)
+	case FormatMarkdown:
+		return decodeMarkdown(r)
+	}
+	return nil, fmt.Errorf("unsupported format: %s", format)
+}
+
+func decodeCSV(r io.Reader, mapping Mapping) ([]domain.TaskImportRecord, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[h] = i
+	}
+	value := func(row []string, field string) string {
+		i, ok := index[mapping.column(field)]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+
+	var records []domain.TaskImportRecord
+	for n := 1; ; n++ {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, domain.TaskImportRecord{
+			Row:         n,
+			Title:       value(row, "title"),
+			Description: value(row, "description"),
+			DueDate:     value(row, "dueDate"),
+		})
+	}
+}
+
+func decodeJSON(r io.Reader, mapping Mapping) ([]domain.TaskImportRecord, error) {
+	var objects []map[string]any
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, err
+	}
+	value := func(o map[string]any, field string) string {
+		v, ok := o[mapping.column(field)]
+		if !ok || v == nil {
+			return ""
+		}
+		if s, ok := v.(string); ok {
+			return s
+		}
+		return fmt.Sprint(v)
+	}
+
+	records := make([]domain.TaskImportRecord, len(objects))
+	for i, o := range objects {
+		records[i] = domain.TaskImportRecord{
+			Row:         i + 1,
+			Title:       value(o, "title"),
+			Description: value(o, "description"),
+			DueDate:     value(o, "dueDate"),
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,124 @@
+package taskio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+// A task is written as a GitHub-style checklist item followed by its description indented
+// by two spaces. The comment with the task ID matches the item with the task when the
+// document is imported again:
+//
+//   - [x] write report (due: 2025-01-10) <!-- task:12 -->
+//     first line of the description
+//     second line
+const markdownIndent = "  "
+
+var (
+	markdownItem    = regexp.MustCompile(`^[-*+] \[([ xX])\] (.*)$`)
+	markdownTaskID  = regexp.MustCompile(`\s*<!-- task:(\d+) -->$`)
+	markdownDueDate = regexp.MustCompile(`\s*\(due: (\d{4}-\d{2}-\d{2})\)$`)
+)
+
+type markdownEncoder struct {
+	w io.Writer
+}
+
+func (e *markdownEncoder) Encode(task domain.Task) error {
+	var b strings.Builder
+	check := " "
+	if task.Completed {
+		check = "x"
+	}
+	// a title is a single line, the line breaks would start a new item
+	title := strings.Join(strings.Fields(task.Title), " ")
+	fmt.Fprintf(&b, "- [%s] %s", check, title)
+	if !task.DueDate.IsZero() {
+		fmt.Fprintf(&b, " (due: %s)", task.DueDate.Format("2006-01-02"))
+	}
+	fmt.Fprintf(&b, " <!-- task:%d -->\n", task.ID)
+
+	if task.Description != "" {
+		for _, line := range strings.Split(strings.ReplaceAll(task.Description, "\r\n", "\n"), "\n") {
+			if line == "" {
+				b.WriteString("\n")
+				continue
+			}
+			b.WriteString(markdownIndent + line + "\n")
+		}
+	}
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *markdownEncoder) Close() error {
+	return nil
+}
+
+// decodeMarkdown reads the top-level checklist items, the other lines such as headings are skipped.
+func decodeMarkdown(r io.Reader) ([]domain.TaskImportRecord, error) {
+	var (
+		records     []domain.TaskImportRecord
+		description []string
+	)
+	flush := func() {
+		if len(records) == 0 {
+			return
+		}
+		// the blank lines only separate the paragraphs of the description
+		for len(description) > 0 && description[len(description)-1] == "" {
+			description = description[:len(description)-1]
+		}
+		records[len(records)-1].Description = strings.Join(description, "\n")
+		description = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	inItem := false
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if m := markdownItem.FindStringSubmatch(line); m != nil {
+			flush()
+			records = append(records, parseMarkdownItem(len(records)+1, m[1] != " ", m[2]))
+			inItem = true
+			continue
+		}
+		switch {
+		case inItem && strings.HasPrefix(line, markdownIndent):
+			description = append(description, strings.TrimPrefix(line, markdownIndent))
+		case inItem && strings.TrimSpace(line) == "":
+			if len(description) > 0 {
+				description = append(description, "")
+			}
+		default:
+			inItem = false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return records, nil
+}
+
+func parseMarkdownItem(row int, completed bool, text string) domain.TaskImportRecord {
+	record := domain.TaskImportRecord{Row: row, Completed: &completed}
+	if m := markdownTaskID.FindStringSubmatch(text); m != nil {
+		record.TaskID, _ = strconv.Atoi(m[1])
+		text = text[:len(text)-len(m[0])]
+	}
+	if m := markdownDueDate.FindStringSubmatch(text); m != nil {
+		record.DueDate = m[1]
+		text = text[:len(text)-len(m[0])]
+	}
+	record.Title = strings.TrimSpace(text)
+	return record
+}
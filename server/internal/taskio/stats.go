@@ -0,0 +1,34 @@
+package taskio
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+// StatsColumns are the columns of the exported statistics, the date is only set on
+// the rows of the completion trend.
+var StatsColumns = []string{"metric", "date", "value"}
+
+// EncodeStats writes the statistics as CSV, one row per metric followed by one row
+// per day of the completion trend.
+func EncodeStats(w io.Writer, stats *domain.TaskStats) error {
+	averageCompletionHours := ""
+	if stats.AverageCompletionHours != nil {
+		averageCompletionHours = strconv.FormatFloat(*stats.AverageCompletionHours, 'f', 2, 64)
+	}
+	records := [][]string{
+		StatsColumns,
+		{"open", "", strconv.Itoa(stats.Open)},
+		{"completed", "", strconv.Itoa(stats.Completed)},
+		{"overdue", "", strconv.Itoa(stats.Overdue)},
+		{"dueThisWeek", "", strconv.Itoa(stats.DueThisWeek)},
+		{"averageCompletionHours", "", averageCompletionHours},
+	}
+	for _, day := range stats.Trend {
+		records = append(records, []string{"completedOnDay", day.Date.Format("2006-01-02"), strconv.Itoa(day.Completed)})
+	}
+	return csv.NewWriter(w).WriteAll(records)
+}
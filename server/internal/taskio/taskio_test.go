@@ -0,0 +1,169 @@
+package taskio_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/taskio"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	createdAt := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []domain.Task{
+		{ID: 1, Title: "buy milk", Description: "2 bottles, low fat", CreatedBy: 1, DueDate: domain.MustDateOnly("2024-12-31"), CreatedAt: createdAt},
+		{ID: 2, Title: "write report", Description: "", Completed: true, CreatedBy: 2, DueDate: domain.MustDateOnly("2025-01-10"), CreatedAt: createdAt},
+	}
+
+	tests := []struct {
+		title  string
+		format string
+		tasks  []domain.Task
+		want   string
+	}{
+		{
+			"csv",
+			taskio.FormatCSV,
+			tasks,
+			"id,title,description,dueDate,completed,completedAt,archivedAt,createdBy,createdAt\n" +
+				"1,buy milk,\"2 bottles, low fat\",2024-12-31,false,,,1,2024-12-01T09:00:00Z\n" +
+				"2,write report,,2025-01-10,true,,,2,2024-12-01T09:00:00Z\n",
+		},
+		{
+			"json",
+			taskio.FormatJSON,
+			tasks[:1],
+			`[{"id":1,"title":"buy milk","description":"2 bottles, low fat","completed":false,"createdBy":1,"parentID":null,"dueDate":"2024-12-31",` +
+				`"priority":"","estimateMinutes":null,"assignees":null,"labels":null,"customFields":null,"trackedSeconds":0,"completedAt":null,"archivedAt":null,"createdAt":"2024-12-01T09:00:00Z","deletedAt":null}]`,
+		},
+		{"empty json", taskio.FormatJSON, nil, "[]"},
+		{
+			"markdown",
+			taskio.FormatMarkdown,
+			[]domain.Task{
+				{ID: 1, Title: "buy milk", Description: "2 bottles\n\nlow fat", DueDate: domain.MustDateOnly("2024-12-31")},
+				{ID: 2, Title: "write report", Completed: true},
+			},
+			"- [ ] buy milk (due: 2024-12-31) <!-- task:1 -->\n  2 bottles\n\n  low fat\n" +
+				"- [x] write report <!-- task:2 -->\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder, err := taskio.NewEncoder(&buf, tt.format)
+			assert.NoError(t, err)
+			for _, task := range tt.tasks {
+				assert.NoError(t, encoder.Encode(task))
+			}
+			assert.NoError(t, encoder.Close())
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		title   string
+		format  string
+		input   string
+		mapping taskio.Mapping
+		want    []domain.TaskImportRecord
+	}{
+		{
+			"csv with the exported columns",
+			taskio.FormatCSV,
+			"id,title,description,dueDate\n1,buy milk,low fat,2024-12-31\n2,write report,,\n",
+			nil,
+			[]domain.TaskImportRecord{
+				{Row: 1, Title: "buy milk", Description: "low fat", DueDate: "2024-12-31"},
+				{Row: 2, Title: "write report"},
+			},
+		},
+		{
+			"csv with column mapping",
+			taskio.FormatCSV,
+			"Name,Notes,Deadline\nbuy milk,low fat,2024-12-31\n",
+			taskio.Mapping{"title": "Name", "description": "Notes", "dueDate": "Deadline"},
+			[]domain.TaskImportRecord{
+				{Row: 1, Title: "buy milk", Description: "low fat", DueDate: "2024-12-31"},
+			},
+		},
+		{
+			"json with column mapping",
+			taskio.FormatJSON,
+			`[{"name":"buy milk","description":"low fat","dueDate":"2024-12-31"},{"name":42}]`,
+			taskio.Mapping{"title": "name"},
+			[]domain.TaskImportRecord{
+				{Row: 1, Title: "buy milk", Description: "low fat", DueDate: "2024-12-31"},
+				{Row: 2, Title: "42"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			records, err := taskio.Decode(strings.NewReader(tt.input), tt.format, tt.mapping)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, records)
+		})
+	}
+}
+
+func TestDecodeMarkdown(t *testing.T) {
+	completed, notCompleted := true, false
+
+	tests := []struct {
+		title string
+		input string
+		want  []domain.TaskImportRecord
+	}{
+		{
+			"exported checklist",
+			"- [ ] buy milk (due: 2024-12-31) <!-- task:1 -->\n  2 bottles\n\n  low fat\n" +
+				"- [x] write report <!-- task:2 -->\n",
+			[]domain.TaskImportRecord{
+				{Row: 1, TaskID: 1, Title: "buy milk", Description: "2 bottles\n\nlow fat", DueDate: "2024-12-31", Completed: &notCompleted},
+				{Row: 2, TaskID: 2, Title: "write report", Completed: &completed},
+			},
+		},
+		{
+			"handwritten notes",
+			"# Groceries\r\n\r\nSome notes.\r\n\r\n* [X] buy milk\r\n  low fat\r\n\r\nnot a description\r\n" +
+				"- [ ] buy eggs (due: 2025-01-05)\r\n- buy bread\r\n",
+			[]domain.TaskImportRecord{
+				{Row: 1, Title: "buy milk", Description: "low fat", Completed: &completed},
+				{Row: 2, Title: "buy eggs", DueDate: "2025-01-05", Completed: &notCompleted},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			records, err := taskio.Decode(strings.NewReader(tt.input), taskio.FormatMarkdown, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, records)
+		})
+	}
+}
+
+func TestEncodeStats(t *testing.T) {
+	averageCompletionHours := 12.5
+	stats := &domain.TaskStats{
+		Open: 3, Completed: 2, Overdue: 1, DueThisWeek: 2, AverageCompletionHours: &averageCompletionHours,
+		Trend: []domain.TaskStatsDay{
+			{Date: domain.MustDateOnly("2024-12-01"), Completed: 0},
+			{Date: domain.MustDateOnly("2024-12-02"), Completed: 2},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, taskio.EncodeStats(&buf, stats))
+	assert.Equal(t, "metric,date,value\n"+
+		"open,,3\ncompleted,,2\noverdue,,1\ndueThisWeek,,2\naverageCompletionHours,,12.50\n"+
+		"completedOnDay,2024-12-01,0\ncompletedOnDay,2024-12-02,2\n", buf.String())
+}
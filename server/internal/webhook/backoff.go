@@ -0,0 +1,25 @@
+package webhook
+
+import "time"
+
+const (
+	MaxAttempts = 8
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+)
+
+// Backoff returns the wait before the next attempt after the given number of
+// failed attempts: 30s, 1m, 2m, 4m, ... up to 6h.
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	d := baseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	SignatureHeaderKey = "X-Webhook-Signature"
+	TimestampHeaderKey = "X-Webhook-Timestamp"
+	EventHeaderKey     = "X-Webhook-Event"
+	DeliveryHeaderKey  = "X-Webhook-Delivery"
+
+	DefaultTimeout = 10 * time.Second
+)
+
+type Request struct {
+	URL        string
+	Secret     string
+	EventType  string
+	DeliveryID int64
+	Body       []byte
+}
+
+// ErrAddressNotAllowed is returned for the webhook URLs resolving to an address of the
+// server's own network, such as localhost or the cloud metadata service.
+var ErrAddressNotAllowed = errors.New("webhook address not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range, which some clouds use for their
+// internal services.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+type Sender interface {
+	Send(ctx context.Context, req Request) (int, error)
+}
+
+type HTTPSender struct {
+	Client *http.Client
+	Now    func() time.Time
+}
+
+// NewHTTPSender creates the sender which only connects to the public addresses and
+// does not follow the redirects, since the URLs are given by the users. The address is
+// checked when dialing so that a host name cannot resolve to another address after the
+// check, and the proxy of the environment is not used for the same reason.
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkAddress}
+	return &HTTPSender{
+		Client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		Now: time.Now,
+	}
+}
+
+// checkAddress rejects the connections to the loopback, private, link-local and the
+// other addresses which are not reachable on the internet.
+func checkAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, address)
+	}
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, addr)
+	}
+	return nil
+}
+
+// Send posts the signed payload and returns the response status code, any status
+// other than 2xx is reported as an error, including the redirects.
+func (s *HTTPSender) Send(ctx context.Context, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := s.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(TimestampHeaderKey, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(SignatureHeaderKey, Sign(req.Secret, timestamp, req.Body))
+	httpReq.Header.Set(EventHeaderKey, req.EventType)
+	httpReq.Header.Set(DeliveryHeaderKey, strconv.FormatInt(req.DeliveryID, 10))
+
+	resp, err := s.Client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,152 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/internal/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+// allowLoopback lets the sender connect to the test servers, which listen on loopback.
+func allowLoopback(sender *webhook.HTTPSender) *webhook.HTTPSender {
+	sender.Client.Transport.(*http.Transport).DialContext = (&net.Dialer{}).DialContext
+	return sender
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"task.created"}`)
+	signature := webhook.Sign("secret", 1700000000, body)
+
+	assert.True(t, webhook.Verify("secret", signature, 1700000000, body))
+	assert.False(t, webhook.Verify("other secret", signature, 1700000000, body))
+	assert.False(t, webhook.Verify("secret", signature, 1700000001, body))
+	assert.False(t, webhook.Verify("secret", signature, 1700000000, []byte(`{"type":"task.deleted"}`)))
+}
+
+func TestHTTPSenderSend(t *testing.T) {
+	tests := []struct {
+		title          string
+		responseStatus int
+		wantStatus     int
+		wantError      bool
+	}{
+		{"success", http.StatusOK, http.StatusOK, false},
+		{"success no content", http.StatusNoContent, http.StatusNoContent, false},
+		{"server error", http.StatusInternalServerError, http.StatusInternalServerError, true},
+		{"client error", http.StatusGone, http.StatusGone, true},
+	}
+
+	body := []byte(`{"id":1,"type":"task.created"}`)
+	now := time.Unix(1700000000, 0)
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			var received *http.Request
+			var receivedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				receivedBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.responseStatus)
+			}))
+			defer server.Close()
+
+			sender := allowLoopback(webhook.NewHTTPSender(time.Second))
+			sender.Now = func() time.Time { return now }
+
+			status, err := sender.Send(context.TODO(), webhook.Request{
+				URL:        server.URL,
+				Secret:     "secret",
+				EventType:  "task.created",
+				DeliveryID: 10,
+				Body:       body,
+			})
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			// the receiver can verify the payload with the shared secret
+			timestamp, _ := strconv.ParseInt(received.Header.Get(webhook.TimestampHeaderKey), 10, 64)
+			assert.Equal(t, now.Unix(), timestamp)
+			assert.True(t, webhook.Verify("secret", received.Header.Get(webhook.SignatureHeaderKey), timestamp, receivedBody))
+			assert.Equal(t, "task.created", received.Header.Get(webhook.EventHeaderKey))
+			assert.Equal(t, "10", received.Header.Get(webhook.DeliveryHeaderKey))
+			assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+			assert.Equal(t, body, receivedBody)
+		})
+	}
+}
+
+func TestHTTPSenderSendConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	status, err := allowLoopback(webhook.NewHTTPSender(time.Second)).Send(context.TODO(), webhook.Request{URL: server.URL})
+	assert.Error(t, err)
+	assert.Equal(t, 0, status)
+}
+
+func TestHTTPSenderSendAddressNotAllowed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request must not reach the server")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		title string
+		url   string
+	}{
+		{"loopback", server.URL},
+		{"localhost", "http://localhost:5432/"},
+		{"ipv6 loopback", "http://[::1]:5432/"},
+		{"metadata service", "http://169.254.169.254/latest/meta-data/"},
+		{"private", "http://10.0.0.1/"},
+		{"ipv4 mapped private", "http://[::ffff:192.168.0.1]/"},
+		{"shared address space", "http://100.100.100.200/"},
+		{"unspecified", "http://0.0.0.0:8080/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			status, err := webhook.NewHTTPSender(time.Second).Send(context.TODO(), webhook.Request{URL: tt.url})
+			assert.ErrorIs(t, err, webhook.ErrAddressNotAllowed)
+			assert.Equal(t, 0, status)
+		})
+	}
+}
+
+func TestHTTPSenderSendRedirect(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	// the redirect is reported as a failure instead of being followed
+	status, err := allowLoopback(webhook.NewHTTPSender(time.Second)).Send(context.TODO(), webhook.Request{URL: server.URL})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTemporaryRedirect, status)
+	assert.False(t, redirected)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), webhook.Backoff(0))
+	assert.Equal(t, 30*time.Second, webhook.Backoff(1))
+	assert.Equal(t, time.Minute, webhook.Backoff(2))
+	assert.Equal(t, 2*time.Minute, webhook.Backoff(3))
+	assert.Equal(t, 6*time.Hour, webhook.Backoff(20))
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type appPasswordRepository struct {
+	db *gorm.DB
+}
+
+func NewAppPasswordRepository(db *gorm.DB) domain.AppPasswordRepository {
+	return &appPasswordRepository{
+		db: db,
+	}
+}
+
+func (r *appPasswordRepository) Create(ctx context.Context, appPassword *domain.AppPassword) error {
+	if err := r.db.WithContext(ctx).Create(appPassword).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *appPasswordRepository) FetchAppPasswordsByUserID(ctx context.Context, userID int) ([]domain.AppPassword, error) {
+	var appPasswords []domain.AppPassword
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&appPasswords).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return appPasswords, nil
+}
+
+func (r *appPasswordRepository) FetchAppPasswordByHash(ctx context.Context, userID int, passwordHash string) (*domain.AppPassword, error) {
+	var appPassword domain.AppPassword
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND password_hash = ?", userID, passwordHash).
+		Take(&appPassword).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrAppPasswordNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &appPassword, nil
+}
+
+// Delete only deletes the user's own app password.
+func (r *appPasswordRepository) Delete(ctx context.Context, appPasswordID, userID int) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", appPasswordID, userID).
+		Delete(&domain.AppPassword{})
+	if result.Error != nil {
+		return myerror.ErrQueryFailed.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return myerror.ErrAppPasswordNotFound.Wrap(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type boardRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardRepository(db *gorm.DB) domain.BoardRepository {
+	return &boardRepository{
+		db: db,
+	}
+}
+
+func (r *boardRepository) Create(ctx context.Context, board *domain.Board) error {
+	if err := r.db.WithContext(ctx).Create(board).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *boardRepository) FetchBoardByID(ctx context.Context, boardID int) (*domain.Board, error) {
+	var board domain.Board
+	if err := conn(ctx, r.db).Where("id = ?", boardID).Take(&board).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrBoardNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &board, nil
+}
+
+func (r *boardRepository) FetchBoardsByUserID(ctx context.Context, userID int) ([]domain.Board, error) {
+	var boards []domain.Board
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&boards).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return boards, nil
+}
+
+func (r *boardRepository) Update(ctx context.Context, board *domain.Board) error {
+	if err := r.db.WithContext(ctx).Model(board).Select("name", "columns").Updates(board).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+// Delete deletes the board, the cards go away with it.
+func (r *boardRepository) Delete(ctx context.Context, boardID int) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", boardID).Delete(&domain.Board{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *boardRepository) Lock(ctx context.Context, boardID int) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	var board domain.Board
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+		Where("id = ?", boardID).Take(&board).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return myerror.ErrBoardNotFound.Wrap(err)
+		}
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *boardRepository) FetchCardsByBoardID(ctx context.Context, boardID int) ([]domain.BoardCard, error) {
+	var cards []domain.BoardCard
+	if err := conn(ctx, r.db).Where("board_id = ?", boardID).Find(&cards).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return cards, nil
+}
+
+// SaveCards inserts the cards or updates the rank of the cards already on the board.
+func (r *boardRepository) SaveCards(ctx context.Context, cards ...domain.BoardCard) error {
+	if len(cards) == 0 {
+		return nil
+	}
+	if err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "board_id"}, {Name: "task_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rank"}),
+	}).Create(&cards).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
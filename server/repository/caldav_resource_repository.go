@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type calDAVResourceRepository struct {
+	db *gorm.DB
+}
+
+func NewCalDAVResourceRepository(db *gorm.DB) domain.CalDAVResourceRepository {
+	return &calDAVResourceRepository{
+		db: db,
+	}
+}
+
+// Create must run in the transaction which creates the task. It fails with
+// ErrPreconditionFailed when the user has another resource of the name, which a
+// concurrent request has created since the name was looked up.
+func (r *calDAVResourceRepository) Create(ctx context.Context, resource *domain.CalDAVResource) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	if err := tx.Create(resource).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return myerror.ErrPreconditionFailed.WrapWithDescription(err, "calendar object has been created by another request")
+		}
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *calDAVResourceRepository) FetchResourceByName(ctx context.Context, userID int, name string) (*domain.CalDAVResource, error) {
+	var resource domain.CalDAVResource
+	if err := conn(ctx, r.db).Where("user_id = ? AND name = ?", userID, name).Take(&resource).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrCalDAVObjectNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &resource, nil
+}
+
+func (r *calDAVResourceRepository) FetchResourcesByTaskID(ctx context.Context, taskIDs ...int) ([]domain.CalDAVResource, error) {
+	var resources []domain.CalDAVResource
+	if len(taskIDs) == 0 {
+		return resources, nil
+	}
+	if err := conn(ctx, r.db).Where("task_id IN ?", taskIDs).Find(&resources).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return resources, nil
+}
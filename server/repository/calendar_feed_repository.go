@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) domain.CalendarFeedRepository {
+	return &calendarFeedRepository{
+		db: db,
+	}
+}
+
+// Save replaces the user's feed, so the previous token is invalidated.
+func (r *calendarFeedRepository) Save(ctx context.Context, feed *domain.CalendarFeed) error {
+	if err := r.db.WithContext(ctx).Save(feed).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *calendarFeedRepository) FetchFeedByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrCalendarFeedNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &feed, nil
+}
+
+func (r *calendarFeedRepository) DeleteByUserID(ctx context.Context, userID int) error {
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.CalendarFeed{})
+	if result.Error != nil {
+		return myerror.ErrQueryFailed.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return myerror.ErrCalendarFeedNotFound.Wrap(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type customFieldRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldRepository(db *gorm.DB) domain.CustomFieldRepository {
+	return &customFieldRepository{
+		db: db,
+	}
+}
+
+// Create fails with ErrCustomFieldAlreadyExists when another field has the key.
+func (r *customFieldRepository) Create(ctx context.Context, field *domain.CustomField) error {
+	if err := r.db.WithContext(ctx).Create(field).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return myerror.ErrCustomFieldAlreadyExists.Wrap(err)
+		}
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *customFieldRepository) FetchFields(ctx context.Context) ([]domain.CustomField, error) {
+	var fields []domain.CustomField
+	if err := conn(ctx, r.db).Order("id").Find(&fields).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return fields, nil
+}
+
+func (r *customFieldRepository) FetchFieldByID(ctx context.Context, fieldID int) (*domain.CustomField, error) {
+	var field domain.CustomField
+	if err := conn(ctx, r.db).Where("id = ?", fieldID).Take(&field).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrCustomFieldNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &field, nil
+}
+
+func (r *customFieldRepository) Update(ctx context.Context, field *domain.CustomField) error {
+	if err := r.db.WithContext(ctx).Model(field).Select("name", "options").Updates(field).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *customFieldRepository) Delete(ctx context.Context, fieldID int) error {
+	if err := conn(ctx, r.db).Where("id = ?", fieldID).Delete(&domain.CustomField{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *customFieldRepository) RemoveTaskValues(ctx context.Context, key string) error {
+	if err := conn(ctx, r.db).Unscoped().Model(&domain.Task{}).Where("custom_fields ->> ? IS NOT NULL", key).
+		UpdateColumn("custom_fields", gorm.Expr("custom_fields - ?", key)).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type digestRepository struct {
+	db *gorm.DB
+}
+
+func NewDigestRepository(db *gorm.DB) domain.DigestRepository {
+	return &digestRepository{
+		db: db,
+	}
+}
+
+func (r *digestRepository) FetchRecipients(ctx context.Context, defaults *domain.UserSetting) ([]domain.DigestRecipient, error) {
+	var recipients []domain.DigestRecipient
+	if err := r.db.WithContext(ctx).Table("users u").
+		Joins("LEFT JOIN user_settings s ON s.user_id = u.id").
+		Select("u.id AS user_id, u.name, u.email, "+
+			"COALESCE(s.digest_hour, ?) AS digest_hour, COALESCE(s.timezone, ?) AS timezone, COALESCE(s.locale, ?) AS locale",
+			defaults.DigestHour, defaults.Timezone, defaults.Locale).
+		Where("COALESCE(s.digest_enabled, ?)", defaults.DigestEnabled).
+		Order("u.id").
+		Scan(&recipients).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return recipients, nil
+}
+
+func (r *digestRepository) FetchDueTasks(ctx context.Context, userID int, today domain.DateOnly) ([]domain.Task, error) {
+	var tasks []domain.Task
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN task_permissions p ON p.task_id = tasks.id").
+		Where("p.user_id = ? AND p.can_read", userID).
+		Where("tasks.completed = ? AND tasks.archived_at IS NULL AND tasks.due_date <= ?", false, today).
+		Order("tasks.due_date, tasks.id").
+		Find(&tasks).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return tasks, nil
+}
+
+func (r *digestRepository) Claim(ctx context.Context, userID int, date domain.DateOnly) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.DigestDelivery{UserID: userID, DigestDate: date})
+	if result.Error != nil {
+		return false, myerror.ErrQueryFailed.Wrap(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *digestRepository) Release(ctx context.Context, userID int, date domain.DateOnly) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND digest_date = ?", userID, date).
+		Delete(&domain.DigestDelivery{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/tests/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchDueTasks(t *testing.T) {
+	// mock
+	db, mock, tearDown := helper.GetDBMock(t)
+	defer tearDown()
+
+	// the tasks shared read-only and the tasks assigned to the user are in the digest,
+	// the permission only has to grant read
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "tasks"."id","tasks"."title"`)+".*"+regexp.QuoteMeta(
+		`FROM "tasks" JOIN task_permissions p ON p.task_id = tasks.id `+
+			`WHERE (p.user_id = $1 AND p.can_read) `+
+			`AND (tasks.completed = $2 AND tasks.archived_at IS NULL AND tasks.due_date <= $3) `+
+			`AND "tasks"."deleted_at" IS NULL ORDER BY tasks.due_date, tasks.id`)).
+		WithArgs(1, false, "2024-06-12").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "created_by"}).AddRow(3, "assigned", 2))
+
+	// run
+	r := repository.NewDigestRepository(db)
+	tasks, err := r.FetchDueTasks(context.TODO(), 1, domain.MustDateOnly("2024-06-12"))
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Task{{ID: 3, Title: "assigned", CreatedBy: 2}}, tasks)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
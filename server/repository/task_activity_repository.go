@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type taskActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskActivityRepository(db *gorm.DB) domain.TaskActivityRepository {
+	return &taskActivityRepository{
+		db: db,
+	}
+}
+
+// Create records the activity, it must run in the transaction of the change.
+func (r *taskActivityRepository) Create(ctx context.Context, activity *domain.TaskActivity) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	if err := tx.Create(activity).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *taskActivityRepository) FetchActivitiesByTaskID(ctx context.Context, taskID int) ([]domain.TaskActivity, error) {
+	var activities []domain.TaskActivity
+	if err := r.db.WithContext(ctx).Where("task_id = ?", taskID).Order("id").Find(&activities).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return activities, nil
+}
+
+func (r *taskActivityRepository) DeleteByTaskID(ctx context.Context, taskIDs ...int) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	if err := tx.Where("task_id IN ?", taskIDs).Delete(&domain.TaskActivity{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/tests/helper"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateTaskActivity(t *testing.T) {
+	tests := []struct {
+		title        string
+		activity     *domain.TaskActivity
+		setGetTxFunc func(*gorm.DB)
+		wantError    error
+	}{
+		{
+			"success",
+			&domain.TaskActivity{
+				TaskID:  1,
+				ActorID: 2,
+				Action:  domain.TaskActivityUpdated,
+				Changes: map[string]domain.FieldChange{"title": {Before: "old", After: "new"}},
+			},
+			func(tx *gorm.DB) {
+				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+					return tx, true
+				}
+			},
+			nil,
+		},
+		{
+			"transaction not found",
+			&domain.TaskActivity{TaskID: 1, ActorID: 2, Action: domain.TaskActivityDeleted},
+			func(tx *gorm.DB) {
+				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+					return nil, false
+				}
+			},
+			myerror.ErrTransactionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			insert := `INSERT INTO "task_activities" ("task_id","actor_id","action","changes","created_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`
+			switch tt.wantError {
+			case myerror.ErrTransactionNotFound:
+			default:
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(insert)).
+					WithArgs(1, 2, domain.TaskActivityUpdated, `{"title":{"before":"old","after":"new"}}`, helper.AnyTime{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				mock.ExpectCommit()
+			}
+
+			// run
+			tt.setGetTxFunc(db)
+			r := repository.NewTaskActivityRepository(db)
+			err := r.Create(context.TODO(), tt.activity)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(3), tt.activity.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestFetchActivitiesByTaskID(t *testing.T) {
+	tests := []struct {
+		title          string
+		wantActivities []domain.TaskActivity
+		wantError      error
+	}{
+		{
+			"success",
+			[]domain.TaskActivity{
+				{
+					ID:        3,
+					TaskID:    1,
+					ActorID:   2,
+					Action:    domain.TaskActivityUpdated,
+					Changes:   map[string]domain.FieldChange{"title": {Before: "old", After: "new"}},
+					CreatedAt: time.Time{},
+				},
+			},
+			nil,
+		},
+		{
+			"query failed",
+			nil,
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			query := `SELECT * FROM "task_activities" WHERE task_id = $1 ORDER BY id`
+			switch tt.wantError {
+			case myerror.ErrQueryFailed:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("fetch activities error"))
+			default:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "actor_id", "action", "changes", "created_at"}).
+						AddRow(3, 1, 2, "updated", `{"title":{"before":"old","after":"new"}}`, time.Time{}))
+			}
+
+			// run
+			r := repository.NewTaskActivityRepository(db)
+			activities, err := r.FetchActivitiesByTaskID(context.TODO(), 1)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantActivities, activities)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type taskAuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskAuditLogRepository(db *gorm.DB) domain.TaskAuditLogRepository {
+	return &taskAuditLogRepository{
+		db: db,
+	}
+}
+
+// Create records the log, it must run in the transaction of the event handler.
+func (r *taskAuditLogRepository) Create(ctx context.Context, log *domain.TaskAuditLog) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(log).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
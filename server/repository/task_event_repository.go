@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaskEventChannel is the Postgres NOTIFY channel that carries new task event IDs.
+const TaskEventChannel = "task_events"
+
+const maxMissedEvents = 500
+
+type taskEventRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskEventRepository(db *gorm.DB) domain.TaskEventRepository {
+	return &taskEventRepository{
+		db: db,
+	}
+}
+
+// Create writes the event to the outbox, it must run in the transaction of the change.
+func (r *taskEventRepository) Create(ctx context.Context, event *domain.TaskEvent) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	if err := tx.Create(event).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *taskEventRepository) FetchEventByID(ctx context.Context, id int64) (*domain.TaskEvent, error) {
+	var event domain.TaskEvent
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrTaskEventNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &event, nil
+}
+
+func (r *taskEventRepository) FetchEventsAfterID(ctx context.Context, userID int, lastEventID int64) ([]domain.TaskEvent, error) {
+	var events []domain.TaskEvent
+	if err := r.db.WithContext(ctx).
+		Where("id > ?", lastEventID).
+		Where("recipients @> ?::jsonb", domain.IntList{userID}).
+		Order("id").
+		Limit(maxMissedEvents).
+		Find(&events).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return events, nil
+}
+
+// FetchLatestEventID returns 0 when the user has no events yet.
+func (r *taskEventRepository) FetchLatestEventID(ctx context.Context, userID int) (int64, error) {
+	var id int64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.TaskEvent{}).
+		Select("COALESCE(MAX(id), 0)").
+		Where("recipients @> ?::jsonb", domain.IntList{userID}).
+		Scan(&id).Error; err != nil {
+		return 0, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return id, nil
+}
+
+// FetchNextPendingEvent locks the oldest event neither relayed nor given up yet. Other
+// relays wait for the lock instead of skipping it, so the events are handled in order.
+func (r *taskEventRepository) FetchNextPendingEvent(ctx context.Context) (*domain.TaskEvent, error) {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return nil, myerror.ErrTransactionNotFound
+	}
+	var event domain.TaskEvent
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("dispatched_at IS NULL AND failed_at IS NULL").Order("id").Take(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrTaskEventNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &event, nil
+}
+
+func (r *taskEventRepository) MarkDispatched(ctx context.Context, eventID int64) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	var event domain.TaskEvent
+	if err := tx.Model(&event).Where("id = ?", eventID).Update("dispatched_at", time.Now()).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *taskEventRepository) MarkAttemptFailed(ctx context.Context, eventID int64, lastError string, failed bool) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	fields := map[string]any{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": lastError,
+	}
+	if failed {
+		fields["failed_at"] = time.Now()
+	}
+	var event domain.TaskEvent
+	if err := tx.Model(&event).Where("id = ?", eventID).Updates(fields).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+// MarkProcessed records that the handler processed the event and
+// returns false when it had already been recorded.
+func (r *taskEventRepository) MarkProcessed(ctx context.Context, handler string, eventID int64) (bool, error) {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return false, myerror.ErrTransactionNotFound
+	}
+	result := tx.Exec("INSERT INTO processed_events (handler, event_id) VALUES (?, ?) ON CONFLICT DO NOTHING", handler, eventID)
+	if result.Error != nil {
+		return false, myerror.ErrQueryFailed.Wrap(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Notify tells every server replica that the event is ready to be streamed.
+// In a transaction the notification is sent when it commits.
+func (r *taskEventRepository) Notify(ctx context.Context, eventID int64) error {
+	if err := conn(ctx, r.db).Exec("SELECT pg_notify(?, ?)", TaskEventChannel, strconv.FormatInt(eventID, 10)).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/tests/helper"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateTaskEvent(t *testing.T) {
+	tests := []struct {
+		title        string
+		event        *domain.TaskEvent
+		setGetTxFunc func(*gorm.DB)
+		wantError    error
+	}{
+		{
+			"success",
+			&domain.TaskEvent{Type: domain.TaskEventCreated, TaskID: 1, Recipients: domain.IntList{1, 2}},
+			func(tx *gorm.DB) {
+				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+					return tx, true
+				}
+			},
+			nil,
+		},
+		{
+			"transaction not found",
+			&domain.TaskEvent{Type: domain.TaskEventCreated, TaskID: 1, Recipients: domain.IntList{1, 2}},
+			func(tx *gorm.DB) {
+				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+					return nil, false
+				}
+			},
+			myerror.ErrTransactionNotFound,
+		},
+		{
+			"create task event failed",
+			&domain.TaskEvent{Type: domain.TaskEventCreated, TaskID: 1, Recipients: domain.IntList{1, 2}},
+			func(tx *gorm.DB) {
+				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+					return tx, true
+				}
+			},
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			insert := `INSERT INTO "task_events" ("type","task_id","payload","recipients","dispatched_at","created_at") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+			switch tt.wantError {
+			case myerror.ErrTransactionNotFound:
+			case myerror.ErrQueryFailed:
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(insert)).
+					WillReturnError(fmt.Errorf("create task event error"))
+				mock.ExpectRollback()
+			default:
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(insert)).
+					WithArgs(tt.event.Type, tt.event.TaskID, sqlmock.AnyArg(), `[1,2]`, nil, helper.AnyTime{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mock.ExpectCommit()
+			}
+
+			// run
+			tt.setGetTxFunc(db)
+			r := repository.NewTaskEventRepository(db)
+			err := r.Create(context.TODO(), tt.event)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(10), tt.event.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestFetchNextPendingEvent(t *testing.T) {
+	tests := []struct {
+		title     string
+		wantEvent *domain.TaskEvent
+		wantError error
+	}{
+		{
+			"success",
+			&domain.TaskEvent{ID: 4, Type: domain.TaskEventUpdated, TaskID: 1, Task: domain.Task{ID: 1, Title: "test"}, Recipients: domain.IntList{1}, CreatedAt: time.Time{}},
+			nil,
+		},
+		{
+			"no pending event",
+			nil,
+			myerror.ErrTaskEventNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			query := `SELECT * FROM "task_events" WHERE dispatched_at IS NULL AND failed_at IS NULL ORDER BY id LIMIT $1 FOR UPDATE`
+			switch tt.wantError {
+			case myerror.ErrTaskEventNotFound:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			default:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type", "task_id", "payload", "recipients", "dispatched_at", "created_at"}).
+						AddRow(4, "task.updated", 1, `{"id":1,"title":"test"}`, `[1]`, nil, time.Time{}))
+			}
+
+			// run
+			repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+				return db, true
+			}
+			r := repository.NewTaskEventRepository(db)
+			event, err := r.FetchNextPendingEvent(context.TODO())
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEvent, event)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestMarkProcessed(t *testing.T) {
+	tests := []struct {
+		title        string
+		rowsAffected int64
+		want         bool
+	}{
+		{"first time", 1, true},
+		{"already processed", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO processed_events (handler, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`)).
+				WithArgs("webhook", int64(4)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			// run
+			repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+				return db, true
+			}
+			r := repository.NewTaskEventRepository(db)
+			got, err := r.MarkProcessed(context.TODO(), "webhook", 4)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestMarkAttemptFailed(t *testing.T) {
+	tests := []struct {
+		title  string
+		failed bool
+		query  string
+		args   []driver.Value
+	}{
+		{
+			"retry later",
+			false,
+			`UPDATE "task_events" SET "attempts"=attempts + 1,"last_error"=$1 WHERE id = $2`,
+			[]driver.Value{"webhook: handler error", int64(4)},
+		},
+		{
+			"give up",
+			true,
+			`UPDATE "task_events" SET "attempts"=attempts + 1,"failed_at"=$1,"last_error"=$2 WHERE id = $3`,
+			[]driver.Value{sqlmock.AnyArg(), "webhook: handler error", int64(4)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(tt.query)).
+				WithArgs(tt.args...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			// run
+			repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+				return db, true
+			}
+			r := repository.NewTaskEventRepository(db)
+			err := r.MarkAttemptFailed(context.TODO(), 4, "webhook: handler error", tt.failed)
+
+			// assert
+			assert.NoError(t, err)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestFetchEventsAfterID(t *testing.T) {
+	tests := []struct {
+		title      string
+		wantEvents []domain.TaskEvent
+		wantError  error
+	}{
+		{
+			"success",
+			[]domain.TaskEvent{
+				{ID: 4, Type: domain.TaskEventUpdated, TaskID: 1, Task: domain.Task{ID: 1, Title: "test"}, Recipients: domain.IntList{1}, CreatedAt: time.Time{}},
+			},
+			nil,
+		},
+		{
+			"query failed",
+			nil,
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			query := `SELECT * FROM "task_events" WHERE id > $1 AND recipients @> $2::jsonb ORDER BY id LIMIT $3`
+			switch tt.wantError {
+			case myerror.ErrQueryFailed:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("fetch task events error"))
+			default:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(int64(3), `[1]`, 500).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "type", "task_id", "payload", "recipients", "created_at"}).
+						AddRow(4, "task.updated", 1, `{"id":1,"title":"test"}`, `[1]`, time.Time{}))
+			}
+
+			// run
+			r := repository.NewTaskEventRepository(db)
+			events, err := r.FetchEventsAfterID(context.TODO(), 1, 3)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEvents, events)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestFetchLatestEventID(t *testing.T) {
+	tests := []struct {
+		title     string
+		wantID    int64
+		wantError error
+	}{
+		{
+			"success",
+			12,
+			nil,
+		},
+		{
+			"query failed",
+			0,
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			query := `SELECT COALESCE(MAX(id), 0) FROM "task_events" WHERE recipients @> $1::jsonb`
+			switch tt.wantError {
+			case myerror.ErrQueryFailed:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(fmt.Errorf("fetch latest event id error"))
+			default:
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(`[1]`).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(12))
+			}
+
+			// run
+			r := repository.NewTaskEventRepository(db)
+			id, err := r.FetchLatestEventID(context.TODO(), 1)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantID, id)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
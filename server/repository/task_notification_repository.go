@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type taskNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskNotificationRepository(db *gorm.DB) domain.TaskNotificationRepository {
+	return &taskNotificationRepository{
+		db: db,
+	}
+}
+
+// MarkAssigneeNotified must run in the transaction of the event handler, so that the
+// record is rolled back when the notification fails.
+func (r *taskNotificationRepository) MarkAssigneeNotified(ctx context.Context, taskID, userID int) (bool, error) {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return false, myerror.ErrTransactionNotFound
+	}
+	result := tx.Exec("INSERT INTO task_assignee_notifications (task_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING", taskID, userID)
+	if result.Error != nil {
+		return false, myerror.ErrQueryFailed.Wrap(result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -24,7 +24,7 @@ func (r *taskPermissionRepository) GrantPermission(ctx context.Context, taskPerm
 	if !ok {
 		return myerror.ErrTransactionNotFound
 	}
-	if err := tx.Create(taskPermission).Error; err != nil {
+	if err := tx.Save(taskPermission).Error; err != nil {
 		return myerror.ErrGrantPermission.Wrap(err)
 	}
 	return nil
@@ -48,7 +48,7 @@ func (r *taskPermissionRepository) FetchTaskIDByUserID(ctx context.Context, user
 
 func (r *taskPermissionRepository) FetchPermissionByTaskID(ctx context.Context, taskID int, userID int) (*domain.TaskPermission, error) {
 	var taskPermission domain.TaskPermission
-	if err := r.db.WithContext(ctx).Where("task_id = ?", taskID).Where("user_id = ?", userID).Take(&taskPermission).Error; err != nil {
+	if err := conn(ctx, r.db).Where("task_id = ?", taskID).Where("user_id = ?", userID).Take(&taskPermission).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, myerror.ErrPermissionNotFound.Wrap(err)
 		}
@@ -56,3 +56,23 @@ func (r *taskPermissionRepository) FetchPermissionByTaskID(ctx context.Context,
 	}
 	return &taskPermission, nil
 }
+
+func (r *taskPermissionRepository) FetchUserIDByTaskID(ctx context.Context, taskID int) ([]int, error) {
+	var userIDs []int
+	var taskPermission domain.TaskPermission
+	if err := conn(ctx, r.db).Model(&taskPermission).Select("user_id").Where("task_id = ?", taskID).Find(&userIDs).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return userIDs, nil
+}
+
+func (r *taskPermissionRepository) DeleteByTaskID(ctx context.Context, taskIDs ...int) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	if err := tx.Where("task_id IN ?", taskIDs).Delete(&domain.TaskPermission{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
@@ -334,3 +334,79 @@ func TestFetchPermissionByTaskID(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchUserIDByTaskID(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		taskID int
+	}
+
+	tests := []struct {
+		title       string
+		args        args
+		query       string
+		wantUserIDs []int
+		wantError   error
+	}{
+		{
+			"success",
+			args{
+				ctx:    context.TODO(),
+				taskID: 1,
+			},
+			`SELECT "user_id" FROM "task_permissions" WHERE task_id = $1`,
+			[]int{1, 2},
+			nil,
+		},
+		{
+			"failed to fetch user id",
+			args{
+				ctx:    context.TODO(),
+				taskID: 1,
+			},
+			`SELECT "user_id" FROM "task_permissions" WHERE task_id = $1`,
+			nil,
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			switch tt.wantError {
+			case myerror.ErrQueryFailed:
+				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+					WithArgs(tt.args.taskID).
+					WillReturnError(fmt.Errorf("fetch user id failed"))
+			default:
+				rows := sqlmock.NewRows([]string{"user_id"})
+				for _, id := range tt.wantUserIDs {
+					rows.AddRow(id)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+					WithArgs(tt.args.taskID).
+					WillReturnRows(rows)
+			}
+
+			// run
+			r := repository.NewTaskPermissionRepository(db)
+			userIDs, err := r.FetchUserIDByTaskID(tt.args.ctx, tt.args.taskID)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantUserIDs, userIDs)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -3,14 +3,33 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/keitatwr/task-management-app/domain"
 	"github.com/keitatwr/task-management-app/internal/myerror"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var GetTxFunc = GetTx
 
+// headlineOptions wraps the matched words of the search snippets in <mark>.
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+
+// trackedSecondsColumn sums the time entries of the task, a running timer counts up to now.
+const trackedSecondsColumn = "(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(stopped_at, NOW()) - started_at)), 0)::BIGINT " +
+	"FROM time_entries WHERE time_entries.task_id = tasks.id) AS tracked_seconds"
+
+// taskOrders are the ORDER BY clauses of the sorts of the task list.
+var taskOrders = map[domain.TaskSort]string{
+	domain.TaskSortPriority: "CASE priority WHEN 'urgent' THEN 4 WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END DESC, " +
+		"due_date, id",
+	domain.TaskSortDueDate:   "due_date, id",
+	domain.TaskSortCreatedAt: "created_at DESC, id DESC",
+}
+
 type taskRepository struct {
 	db *gorm.DB
 }
@@ -32,9 +51,51 @@ func (r *taskRepository) Create(ctx context.Context, task *domain.Task) (int, er
 	return task.ID, nil
 }
 
-func (r *taskRepository) FetchAllTaskByTaskID(ctx context.Context, taskIDs ...int) ([]domain.Task, error) {
+func (r *taskRepository) FetchAllTaskByTaskID(ctx context.Context, userID int, filter domain.TaskFilter, taskIDs ...int) ([]domain.Task, error) {
 	var tasks []domain.Task
-	if err := r.db.WithContext(ctx).Where("id IN ?", taskIDs).Find(&tasks).Error; err != nil {
+	query := r.db.WithContext(ctx).Select("tasks.*, "+trackedSecondsColumn).Where("id IN ?", taskIDs)
+	if filter.Archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+	if filter.Completed != nil {
+		query = query.Where("completed = ?", *filter.Completed)
+	}
+	if filter.Overdue {
+		query = query.Where("completed = ? AND due_date < ?", false, filter.Today)
+	}
+	if filter.DueWithinDays != nil {
+		query = query.Where("due_date BETWEEN ? AND ?", filter.Today, filter.Today.AddDays(*filter.DueWithinDays))
+	}
+	switch filter.Owner {
+	case domain.TaskOwnerMe:
+		query = query.Where("created_by = ?", userID)
+	case domain.TaskOwnerOthers:
+		query = query.Where("created_by <> ?", userID)
+	}
+	switch filter.Assignee {
+	case domain.TaskAssigneeMe:
+		query = query.Where("assignees @> ?::jsonb", domain.IntList{userID})
+	case domain.TaskAssigneeNone:
+		query = query.Where("assignees = '[]'::jsonb")
+	}
+	if filter.Label != "" {
+		query = query.Where("labels @> ?::jsonb", domain.StringList{filter.Label})
+	}
+	if len(filter.Priority) > 0 {
+		query = query.Where("priority IN ?", filter.Priority)
+	}
+	for _, match := range filter.FieldMatches {
+		query = query.Where("custom_fields @> ?::jsonb", match)
+	}
+	if filter.SortField != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL: "custom_fields -> ? NULLS LAST, id", Vars: []any{filter.SortField}}})
+	} else if order, ok := taskOrders[filter.Sort]; ok {
+		query = query.Order(order)
+	}
+	if err := query.Find(&tasks).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, myerror.ErrTaskNotFound.Wrap(err)
 		}
@@ -45,7 +106,7 @@ func (r *taskRepository) FetchAllTaskByTaskID(ctx context.Context, taskIDs ...in
 
 func (r *taskRepository) FetchTaskByTaskID(ctx context.Context, taskID int) (*domain.Task, error) {
 	var task domain.Task
-	if err := r.db.WithContext(ctx).Where("id = ?", taskID).Take(&task).Error; err != nil {
+	if err := conn(ctx, r.db).Select("tasks.*, "+trackedSecondsColumn).Where("id = ?", taskID).Take(&task).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, myerror.ErrTaskNotFound.Wrap(err)
 		}
@@ -56,14 +117,141 @@ func (r *taskRepository) FetchTaskByTaskID(ctx context.Context, taskID int) (*do
 
 func (r *taskRepository) Update(ctx context.Context, taskID int, updateFields map[string]any) error {
 	var task domain.Task
-	if err := r.db.WithContext(ctx).Model(&task).Where("id = ?", taskID).Select("title", "description", "due_date").Updates(updateFields).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&task).Where("id = ?", taskID).Select("title", "description", "due_date", "priority", "estimate_minutes", "assignees", "labels", "custom_fields", "completed", "completed_at", "archived_at").Updates(updateFields).Error; err != nil {
 		return myerror.ErrQueryFailed.Wrap(err)
 	}
 	return nil
 }
 
 func (r *taskRepository) Delete(ctx context.Context, taskID int) error {
-	if err := r.db.WithContext(ctx).Where("id = ?", taskID).Delete(&domain.Task{}).Error; err != nil {
+	if err := conn(ctx, r.db).Where("id = ?", taskID).Delete(&domain.Task{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *taskRepository) FetchDeletedTasksByTaskID(ctx context.Context, taskIDs ...int) ([]domain.Task, error) {
+	var tasks []domain.Task
+	if err := r.db.WithContext(ctx).Unscoped().Where("id IN ?", taskIDs).Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").Find(&tasks).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) Restore(ctx context.Context, taskID int) error {
+	var task domain.Task
+	result := conn(ctx, r.db).Unscoped().Model(&task).Where("id = ?", taskID).Where("deleted_at IS NOT NULL").
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return myerror.ErrQueryFailed.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return myerror.ErrTaskNotFound
+	}
+	return nil
+}
+
+func (r *taskRepository) FetchTaskIDsDeletedBefore(ctx context.Context, before time.Time, limit int) ([]int, error) {
+	var taskIDs []int
+	var task domain.Task
+	if err := conn(ctx, r.db).Unscoped().Model(&task).Select("id").Where("deleted_at < ?", before).
+		Order("id").Limit(limit).Find(&taskIDs).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return taskIDs, nil
+}
+
+// Purge removes the tasks permanently, it must run in a transaction
+// together with the removal of the rows depending on them.
+func (r *taskRepository) Purge(ctx context.Context, taskIDs ...int) error {
+	tx, ok := GetTxFunc(ctx)
+	if !ok {
+		return myerror.ErrTransactionNotFound
+	}
+	if err := tx.Unscoped().Where("id IN ?", taskIDs).Delete(&domain.Task{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+// FetchTaskIDsToAutoArchive returns the tasks created by the user which were completed
+// before completedBefore and are not archived yet.
+func (r *taskRepository) FetchTaskIDsToAutoArchive(ctx context.Context, userID int, completedBefore time.Time) ([]int, error) {
+	var taskIDs []int
+	var task domain.Task
+	if err := r.db.WithContext(ctx).Model(&task).Select("id").Where("created_by = ?", userID).
+		Where("completed = ?", true).Where("completed_at < ?", completedBefore).Where("archived_at IS NULL").
+		Order("id").Find(&taskIDs).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return taskIDs, nil
+}
+
+// Search finds the tasks among taskIDs matching the query, best match first.
+// Every word of the query is matched as a prefix of the words in the title or
+// the description. Japanese text has no spaces between words, so a substring
+// match is used as well, backed by the trigram index.
+func (r *taskRepository) Search(ctx context.Context, query string, limit int, taskIDs ...int) ([]domain.TaskSearchResult, error) {
+	var results []domain.TaskSearchResult
+	var task domain.Task
+	tsQuery := prefixTSQuery(query)
+	pattern := "%" + escapeLike(query) + "%"
+	if err := r.db.WithContext(ctx).Model(&task).
+		Select("tasks.*, ts_rank(search_vector, to_tsquery('simple', ?)) AS rank, "+
+			"ts_headline('simple', title, to_tsquery('simple', ?), ?) AS title_highlight, "+
+			"ts_headline('simple', description, to_tsquery('simple', ?), ?) AS description_highlight",
+			tsQuery, tsQuery, headlineOptions+", HighlightAll=true", tsQuery, headlineOptions).
+		Where("id IN ?", taskIDs).
+		Where("search_vector @@ to_tsquery('simple', ?) OR title ILIKE ? OR description ILIKE ?", tsQuery, pattern, pattern).
+		Order("rank DESC, id DESC").Limit(limit).Find(&results).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return results, nil
+}
+
+// IndexSearchVector weights the title over the description, the 'simple' configuration
+// does not stem so that English and Japanese words are indexed as they are.
+func (r *taskRepository) IndexSearchVector(ctx context.Context, taskID int) error {
+	if err := conn(ctx, r.db).Exec("UPDATE tasks SET search_vector = "+
+		"setweight(to_tsvector('simple', coalesce(title, '')), 'A') || "+
+		"setweight(to_tsvector('simple', coalesce(description, '')), 'B') "+
+		"WHERE id = ?", taskID).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+// prefixTSQuery builds a tsquery matching all the words of the query as prefixes,
+// e.g. "buy milk" becomes "buy:* & milk:*". Characters other than letters and
+// digits are dropped so that the user input cannot break the tsquery syntax.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = strings.ToLower(w) + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// FetchAllTaskInBatches passes the tasks to fn batch by batch in the order of the id,
+// including the archived tasks. The error returned by fn stops the fetch and is returned as it is.
+func (r *taskRepository) FetchAllTaskInBatches(ctx context.Context, batchSize int, fn func([]domain.Task) error, taskIDs ...int) error {
+	var tasks []domain.Task
+	var fnErr error
+	if err := r.db.WithContext(ctx).Where("id IN ?", taskIDs).
+		FindInBatches(&tasks, batchSize, func(tx *gorm.DB, batch int) error {
+			fnErr = fn(tasks)
+			return fnErr
+		}).Error; err != nil {
+		if fnErr != nil {
+			return fnErr
+		}
 		return myerror.ErrQueryFailed.Wrap(err)
 	}
 	return nil
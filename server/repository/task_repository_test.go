@@ -19,6 +19,10 @@ import (
 
 var AnyDate domain.DateOnly
 
+// selectTasks selects the tasks with the time tracked on them.
+const selectTasks = `SELECT tasks.*, (SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(stopped_at, NOW()) - started_at)), 0)::BIGINT ` +
+	`FROM time_entries WHERE time_entries.task_id = tasks.id) AS tracked_seconds FROM "tasks"`
+
 func TestCreateTask(t *testing.T) {
 	type args struct {
 		ctx  context.Context
@@ -42,9 +46,10 @@ func TestCreateTask(t *testing.T) {
 					Completed:   false,
 					CreatedBy:   1,
 					DueDate:     AnyDate,
+					Priority:    domain.TaskPriorityNone,
 				},
 			},
-			`INSERT INTO "tasks" ("title","description","completed","created_by","due_date","created_at") VALUES ($1,$2,$3,$4,$5,$6)`,
+			`INSERT INTO "tasks" ("title","description","completed","created_by","parent_id","due_date","priority","estimate_minutes","assignees","labels","custom_fields","completed_at","archived_at","created_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`,
 			func(tx *gorm.DB) {
 				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
 					return tx, true
@@ -62,9 +67,10 @@ func TestCreateTask(t *testing.T) {
 					Completed:   false,
 					CreatedBy:   1,
 					DueDate:     AnyDate,
+					Priority:    domain.TaskPriorityNone,
 				},
 			},
-			`INSERT INTO "tasks" ("title","description","completed","created_by","due_date","created_at") VALUES ($1,$2,$3,$4,$5,$6)`,
+			`INSERT INTO "tasks" ("title","description","completed","created_by","parent_id","due_date","priority","estimate_minutes","assignees","labels","custom_fields","completed_at","archived_at","created_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`,
 			func(tx *gorm.DB) {
 				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
 					return tx, true
@@ -82,9 +88,10 @@ func TestCreateTask(t *testing.T) {
 					Completed:   false,
 					CreatedBy:   1,
 					DueDate:     AnyDate,
+					Priority:    domain.TaskPriorityNone,
 				},
 			},
-			`INSERT INTO "tasks" ("title","description","completed","created_by","due_date","created_at") VALUES ($1,$2,$3,$4,$5,$6)`,
+			`INSERT INTO "tasks" ("title","description","completed","created_by","parent_id","due_date","priority","estimate_minutes","assignees","labels","custom_fields","completed_at","archived_at","created_at","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`,
 			func(tx *gorm.DB) {
 				repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
 					return nil, false
@@ -107,7 +114,7 @@ func TestCreateTask(t *testing.T) {
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
 					WithArgs(tt.args.task.Title, tt.args.task.Description, tt.args.task.Completed,
-						tt.args.task.CreatedBy, tt.args.task.DueDate, helper.AnyTime{}).
+						tt.args.task.CreatedBy, nil, tt.args.task.DueDate, tt.args.task.Priority, nil, "[]", "[]", "{}", nil, nil, helper.AnyTime{}, nil).
 					WillReturnError(tt.wantError)
 				mock.ExpectRollback()
 			default:
@@ -115,7 +122,7 @@ func TestCreateTask(t *testing.T) {
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
 					WithArgs(tt.args.task.Title, tt.args.task.Description, tt.args.task.Completed,
-						tt.args.task.CreatedBy, tt.args.task.DueDate, helper.AnyTime{}).
+						tt.args.task.CreatedBy, nil, tt.args.task.DueDate, tt.args.task.Priority, nil, "[]", "[]", "{}", nil, nil, helper.AnyTime{}, nil).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mock.ExpectCommit()
 			}
@@ -145,6 +152,7 @@ func TestFetchAllTaskByTaskID(t *testing.T) {
 	// now := time.Now()
 	type args struct {
 		ctx     context.Context
+		filter  domain.TaskFilter
 		taskIDs []int
 	}
 
@@ -162,7 +170,7 @@ func TestFetchAllTaskByTaskID(t *testing.T) {
 				ctx:     context.TODO(),
 				taskIDs: []int{1, 2},
 			},
-			`SELECT * FROM "tasks" WHERE id IN ($1,$2)`,
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND "tasks"."deleted_at" IS NULL`,
 			[][]driver.Value{
 				[]driver.Value{1, "test", "test", false, 1, AnyDate, time.Time{}},
 				[]driver.Value{2, "test", "test", false, 1, AnyDate, time.Time{}},
@@ -173,13 +181,29 @@ func TestFetchAllTaskByTaskID(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"archived tasks",
+			args{
+				ctx:     context.TODO(),
+				filter:  domain.TaskFilter{Archived: true},
+				taskIDs: []int{1, 2},
+			},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NOT NULL AND "tasks"."deleted_at" IS NULL`,
+			[][]driver.Value{
+				[]driver.Value{1, "test", "test", true, 1, AnyDate, time.Time{}},
+			},
+			[]domain.Task{
+				{ID: 1, Title: "test", Description: "test", Completed: true, CreatedBy: 1, DueDate: AnyDate, CreatedAt: time.Time{}},
+			},
+			nil,
+		},
 		{
 			"tasks not found",
 			args{
 				ctx:     context.TODO(),
 				taskIDs: []int{1, 2},
 			},
-			`SELECT * FROM "tasks" WHERE id IN ($1,$2)`,
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND "tasks"."deleted_at" IS NULL`,
 			nil,
 			nil,
 			myerror.ErrTaskNotFound,
@@ -190,7 +214,7 @@ func TestFetchAllTaskByTaskID(t *testing.T) {
 				ctx:     context.TODO(),
 				taskIDs: []int{1, 2},
 			},
-			`SELECT * FROM "tasks" WHERE id IN ($1,$2)`,
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND "tasks"."deleted_at" IS NULL`,
 			nil,
 			nil,
 			myerror.ErrQueryFailed,
@@ -226,7 +250,7 @@ func TestFetchAllTaskByTaskID(t *testing.T) {
 
 			// run
 			r := repository.NewTaskRepository(db)
-			tasks, err := r.FetchAllTaskByTaskID(tt.args.ctx, tt.args.taskIDs...)
+			tasks, err := r.FetchAllTaskByTaskID(tt.args.ctx, 1, tt.args.filter, tt.args.taskIDs...)
 
 			// assert
 			if tt.wantError != nil {
@@ -265,7 +289,7 @@ func TestFetchTaskByTaskID(t *testing.T) {
 				ctx:    context.TODO(),
 				taskID: 1,
 			},
-			`SELECT * FROM "tasks" WHERE id = $1 LIMIT $2`,
+			selectTasks + ` WHERE id = $1 AND "tasks"."deleted_at" IS NULL LIMIT $2`,
 			[]driver.Value{1, "test", "test", false, 1, AnyDate, time.Time{}},
 			&domain.Task{ID: 1, Title: "test", Description: "test", Completed: false, CreatedBy: 1, DueDate: AnyDate, CreatedAt: time.Time{}},
 			nil,
@@ -276,7 +300,7 @@ func TestFetchTaskByTaskID(t *testing.T) {
 				ctx:    context.TODO(),
 				taskID: 1,
 			},
-			`SELECT * FROM "tasks" WHERE id = $1 LIMIT $2`,
+			selectTasks + ` WHERE id = $1 AND "tasks"."deleted_at" IS NULL LIMIT $2`,
 			nil,
 			nil,
 			myerror.ErrTaskNotFound,
@@ -287,7 +311,7 @@ func TestFetchTaskByTaskID(t *testing.T) {
 				ctx:    context.TODO(),
 				taskID: 1,
 			},
-			`SELECT * FROM "tasks" WHERE id = $1 LIMIT $2`,
+			selectTasks + ` WHERE id = $1 AND "tasks"."deleted_at" IS NULL LIMIT $2`,
 			nil,
 			nil,
 			myerror.ErrQueryFailed,
@@ -445,7 +469,7 @@ func TestDeleteTask(t *testing.T) {
 				ctx:    context.TODO(),
 				taskID: 1,
 			},
-			`DELETE FROM "tasks" WHERE id = $1`,
+			`UPDATE "tasks" SET "deleted_at"=$1 WHERE id = $2 AND "tasks"."deleted_at" IS NULL`,
 			nil,
 		},
 		{
@@ -454,7 +478,7 @@ func TestDeleteTask(t *testing.T) {
 				ctx:    context.TODO(),
 				taskID: 1,
 			},
-			`DELETE FROM "tasks" WHERE id = $1`,
+			`UPDATE "tasks" SET "deleted_at"=$1 WHERE id = $2 AND "tasks"."deleted_at" IS NULL`,
 			myerror.ErrQueryFailed,
 		},
 	}
@@ -470,14 +494,14 @@ func TestDeleteTask(t *testing.T) {
 				mock.MatchExpectationsInOrder(false)
 				mock.ExpectBegin()
 				mock.ExpectExec(regexp.QuoteMeta(tt.query)).
-					WithArgs(tt.args.taskID).
+					WithArgs(helper.AnyTime{}, tt.args.taskID).
 					WillReturnError(fmt.Errorf("delete task error"))
 				mock.ExpectRollback()
 			default:
 				mock.MatchExpectationsInOrder(false)
 				mock.ExpectBegin()
 				mock.ExpectExec(regexp.QuoteMeta(tt.query)).
-					WithArgs(tt.args.taskID).
+					WithArgs(helper.AnyTime{}, tt.args.taskID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			}
@@ -500,3 +524,223 @@ func TestDeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreTask(t *testing.T) {
+	tests := []struct {
+		title        string
+		rowsAffected int64
+		wantError    error
+	}{
+		{"success", 1, nil},
+		{"task is not in the trash", 0, myerror.ErrTaskNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tasks" SET "deleted_at"=$1 WHERE id = $2 AND deleted_at IS NOT NULL`)).
+				WithArgs(nil, 1).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			mock.ExpectCommit()
+
+			// run
+			r := repository.NewTaskRepository(db)
+			err := r.Restore(context.TODO(), 1)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestPurgeTask(t *testing.T) {
+	// mock
+	db, mock, tearDown := helper.GetDBMock(t)
+	defer tearDown()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "tasks" WHERE id IN ($1,$2)`)).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	// run
+	repository.GetTxFunc = func(ctx context.Context) (*gorm.DB, bool) {
+		return db, true
+	}
+	r := repository.NewTaskRepository(db)
+	err := r.Purge(context.TODO(), 1, 2)
+
+	// assert
+	assert.NoError(t, err)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestFetchTaskIDsToAutoArchive(t *testing.T) {
+	// mock
+	db, mock, tearDown := helper.GetDBMock(t)
+	defer tearDown()
+
+	completedBefore := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "tasks" WHERE created_by = $1 AND completed = $2 AND completed_at < $3 AND archived_at IS NULL AND "tasks"."deleted_at" IS NULL ORDER BY id`)).
+		WithArgs(1, true, completedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(3))
+
+	// run
+	r := repository.NewTaskRepository(db)
+	taskIDs, err := r.FetchTaskIDsToAutoArchive(context.TODO(), 1, completedBefore)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, taskIDs)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSearchTask(t *testing.T) {
+	const query = `SELECT tasks.*, ts_rank(search_vector, to_tsquery('simple', $1)) AS rank, ts_headline('simple', title, to_tsquery('simple', $2), $3) AS title_highlight, ts_headline('simple', description, to_tsquery('simple', $4), $5) AS description_highlight FROM "tasks" WHERE id IN ($6,$7) AND (search_vector @@ to_tsquery('simple', $8) OR title ILIKE $9 OR description ILIKE $10) AND "tasks"."deleted_at" IS NULL ORDER BY rank DESC, id DESC LIMIT $11`
+
+	tests := []struct {
+		title       string
+		query       string
+		wantTSQuery string
+		wantPattern string
+	}{
+		{"english words are matched as prefixes", "Buy mil", "buy:* & mil:*", "%Buy mil%"},
+		{"japanese text", "買い物", "買い物:*", "%買い物%"},
+		{"tsquery and like syntax is dropped", "50% & (milk)", "50:* & milk:*", `%50\% & (milk)%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			mock.ExpectQuery(regexp.QuoteMeta(query)).
+				WithArgs(tt.wantTSQuery, tt.wantTSQuery, sqlmock.AnyArg(), tt.wantTSQuery, sqlmock.AnyArg(),
+					1, 2, tt.wantTSQuery, tt.wantPattern, tt.wantPattern, 20).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "title", "rank", "title_highlight", "description_highlight"}).
+					AddRow(1, "test", 0.5, "<mark>test</mark>", ""))
+
+			// run
+			r := repository.NewTaskRepository(db)
+			results, err := r.Search(context.TODO(), tt.query, 20, 1, 2)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, []domain.TaskSearchResult{
+				{Task: domain.Task{ID: 1, Title: "test"}, Rank: 0.5, TitleHighlight: "<mark>test</mark>"},
+			}, results)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestFetchAllTaskByTaskIDWithFilter(t *testing.T) {
+	completed, week := false, 7
+	today := domain.MustDateOnly("2024-12-02")
+
+	tests := []struct {
+		title    string
+		filter   domain.TaskFilter
+		query    string
+		wantArgs []driver.Value
+	}{
+		{
+			"completed and owned by the user",
+			domain.TaskFilter{Completed: &completed, Owner: domain.TaskOwnerMe},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND completed = $3 AND created_by = $4 AND "tasks"."deleted_at" IS NULL`,
+			[]driver.Value{1, 2, false, 1},
+		},
+		{
+			"overdue and shared with the user",
+			domain.TaskFilter{Overdue: true, Owner: domain.TaskOwnerOthers, Today: today},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND (completed = $3 AND due_date < $4) AND created_by <> $5 AND "tasks"."deleted_at" IS NULL`,
+			[]driver.Value{1, 2, false, "2024-12-02", 1},
+		},
+		{
+			"due within days",
+			domain.TaskFilter{DueWithinDays: &week, Today: today},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND (due_date BETWEEN $3 AND $4) AND "tasks"."deleted_at" IS NULL`,
+			[]driver.Value{1, 2, "2024-12-02", "2024-12-09"},
+		},
+		{
+			"priorities sorted by priority",
+			domain.TaskFilter{Priority: []domain.TaskPriority{domain.TaskPriorityHigh, domain.TaskPriorityUrgent}, Sort: domain.TaskSortPriority},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND priority IN ($3,$4) AND "tasks"."deleted_at" IS NULL ` +
+				`ORDER BY CASE priority WHEN 'urgent' THEN 4 WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END DESC, due_date, id`,
+			[]driver.Value{1, 2, domain.TaskPriorityHigh, domain.TaskPriorityUrgent},
+		},
+		{
+			"assigned to the user",
+			domain.TaskFilter{Assignee: domain.TaskAssigneeMe},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND assignees @> $3::jsonb AND "tasks"."deleted_at" IS NULL`,
+			[]driver.Value{1, 2, "[1]"},
+		},
+		{
+			"assigned to nobody",
+			domain.TaskFilter{Assignee: domain.TaskAssigneeNone},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND assignees = '[]'::jsonb AND "tasks"."deleted_at" IS NULL`,
+			[]driver.Value{1, 2},
+		},
+		{
+			"having the label",
+			domain.TaskFilter{Label: "backend"},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND labels @> $3::jsonb AND "tasks"."deleted_at" IS NULL`,
+			[]driver.Value{1, 2, `["backend"]`},
+		},
+		{
+			"custom field value sorted by another custom field",
+			domain.TaskFilter{
+				FieldMatches: []domain.CustomFieldValues{{"customer": "acme"}},
+				SortField:    "points",
+				Sort:         domain.TaskSortDueDate,
+			},
+			selectTasks + ` WHERE id IN ($1,$2) AND archived_at IS NULL AND custom_fields @> $3::jsonb AND "tasks"."deleted_at" IS NULL ` +
+				`ORDER BY custom_fields -> $4 NULLS LAST, id`,
+			[]driver.Value{1, 2, `{"customer":"acme"}`, "points"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+			// run
+			r := repository.NewTaskRepository(db)
+			tasks, err := r.FetchAllTaskByTaskID(context.TODO(), 1, tt.filter, 1, 2)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, []domain.Task{{ID: 1}}, tasks)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
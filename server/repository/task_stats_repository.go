@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type taskStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskStatsRepository(db *gorm.DB) domain.TaskStatsRepository {
+	return &taskStatsRepository{
+		db: db,
+	}
+}
+
+// statsTasks selects the tasks listed by GET /tasks, the permission is the same as
+// the one of the task list.
+func (r *taskStatsRepository) statsTasks(ctx context.Context, userID int, owner domain.TaskOwner) *gorm.DB {
+	query := r.db.WithContext(ctx).Table("tasks t").
+		Joins("JOIN task_permissions p ON p.task_id = t.id").
+		Where("p.user_id = ? AND p.can_read", userID).
+		Where("t.deleted_at IS NULL AND t.archived_at IS NULL")
+	switch owner {
+	case domain.TaskOwnerMe:
+		query = query.Where("t.created_by = ?", userID)
+	case domain.TaskOwnerOthers:
+		query = query.Where("t.created_by <> ?", userID)
+	}
+	return query
+}
+
+func (r *taskStatsRepository) FetchCounts(ctx context.Context, userID int, owner domain.TaskOwner, today, weekEnd domain.DateOnly) (*domain.TaskStatsCounts, error) {
+	var counts domain.TaskStatsCounts
+	if err := r.statsTasks(ctx, userID, owner).Select(
+		"COUNT(*) FILTER (WHERE NOT t.completed) AS open, "+
+			"COUNT(*) FILTER (WHERE t.completed) AS completed, "+
+			"COUNT(*) FILTER (WHERE NOT t.completed AND t.due_date < ?) AS overdue, "+
+			"COUNT(*) FILTER (WHERE NOT t.completed AND t.due_date BETWEEN ? AND ?) AS due_this_week, "+
+			"AVG(EXTRACT(EPOCH FROM t.completed_at - t.created_at)) FILTER (WHERE t.completed) AS average_completion_seconds",
+		today, today, weekEnd).
+		Scan(&counts).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &counts, nil
+}
+
+func (r *taskStatsRepository) FetchCompletionTrend(ctx context.Context, userID int, owner domain.TaskOwner, from domain.DateOnly, loc *time.Location) ([]domain.TaskStatsDay, error) {
+	var days []domain.TaskStatsDay
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	if err := r.statsTasks(ctx, userID, owner).
+		Select("DATE(t.completed_at AT TIME ZONE ?) AS date, COUNT(*) AS completed", loc.String()).
+		Where("t.completed AND t.completed_at >= ?", start).
+		Group("1").Order("1").
+		Scan(&days).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return days, nil
+}
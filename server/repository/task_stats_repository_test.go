@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/tests/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchStatsCounts(t *testing.T) {
+	const counts = `SELECT COUNT(*) FILTER (WHERE NOT t.completed) AS open, COUNT(*) FILTER (WHERE t.completed) AS completed, ` +
+		`COUNT(*) FILTER (WHERE NOT t.completed AND t.due_date < $1) AS overdue, ` +
+		`COUNT(*) FILTER (WHERE NOT t.completed AND t.due_date BETWEEN $2 AND $3) AS due_this_week, ` +
+		`AVG(EXTRACT(EPOCH FROM t.completed_at - t.created_at)) FILTER (WHERE t.completed) AS average_completion_seconds ` +
+		`FROM tasks t JOIN task_permissions p ON p.task_id = t.id ` +
+		`WHERE (p.user_id = $4 AND p.can_read) AND (t.deleted_at IS NULL AND t.archived_at IS NULL)`
+	today, weekEnd := domain.MustDateOnly("2024-06-12"), domain.MustDateOnly("2024-06-16")
+
+	tests := []struct {
+		title      string
+		owner      domain.TaskOwner
+		query      string
+		args       []driver.Value
+		wantCounts *domain.TaskStatsCounts
+	}{
+		{
+			"all tasks",
+			"",
+			counts,
+			[]driver.Value{"2024-06-12", "2024-06-12", "2024-06-16", 1},
+			&domain.TaskStatsCounts{Open: 2, Completed: 1, Overdue: 1},
+		},
+		{
+			// the tasks shared read-only and the tasks assigned to the user are counted,
+			// the permission only has to grant read
+			"read-only task shared by another user",
+			domain.TaskOwnerOthers,
+			counts + ` AND t.created_by <> $5`,
+			[]driver.Value{"2024-06-12", "2024-06-12", "2024-06-16", 1, 1},
+			&domain.TaskStatsCounts{Open: 1, DueThisWeek: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			db, mock, tearDown := helper.GetDBMock(t)
+			defer tearDown()
+
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"open", "completed", "overdue", "due_this_week", "average_completion_seconds"}).
+					AddRow(tt.wantCounts.Open, tt.wantCounts.Completed, tt.wantCounts.Overdue, tt.wantCounts.DueThisWeek, nil))
+
+			// run
+			r := repository.NewTaskStatsRepository(db)
+			got, err := r.FetchCounts(context.TODO(), 1, tt.owner, today, weekEnd)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCounts, got)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type taskTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskTemplateRepository(db *gorm.DB) domain.TaskTemplateRepository {
+	return &taskTemplateRepository{
+		db: db,
+	}
+}
+
+func (r *taskTemplateRepository) Create(ctx context.Context, template *domain.TaskTemplate) error {
+	if err := r.db.WithContext(ctx).Create(template).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *taskTemplateRepository) FetchTemplateByID(ctx context.Context, templateID int) (*domain.TaskTemplate, error) {
+	var template domain.TaskTemplate
+	if err := conn(ctx, r.db).Where("id = ?", templateID).Take(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrTaskTemplateNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &template, nil
+}
+
+func (r *taskTemplateRepository) FetchTemplatesByUserID(ctx context.Context, userID int) ([]domain.TaskTemplate, error) {
+	var templates []domain.TaskTemplate
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&templates).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return templates, nil
+}
+
+func (r *taskTemplateRepository) Delete(ctx context.Context, templateID int) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", templateID).Delete(&domain.TaskTemplate{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
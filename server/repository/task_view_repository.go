@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type taskViewRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskViewRepository(db *gorm.DB) domain.TaskViewRepository {
+	return &taskViewRepository{
+		db: db,
+	}
+}
+
+func (r *taskViewRepository) Create(ctx context.Context, view *domain.TaskView) error {
+	if err := r.db.WithContext(ctx).Create(view).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *taskViewRepository) FetchViewByID(ctx context.Context, viewID int) (*domain.TaskView, error) {
+	var view domain.TaskView
+	if err := r.db.WithContext(ctx).Where("id = ?", viewID).Take(&view).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrTaskViewNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &view, nil
+}
+
+func (r *taskViewRepository) FetchViewsByUserID(ctx context.Context, userID int) ([]domain.TaskView, error) {
+	var views []domain.TaskView
+	if err := r.db.WithContext(ctx).Where("user_id = ? OR shared", userID).Order("id").Find(&views).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return views, nil
+}
+
+func (r *taskViewRepository) SetShared(ctx context.Context, viewID int, shared bool) error {
+	if err := r.db.WithContext(ctx).Model(&domain.TaskView{}).Where("id = ?", viewID).
+		Update("shared", shared).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *taskViewRepository) Delete(ctx context.Context, viewID int) error {
+	if err := r.db.WithContext(ctx).Where("id = ?", viewID).Delete(&domain.TaskView{}).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
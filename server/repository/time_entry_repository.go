@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+// pgUniqueViolation is the SQLSTATE of a unique constraint violation.
+const pgUniqueViolation = "23505"
+
+type timeEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepository(db *gorm.DB) domain.TimeEntryRepository {
+	return &timeEntryRepository{
+		db: db,
+	}
+}
+
+// Create fails with ErrTimerAlreadyRunning when the user has a running timer, the
+// unique index on the running entries keeps concurrent starts from running two timers.
+func (r *timeEntryRepository) Create(ctx context.Context, entry *domain.TimeEntry) error {
+	if err := conn(ctx, r.db).Create(entry).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return myerror.ErrTimerAlreadyRunning.Wrap(err)
+		}
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *timeEntryRepository) FetchRunningEntryByUserID(ctx context.Context, userID int) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+	if err := conn(ctx, r.db).Where("user_id = ? AND stopped_at IS NULL", userID).Take(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrTimeEntryNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &entry, nil
+}
+
+func (r *timeEntryRepository) Stop(ctx context.Context, entryID int, stoppedAt time.Time) error {
+	result := conn(ctx, r.db).Model(&domain.TimeEntry{}).Where("id = ? AND stopped_at IS NULL", entryID).
+		Update("stopped_at", stoppedAt)
+	if result.Error != nil {
+		return myerror.ErrQueryFailed.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return myerror.ErrTimeEntryNotFound.Wrap(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+func (r *timeEntryRepository) FetchEntriesByTaskID(ctx context.Context, taskID int) ([]domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+	if err := r.db.WithContext(ctx).Where("task_id = ?", taskID).Order("started_at DESC, id DESC").
+		Find(&entries).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return entries, nil
+}
+
+func (r *timeEntryRepository) FetchEntriesByUserID(ctx context.Context, userID int, from, to time.Time) ([]domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+	if err := r.db.WithContext(ctx).Select("time_entries.*, tasks.title AS task_title").
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+		Where("time_entries.user_id = ?", userID).
+		Where("time_entries.started_at < ? AND (time_entries.stopped_at IS NULL OR time_entries.stopped_at > ?)", to, from).
+		Order("time_entries.started_at, time_entries.id").Find(&entries).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return entries, nil
+}
@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/internal/myerror"
 	"github.com/keitatwr/task-management-app/transaction"
 	"gorm.io/gorm"
 )
 
 var txKey = struct{}{}
 
+type savepointKey struct{}
+
 type tx struct {
 	db *gorm.DB
 }
@@ -19,6 +23,10 @@ func NewTransaction(db *gorm.DB) transaction.Transaction {
 }
 
 func (t *tx) DoInTx(ctx context.Context, f func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if tx, ok := GetTx(ctx); ok {
+		return doInSavepoint(ctx, tx, f)
+	}
+
 	tx := t.db.WithContext(ctx).Begin()
 
 	ctx = context.WithValue(ctx, &txKey, tx)
@@ -41,7 +49,44 @@ func (t *tx) DoInTx(ctx context.Context, f func(ctx context.Context) (interface{
 	return v, nil
 }
 
+// doInSavepoint runs a nested DoInTx in a savepoint of the outer transaction,
+// so that it can fail and roll back without aborting the outer transaction.
+func doInSavepoint(ctx context.Context, tx *gorm.DB, f func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	depth, _ := ctx.Value(savepointKey{}).(int)
+	depth++
+	ctx = context.WithValue(ctx, savepointKey{}, depth)
+	name := fmt.Sprintf("sp%d", depth)
+
+	if err := tx.SavePoint(name).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+
+	v, err := f(ctx)
+
+	if err != nil {
+		if err := tx.RollbackTo(name).Error; err != nil {
+			logger.W(ctx, "failed to rollback to savepoint", err)
+		}
+		logger.I(ctx, "rollback to savepoint", "name", name)
+		return nil, err
+	}
+
+	if err := tx.Exec("RELEASE SAVEPOINT " + name).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return v, nil
+}
+
 func GetTx(ctx context.Context) (*gorm.DB, bool) {
 	tx, ok := ctx.Value(&txKey).(*gorm.DB)
 	return tx, ok
 }
+
+// conn returns the transaction started by DoInTx when ctx carries one,
+// so that the queries inside DoInTx see the changes made before them.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := GetTx(ctx); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
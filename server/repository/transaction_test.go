@@ -0,0 +1,48 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/tests/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNestedDoInTx(t *testing.T) {
+	// mock
+	db, mock, tearDown := helper.GetDBMock(t)
+	defer tearDown()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// run
+	transaction := repository.NewTransaction(db)
+	var innerErr error
+	_, err := transaction.DoInTx(context.TODO(), func(ctx context.Context) (interface{}, error) {
+		if _, err := transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		}); err != nil {
+			return nil, err
+		}
+		// the failure of a nested transaction only rolls back its savepoint
+		_, innerErr = transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+			return nil, fmt.Errorf("inner error")
+		})
+		return nil, nil
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.EqualError(t, innerErr, "inner error")
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type userSettingRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSettingRepository(db *gorm.DB) domain.UserSettingRepository {
+	return &userSettingRepository{
+		db: db,
+	}
+}
+
+// FetchSettingByUserID returns the default setting when the user has not saved one yet.
+func (r *userSettingRepository) FetchSettingByUserID(ctx context.Context, userID int) (*domain.UserSetting, error) {
+	var setting domain.UserSetting
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Take(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.NewUserSetting(userID), nil
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &setting, nil
+}
+
+func (r *userSettingRepository) Save(ctx context.Context, setting *domain.UserSetting) error {
+	if err := r.db.WithContext(ctx).Save(setting).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *userSettingRepository) FetchAutoArchiveSettings(ctx context.Context) ([]domain.UserSetting, error) {
+	var settings []domain.UserSetting
+	if err := r.db.WithContext(ctx).Where("auto_archive_days > ?", 0).Order("user_id").Find(&settings).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+const maxDeliveryLog = 100
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) domain.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	if err := conn(ctx, r.db).Create(delivery).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *webhookDeliveryRepository) FetchDeliveryByID(ctx context.Context, deliveryID int64) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	if err := r.db.WithContext(ctx).Where("id = ?", deliveryID).Take(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrWebhookDeliveryNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) FetchDeliveriesByWebhookID(ctx context.Context, webhookID int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	if err := r.db.WithContext(ctx).Where("webhook_id = ?", webhookID).
+		Order("id DESC").Limit(maxDeliveryLog).Find(&deliveries).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries pushes next_attempt_at of the due deliveries forward by lease,
+// so that other replicas skip them while they are sent and they are retried
+// if this server stops before recording the result.
+func (r *webhookDeliveryRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	now := time.Now()
+	query := `UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at, id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`
+	if err := r.db.WithContext(ctx).Raw(query, now.Add(lease), domain.WebhookDeliveryPending, now, limit).
+		Scan(&deliveries).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, deliveryID int64, updateFields map[string]any) error {
+	var delivery domain.WebhookDelivery
+	if err := r.db.WithContext(ctx).Model(&delivery).Where("id = ?", deliveryID).Updates(updateFields).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"gorm.io/gorm"
+)
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) domain.WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	if err := r.db.WithContext(ctx).Create(webhook).Error; err != nil {
+		return myerror.ErrQueryFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (r *webhookRepository) FetchWebhookByID(ctx context.Context, webhookID int) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := r.db.WithContext(ctx).Where("id = ?", webhookID).Take(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, myerror.ErrWebhookNotFound.Wrap(err)
+		}
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) FetchWebhooksByUserID(ctx context.Context, userID int) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) FetchSubscribedWebhooks(ctx context.Context, eventType domain.TaskEventType, userIDs ...int) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	if err := r.db.WithContext(ctx).
+		Where("user_id IN ?", userIDs).
+		Where("active = ?", true).
+		Where("(event_types @> ?::jsonb OR event_types @> ?::jsonb)",
+			domain.StringList{string(eventType)}, domain.StringList{domain.WebhookEventAll}).
+		Find(&webhooks).Error; err != nil {
+		return nil, myerror.ErrQueryFailed.Wrap(err)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, webhookID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhookID).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return myerror.ErrQueryFailed.Wrap(err)
+		}
+		if err := tx.Where("id = ?", webhookID).Delete(&domain.Webhook{}).Error; err != nil {
+			return myerror.ErrQueryFailed.Wrap(err)
+		}
+		return nil
+	})
+}
@@ -3,10 +3,12 @@ package helper
 import (
 	"encoding/json"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,6 +16,8 @@ func AssertResponse(t *testing.T, wantCode int, wantRes any, w *httptest.Respons
 
 	if wantCode >= 400 || wantCode >= 500 {
 
+		assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
 		var actualRes domain.ErrorResponse
 		err := json.Unmarshal(w.Body.Bytes(), &actualRes)
 		assert.NoError(t, err)
@@ -35,3 +39,16 @@ func AssertResponse(t *testing.T, wantCode int, wantRes any, w *httptest.Respons
 		}
 	}
 }
+
+// Problem returns the problem details rendered for the error code, the instance is empty
+// since the tests do not run the logging middleware.
+func Problem(status int, code myerror.ErrorCode, detail string, fields ...domain.FieldError) domain.ErrorResponse {
+	return domain.ErrorResponse{
+		Type:   "/api/v1/errors/" + strconv.Itoa(int(code)),
+		Title:  myerror.ErrMessages[code],
+		Status: status,
+		Detail: detail,
+		Code:   int(code),
+		Errors: fields,
+	}
+}
@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/keitatwr/task-management-app/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -71,9 +72,9 @@ func (mr *MockTaskRepositoryMockRecorder) Delete(ctx, taskID any) *gomock.Call {
 }
 
 // FetchAllTaskByTaskID mocks base method.
-func (m *MockTaskRepository) FetchAllTaskByTaskID(ctx context.Context, taskIDs ...int) ([]domain.Task, error) {
+func (m *MockTaskRepository) FetchAllTaskByTaskID(ctx context.Context, userID int, filter domain.TaskFilter, taskIDs ...int) ([]domain.Task, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{ctx}
+	varargs := []any{ctx, userID, filter}
 	for _, a := range taskIDs {
 		varargs = append(varargs, a)
 	}
@@ -84,12 +85,51 @@ func (m *MockTaskRepository) FetchAllTaskByTaskID(ctx context.Context, taskIDs .
 }
 
 // FetchAllTaskByTaskID indicates an expected call of FetchAllTaskByTaskID.
-func (mr *MockTaskRepositoryMockRecorder) FetchAllTaskByTaskID(ctx any, taskIDs ...any) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) FetchAllTaskByTaskID(ctx, userID, filter any, taskIDs ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{ctx}, taskIDs...)
+	varargs := append([]any{ctx, userID, filter}, taskIDs...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllTaskByTaskID", reflect.TypeOf((*MockTaskRepository)(nil).FetchAllTaskByTaskID), varargs...)
 }
 
+// FetchAllTaskInBatches mocks base method.
+func (m *MockTaskRepository) FetchAllTaskInBatches(ctx context.Context, batchSize int, fn func([]domain.Task) error, taskIDs ...int) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, batchSize, fn}
+	for _, a := range taskIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchAllTaskInBatches", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchAllTaskInBatches indicates an expected call of FetchAllTaskInBatches.
+func (mr *MockTaskRepositoryMockRecorder) FetchAllTaskInBatches(ctx, batchSize, fn any, taskIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, batchSize, fn}, taskIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllTaskInBatches", reflect.TypeOf((*MockTaskRepository)(nil).FetchAllTaskInBatches), varargs...)
+}
+
+// FetchDeletedTasksByTaskID mocks base method.
+func (m *MockTaskRepository) FetchDeletedTasksByTaskID(ctx context.Context, taskIDs ...int) ([]domain.Task, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range taskIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchDeletedTasksByTaskID", varargs...)
+	ret0, _ := ret[0].([]domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDeletedTasksByTaskID indicates an expected call of FetchDeletedTasksByTaskID.
+func (mr *MockTaskRepositoryMockRecorder) FetchDeletedTasksByTaskID(ctx any, taskIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, taskIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeletedTasksByTaskID", reflect.TypeOf((*MockTaskRepository)(nil).FetchDeletedTasksByTaskID), varargs...)
+}
+
 // FetchTaskByTaskID mocks base method.
 func (m *MockTaskRepository) FetchTaskByTaskID(ctx context.Context, taskID int) (*domain.Task, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +145,103 @@ func (mr *MockTaskRepositoryMockRecorder) FetchTaskByTaskID(ctx, taskID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaskByTaskID", reflect.TypeOf((*MockTaskRepository)(nil).FetchTaskByTaskID), ctx, taskID)
 }
 
+// FetchTaskIDsDeletedBefore mocks base method.
+func (m *MockTaskRepository) FetchTaskIDsDeletedBefore(ctx context.Context, before time.Time, limit int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTaskIDsDeletedBefore", ctx, before, limit)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTaskIDsDeletedBefore indicates an expected call of FetchTaskIDsDeletedBefore.
+func (mr *MockTaskRepositoryMockRecorder) FetchTaskIDsDeletedBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaskIDsDeletedBefore", reflect.TypeOf((*MockTaskRepository)(nil).FetchTaskIDsDeletedBefore), ctx, before, limit)
+}
+
+// FetchTaskIDsToAutoArchive mocks base method.
+func (m *MockTaskRepository) FetchTaskIDsToAutoArchive(ctx context.Context, userID int, completedBefore time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTaskIDsToAutoArchive", ctx, userID, completedBefore)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTaskIDsToAutoArchive indicates an expected call of FetchTaskIDsToAutoArchive.
+func (mr *MockTaskRepositoryMockRecorder) FetchTaskIDsToAutoArchive(ctx, userID, completedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaskIDsToAutoArchive", reflect.TypeOf((*MockTaskRepository)(nil).FetchTaskIDsToAutoArchive), ctx, userID, completedBefore)
+}
+
+// IndexSearchVector mocks base method.
+func (m *MockTaskRepository) IndexSearchVector(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexSearchVector", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexSearchVector indicates an expected call of IndexSearchVector.
+func (mr *MockTaskRepositoryMockRecorder) IndexSearchVector(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSearchVector", reflect.TypeOf((*MockTaskRepository)(nil).IndexSearchVector), ctx, taskID)
+}
+
+// Purge mocks base method.
+func (m *MockTaskRepository) Purge(ctx context.Context, taskIDs ...int) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range taskIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Purge", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTaskRepositoryMockRecorder) Purge(ctx any, taskIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, taskIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTaskRepository)(nil).Purge), varargs...)
+}
+
+// Restore mocks base method.
+func (m *MockTaskRepository) Restore(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTaskRepositoryMockRecorder) Restore(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTaskRepository)(nil).Restore), ctx, taskID)
+}
+
+// Search mocks base method.
+func (m *MockTaskRepository) Search(ctx context.Context, query string, limit int, taskIDs ...int) ([]domain.TaskSearchResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, query, limit}
+	for _, a := range taskIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Search", varargs...)
+	ret0, _ := ret[0].([]domain.TaskSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTaskRepositoryMockRecorder) Search(ctx, query, limit any, taskIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, query, limit}, taskIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTaskRepository)(nil).Search), varargs...)
+}
+
 // Update mocks base method.
 func (m *MockTaskRepository) Update(ctx context.Context, taskID int, updateFields map[string]any) error {
 	m.ctrl.T.Helper()
@@ -143,18 +280,106 @@ func (m *MockTaskUsecase) EXPECT() *MockTaskUsecaseMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockTaskUsecase) Create(ctx context.Context, title, description string, userID int, due_date domain.DateOnly) error {
+// AddSubtask mocks base method.
+func (m *MockTaskUsecase) AddSubtask(ctx context.Context, parentID, userID int, title, description string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSubtask", ctx, parentID, userID, title, description)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSubtask indicates an expected call of AddSubtask.
+func (mr *MockTaskUsecaseMockRecorder) AddSubtask(ctx, parentID, userID, title, description any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubtask", reflect.TypeOf((*MockTaskUsecase)(nil).AddSubtask), ctx, parentID, userID, title, description)
+}
+
+// Archive mocks base method.
+func (m *MockTaskUsecase) Archive(ctx context.Context, taskID, userID int, archived bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", ctx, taskID, userID, archived)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockTaskUsecaseMockRecorder) Archive(ctx, taskID, userID, archived any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockTaskUsecase)(nil).Archive), ctx, taskID, userID, archived)
+}
+
+// Assign mocks base method.
+func (m *MockTaskUsecase) Assign(ctx context.Context, taskID, userID int, assigneeIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, taskID, userID, assigneeIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockTaskUsecaseMockRecorder) Assign(ctx, taskID, userID, assigneeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockTaskUsecase)(nil).Assign), ctx, taskID, userID, assigneeIDs)
+}
+
+// AutoArchive mocks base method.
+func (m *MockTaskUsecase) AutoArchive(ctx context.Context, userID int, completedFor time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoArchive", ctx, userID, completedFor)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoArchive indicates an expected call of AutoArchive.
+func (mr *MockTaskUsecaseMockRecorder) AutoArchive(ctx, userID, completedFor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoArchive", reflect.TypeOf((*MockTaskUsecase)(nil).AutoArchive), ctx, userID, completedFor)
+}
+
+// Bulk mocks base method.
+func (m *MockTaskUsecase) Bulk(ctx context.Context, userID int, taskIDs []int, filter *domain.TaskFilter, op domain.TaskBulkOperation, allOrNothing bool) ([]domain.TaskBulkResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, title, description, userID, due_date)
+	ret := m.ctrl.Call(m, "Bulk", ctx, userID, taskIDs, filter, op, allOrNothing)
+	ret0, _ := ret[0].([]domain.TaskBulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockTaskUsecaseMockRecorder) Bulk(ctx, userID, taskIDs, filter, op, allOrNothing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTaskUsecase)(nil).Bulk), ctx, userID, taskIDs, filter, op, allOrNothing)
+}
+
+// Complete mocks base method.
+func (m *MockTaskUsecase) Complete(ctx context.Context, taskID, userID int, completed bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, taskID, userID, completed)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// Complete indicates an expected call of Complete.
+func (mr *MockTaskUsecaseMockRecorder) Complete(ctx, taskID, userID, completed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockTaskUsecase)(nil).Complete), ctx, taskID, userID, completed)
+}
+
+// Create mocks base method.
+func (m *MockTaskUsecase) Create(ctx context.Context, title, description string, userID int, due_date domain.DateOnly, priority domain.TaskPriority, estimateMinutes *int, customFields domain.CustomFieldValues) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, title, description, userID, due_date, priority, estimateMinutes, customFields)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Create indicates an expected call of Create.
-func (mr *MockTaskUsecaseMockRecorder) Create(ctx, title, description, userID, due_date any) *gomock.Call {
+func (mr *MockTaskUsecaseMockRecorder) Create(ctx, title, description, userID, due_date, priority, estimateMinutes, customFields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskUsecase)(nil).Create), ctx, title, description, userID, due_date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskUsecase)(nil).Create), ctx, title, description, userID, due_date, priority, estimateMinutes, customFields)
 }
 
 // Delete mocks base method.
@@ -171,19 +396,48 @@ func (mr *MockTaskUsecaseMockRecorder) Delete(ctx, taskID, userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskUsecase)(nil).Delete), ctx, taskID, userID)
 }
 
+// Export mocks base method.
+func (m *MockTaskUsecase) Export(ctx context.Context, userID int, fn func([]domain.Task) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockTaskUsecaseMockRecorder) Export(ctx, userID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockTaskUsecase)(nil).Export), ctx, userID, fn)
+}
+
+// FetchActivitiesByTaskID mocks base method.
+func (m *MockTaskUsecase) FetchActivitiesByTaskID(ctx context.Context, taskID, userID int) ([]domain.TaskActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchActivitiesByTaskID", ctx, taskID, userID)
+	ret0, _ := ret[0].([]domain.TaskActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchActivitiesByTaskID indicates an expected call of FetchActivitiesByTaskID.
+func (mr *MockTaskUsecaseMockRecorder) FetchActivitiesByTaskID(ctx, taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchActivitiesByTaskID", reflect.TypeOf((*MockTaskUsecase)(nil).FetchActivitiesByTaskID), ctx, taskID, userID)
+}
+
 // FetchAllTaskByUserID mocks base method.
-func (m *MockTaskUsecase) FetchAllTaskByUserID(ctx context.Context, userID int) ([]domain.Task, error) {
+func (m *MockTaskUsecase) FetchAllTaskByUserID(ctx context.Context, userID int, filter domain.TaskFilter) ([]domain.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FetchAllTaskByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "FetchAllTaskByUserID", ctx, userID, filter)
 	ret0, _ := ret[0].([]domain.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchAllTaskByUserID indicates an expected call of FetchAllTaskByUserID.
-func (mr *MockTaskUsecaseMockRecorder) FetchAllTaskByUserID(ctx, userID any) *gomock.Call {
+func (mr *MockTaskUsecaseMockRecorder) FetchAllTaskByUserID(ctx, userID, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllTaskByUserID", reflect.TypeOf((*MockTaskUsecase)(nil).FetchAllTaskByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllTaskByUserID", reflect.TypeOf((*MockTaskUsecase)(nil).FetchAllTaskByUserID), ctx, userID, filter)
 }
 
 // FetchTaskByTaskID mocks base method.
@@ -201,16 +455,118 @@ func (mr *MockTaskUsecaseMockRecorder) FetchTaskByTaskID(ctx, taskID, userID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaskByTaskID", reflect.TypeOf((*MockTaskUsecase)(nil).FetchTaskByTaskID), ctx, taskID, userID)
 }
 
+// FetchTrashByUserID mocks base method.
+func (m *MockTaskUsecase) FetchTrashByUserID(ctx context.Context, userID int) ([]domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTrashByUserID", ctx, userID)
+	ret0, _ := ret[0].([]domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchTrashByUserID indicates an expected call of FetchTrashByUserID.
+func (mr *MockTaskUsecaseMockRecorder) FetchTrashByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTrashByUserID", reflect.TypeOf((*MockTaskUsecase)(nil).FetchTrashByUserID), ctx, userID)
+}
+
+// Import mocks base method.
+func (m *MockTaskUsecase) Import(ctx context.Context, userID int, records []domain.TaskImportRecord, dryRun bool) ([]domain.TaskImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, userID, records, dryRun)
+	ret0, _ := ret[0].([]domain.TaskImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockTaskUsecaseMockRecorder) Import(ctx, userID, records, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockTaskUsecase)(nil).Import), ctx, userID, records, dryRun)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockTaskUsecase) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, retention)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockTaskUsecaseMockRecorder) PurgeDeleted(ctx, retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockTaskUsecase)(nil).PurgeDeleted), ctx, retention)
+}
+
+// Restore mocks base method.
+func (m *MockTaskUsecase) Restore(ctx context.Context, taskID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTaskUsecaseMockRecorder) Restore(ctx, taskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTaskUsecase)(nil).Restore), ctx, taskID, userID)
+}
+
+// Search mocks base method.
+func (m *MockTaskUsecase) Search(ctx context.Context, userID int, query string, limit int) ([]domain.TaskSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, userID, query, limit)
+	ret0, _ := ret[0].([]domain.TaskSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTaskUsecaseMockRecorder) Search(ctx, userID, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTaskUsecase)(nil).Search), ctx, userID, query, limit)
+}
+
+// SetLabels mocks base method.
+func (m *MockTaskUsecase) SetLabels(ctx context.Context, taskID, userID int, labels []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLabels", ctx, taskID, userID, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLabels indicates an expected call of SetLabels.
+func (mr *MockTaskUsecaseMockRecorder) SetLabels(ctx, taskID, userID, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabels", reflect.TypeOf((*MockTaskUsecase)(nil).SetLabels), ctx, taskID, userID, labels)
+}
+
+// Share mocks base method.
+func (m *MockTaskUsecase) Share(ctx context.Context, taskID, userID, targetUserID int, canEdit bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Share", ctx, taskID, userID, targetUserID, canEdit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Share indicates an expected call of Share.
+func (mr *MockTaskUsecaseMockRecorder) Share(ctx, taskID, userID, targetUserID, canEdit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockTaskUsecase)(nil).Share), ctx, taskID, userID, targetUserID, canEdit)
+}
+
 // Update mocks base method.
-func (m *MockTaskUsecase) Update(ctx context.Context, taskID, userID int, title, description string, due_date domain.DateOnly) error {
+func (m *MockTaskUsecase) Update(ctx context.Context, taskID, userID int, title, description string, due_date domain.DateOnly, priority domain.TaskPriority, estimateMinutes *int, customFields domain.CustomFieldValues) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, taskID, userID, title, description, due_date)
+	ret := m.ctrl.Call(m, "Update", ctx, taskID, userID, title, description, due_date, priority, estimateMinutes, customFields)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTaskUsecaseMockRecorder) Update(ctx, taskID, userID, title, description, due_date any) *gomock.Call {
+func (mr *MockTaskUsecaseMockRecorder) Update(ctx, taskID, userID, title, description, due_date, priority, estimateMinutes, customFields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskUsecase)(nil).Update), ctx, taskID, userID, title, description, due_date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskUsecase)(nil).Update), ctx, taskID, userID, title, description, due_date, priority, estimateMinutes, customFields)
 }
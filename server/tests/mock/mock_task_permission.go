@@ -41,6 +41,25 @@ func (m *MockTaskPermissionRepository) EXPECT() *MockTaskPermissionRepositoryMoc
 	return m.recorder
 }
 
+// DeleteByTaskID mocks base method.
+func (m *MockTaskPermissionRepository) DeleteByTaskID(ctx context.Context, taskIDs ...int) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range taskIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteByTaskID", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTaskID indicates an expected call of DeleteByTaskID.
+func (mr *MockTaskPermissionRepositoryMockRecorder) DeleteByTaskID(ctx any, taskIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, taskIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTaskID", reflect.TypeOf((*MockTaskPermissionRepository)(nil).DeleteByTaskID), varargs...)
+}
+
 // FetchPermissionByTaskID mocks base method.
 func (m *MockTaskPermissionRepository) FetchPermissionByTaskID(ctx context.Context, taskID, userID int) (*domain.TaskPermission, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +90,21 @@ func (mr *MockTaskPermissionRepositoryMockRecorder) FetchTaskIDByUserID(ctx, id,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTaskIDByUserID", reflect.TypeOf((*MockTaskPermissionRepository)(nil).FetchTaskIDByUserID), ctx, id, canEdit, canRead)
 }
 
+// FetchUserIDByTaskID mocks base method.
+func (m *MockTaskPermissionRepository) FetchUserIDByTaskID(ctx context.Context, taskID int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchUserIDByTaskID", ctx, taskID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchUserIDByTaskID indicates an expected call of FetchUserIDByTaskID.
+func (mr *MockTaskPermissionRepositoryMockRecorder) FetchUserIDByTaskID(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUserIDByTaskID", reflect.TypeOf((*MockTaskPermissionRepository)(nil).FetchUserIDByTaskID), ctx, taskID)
+}
+
 // GrantPermission mocks base method.
 func (m *MockTaskPermissionRepository) GrantPermission(ctx context.Context, taskPermission *domain.TaskPermission) error {
 	m.ctrl.T.Helper()
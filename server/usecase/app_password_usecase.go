@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/security"
+)
+
+type appPasswordUsecase struct {
+	appPasswordRepository domain.AppPasswordRepository
+	userRepository        domain.UserRepository
+}
+
+func NewAppPasswordUsecase(appPasswordRepo domain.AppPasswordRepository, userRepo domain.UserRepository) domain.AppPasswordUsecase {
+	return &appPasswordUsecase{
+		appPasswordRepository: appPasswordRepo,
+		userRepository:        userRepo,
+	}
+}
+
+func (u *appPasswordUsecase) Create(ctx context.Context, userID int, name string) (*domain.AppPassword, error) {
+	password, err := security.GenerateToken()
+	if err != nil {
+		return nil, myerror.ErrUnExpected.WrapWithDescription(err, "failed to generate app password")
+	}
+
+	appPassword := &domain.AppPassword{
+		UserID:       userID,
+		Name:         name,
+		Password:     password,
+		PasswordHash: security.HashToken(password),
+	}
+	if err := u.appPasswordRepository.Create(ctx, appPassword); err != nil {
+		return nil, err
+	}
+	return appPassword, nil
+}
+
+func (u *appPasswordUsecase) FetchAppPasswordsByUserID(ctx context.Context, userID int) ([]domain.AppPassword, error) {
+	return u.appPasswordRepository.FetchAppPasswordsByUserID(ctx, userID)
+}
+
+func (u *appPasswordUsecase) Delete(ctx context.Context, appPasswordID, userID int) error {
+	return u.appPasswordRepository.Delete(ctx, appPasswordID, userID)
+}
+
+// Authenticate returns ErrInvalidPassword for both an unknown email and a wrong
+// password, so that the response does not tell which emails are registered.
+func (u *appPasswordUsecase) Authenticate(ctx context.Context, email, password string) (*domain.User, error) {
+	user, err := u.userRepository.FetchUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, myerror.ErrUserNotFound) {
+			return nil, myerror.ErrInvalidPassword
+		}
+		return nil, err
+	}
+
+	if _, err := u.appPasswordRepository.FetchAppPasswordByHash(ctx, user.ID, security.HashToken(password)); err != nil {
+		if errors.Is(err, myerror.ErrAppPasswordNotFound) {
+			return nil, myerror.ErrInvalidPassword
+		}
+		return nil, err
+	}
+	user.Password = ""
+	return user, nil
+}
@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/security"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		title                    string
+		setupMockUserRepo        func(*mock.MockUserRepository)
+		setupMockAppPasswordRepo func(*mock.MockAppPasswordRepository)
+		wantUser                 *domain.User
+		wantError                error
+	}{
+		{
+			"success",
+			func(mockUserRepo *mock.MockUserRepository) {
+				mockUserRepo.EXPECT().FetchUserByEmail(context.TODO(), "test@example.com").
+					Return(&domain.User{ID: 1, Email: "test@example.com", Password: "hashed"}, nil)
+			},
+			func(mockAppPasswordRepo *mock.MockAppPasswordRepository) {
+				mockAppPasswordRepo.EXPECT().FetchAppPasswordByHash(context.TODO(), 1, security.HashToken("secret")).
+					Return(&domain.AppPassword{ID: 3, UserID: 1}, nil)
+			},
+			&domain.User{ID: 1, Email: "test@example.com"},
+			nil,
+		},
+		{
+			"unknown email",
+			func(mockUserRepo *mock.MockUserRepository) {
+				mockUserRepo.EXPECT().FetchUserByEmail(context.TODO(), "test@example.com").
+					Return(nil, myerror.ErrUserNotFound)
+			},
+			nil,
+			nil,
+			myerror.ErrInvalidPassword,
+		},
+		{
+			"wrong password",
+			func(mockUserRepo *mock.MockUserRepository) {
+				mockUserRepo.EXPECT().FetchUserByEmail(context.TODO(), "test@example.com").
+					Return(&domain.User{ID: 1, Email: "test@example.com"}, nil)
+			},
+			func(mockAppPasswordRepo *mock.MockAppPasswordRepository) {
+				mockAppPasswordRepo.EXPECT().FetchAppPasswordByHash(context.TODO(), 1, security.HashToken("secret")).
+					Return(nil, myerror.ErrAppPasswordNotFound)
+			},
+			nil,
+			myerror.ErrInvalidPassword,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUserRepo := mock.NewMockUserRepository(ctrl)
+			mockAppPasswordRepo := mock.NewMockAppPasswordRepository(ctrl)
+
+			if tt.setupMockUserRepo != nil {
+				tt.setupMockUserRepo(mockUserRepo)
+			}
+			if tt.setupMockAppPasswordRepo != nil {
+				tt.setupMockAppPasswordRepo(mockAppPasswordRepo)
+			}
+
+			// run
+			uc := usecase.NewAppPasswordUsecase(mockAppPasswordRepo, mockUserRepo)
+			user, err := uc.Authenticate(context.TODO(), "test@example.com", "secret")
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantUser, user)
+			}
+		})
+	}
+}
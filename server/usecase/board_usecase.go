@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/rank"
+	"github.com/keitatwr/task-management-app/transaction"
+)
+
+type boardUsecase struct {
+	boardRepository domain.BoardRepository
+	taskUsecase     domain.TaskUsecase
+	transaction     transaction.Transaction
+}
+
+func NewBoardUsecase(boardRepo domain.BoardRepository, taskUsecase domain.TaskUsecase,
+	transaction transaction.Transaction) domain.BoardUsecase {
+	return &boardUsecase{
+		boardRepository: boardRepo,
+		taskUsecase:     taskUsecase,
+		transaction:     transaction,
+	}
+}
+
+func (u *boardUsecase) Create(ctx context.Context, userID int, name string, columns []domain.BoardColumn) (*domain.Board, error) {
+	columns, err := validateBoardColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+	board := &domain.Board{
+		UserID:  userID,
+		Name:    name,
+		Columns: columns,
+	}
+	if err := u.boardRepository.Create(ctx, board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+func (u *boardUsecase) FetchBoardsByUserID(ctx context.Context, userID int) ([]domain.Board, error) {
+	return u.boardRepository.FetchBoardsByUserID(ctx, userID)
+}
+
+// FetchBoardByID returns the board with the cards of each column.
+func (u *boardUsecase) FetchBoardByID(ctx context.Context, boardID, userID int) (*domain.Board, error) {
+	board, err := u.fetchOwnedBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := u.taskUsecase.FetchAllTaskByUserID(ctx, userID, domain.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	ranks, err := u.fetchRanks(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for i, cards := range layoutBoard(board.Columns, tasks, ranks) {
+		board.Columns[i].Tasks = cards
+	}
+	return board, nil
+}
+
+// Update replaces the name and the columns, the ranks of the cards are kept.
+func (u *boardUsecase) Update(ctx context.Context, boardID, userID int, name string, columns []domain.BoardColumn) (*domain.Board, error) {
+	board, err := u.fetchOwnedBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	columns, err = validateBoardColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+	board.Name = name
+	board.Columns = columns
+	if err := u.boardRepository.Update(ctx, board); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+func (u *boardUsecase) Delete(ctx context.Context, boardID, userID int) error {
+	if _, err := u.fetchOwnedBoard(ctx, boardID, userID); err != nil {
+		return err
+	}
+	return u.boardRepository.Delete(ctx, boardID)
+}
+
+// Move moves the task to the position in the column in one transaction. The task
+// takes the status or the label of the column and loses the labels of the other
+// columns, then it is ranked between its new neighbours. The moves on a board are
+// serialized by locking the board, so that concurrent moves do not compute their
+// ranks from the same neighbours.
+func (u *boardUsecase) Move(ctx context.Context, boardID, userID, taskID, column, position int) (*domain.BoardCard, error) {
+	board, err := u.fetchOwnedBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if column >= len(board.Columns) {
+		return nil, myerror.ErrValidation.WithDescription(fmt.Sprintf("the board has %d columns", len(board.Columns)))
+	}
+	target := board.Columns[column]
+
+	moved, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := u.boardRepository.Lock(ctx, boardID); err != nil {
+			return nil, err
+		}
+		task, err := u.taskUsecase.FetchTaskByTaskID(ctx, taskID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if task.ArchivedAt != nil {
+			return nil, myerror.ErrTaskArchived
+		}
+
+		labels := []string{}
+		for _, label := range task.Labels {
+			if (target.Kind == domain.BoardColumnLabel && label == target.Value) || !isBoardLabel(board.Columns, label) {
+				labels = append(labels, label)
+			}
+		}
+		if target.Kind == domain.BoardColumnLabel && !slices.Contains(labels, target.Value) {
+			labels = append(labels, target.Value)
+		}
+		if !slices.Equal(labels, task.Labels) {
+			if err := u.taskUsecase.SetLabels(ctx, taskID, userID, labels); err != nil {
+				return nil, err
+			}
+		}
+		if target.Kind == domain.BoardColumnStatus {
+			completed := target.Value == domain.BoardStatusCompleted
+			if task.Completed != completed {
+				if err := u.taskUsecase.Complete(ctx, taskID, userID, completed); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return u.rankCard(ctx, board, taskID, userID, column, position)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved.(*domain.BoardCard), nil
+}
+
+// rankCard gives the task a rank between the cards at position-1 and position of
+// the column, ctx must carry the transaction holding the lock of the board.
+func (u *boardUsecase) rankCard(ctx context.Context, board *domain.Board, taskID, userID, column, position int) (*domain.BoardCard, error) {
+	tasks, err := u.taskUsecase.FetchAllTaskByUserID(ctx, userID, domain.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	ranks, err := u.fetchRanks(ctx, board.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := []domain.Task{}
+	for _, task := range layoutBoard(board.Columns, tasks, ranks)[column] {
+		if task.ID != taskID {
+			cards = append(cards, task)
+		}
+	}
+	if position > len(cards) {
+		position = len(cards)
+	}
+
+	// the cards without a rank come last, they are ranked after the last ranked card
+	var changed []domain.BoardCard
+	var unranked []int
+	last := ""
+	for _, card := range cards {
+		if r, ok := ranks[card.ID]; ok {
+			last = r
+		} else {
+			unranked = append(unranked, card.ID)
+		}
+	}
+	if len(unranked) > 0 {
+		changed, err = u.assignRanks(board.ID, last, unranked, ranks)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := rankAt(cards, ranks, position)
+	if errors.Is(err, rank.ErrInvalidRange) {
+		// the neighbours share a rank, e.g. after a task moved in from another
+		// column by a status change, so the column is ranked again
+		ids := make([]int, len(cards))
+		for i, card := range cards {
+			ids[i] = card.ID
+		}
+		changed, err = u.assignRanks(board.ID, "", ids, ranks)
+		if err != nil {
+			return nil, err
+		}
+		r, err = rankAt(cards, ranks, position)
+	}
+	if err != nil {
+		return nil, myerror.ErrUnExpected.Wrap(err)
+	}
+
+	card := domain.BoardCard{BoardID: board.ID, TaskID: taskID, Rank: r}
+	if err := u.boardRepository.SaveCards(ctx, append(changed, card)...); err != nil {
+		return nil, err
+	}
+	card.Column = column
+	return &card, nil
+}
+
+// assignRanks ranks the tasks in order after prev and stores the ranks in ranks.
+func (u *boardUsecase) assignRanks(boardID int, prev string, taskIDs []int, ranks map[int]string) ([]domain.BoardCard, error) {
+	assigned, err := rank.After(prev, len(taskIDs))
+	if err != nil {
+		return nil, myerror.ErrUnExpected.Wrap(err)
+	}
+	cards := make([]domain.BoardCard, len(taskIDs))
+	for i, taskID := range taskIDs {
+		ranks[taskID] = assigned[i]
+		cards[i] = domain.BoardCard{BoardID: boardID, TaskID: taskID, Rank: assigned[i]}
+	}
+	return cards, nil
+}
+
+func (u *boardUsecase) fetchRanks(ctx context.Context, boardID int) (map[int]string, error) {
+	cards, err := u.boardRepository.FetchCardsByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	ranks := make(map[int]string, len(cards))
+	for _, card := range cards {
+		ranks[card.TaskID] = card.Rank
+	}
+	return ranks, nil
+}
+
+func (u *boardUsecase) fetchOwnedBoard(ctx context.Context, boardID, userID int) (*domain.Board, error) {
+	board, err := u.boardRepository.FetchBoardByID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board.UserID != userID {
+		return nil, myerror.ErrPermissionDenied
+	}
+	return board, nil
+}
+
+// rankAt returns a rank between the cards at position-1 and position.
+func rankAt(cards []domain.Task, ranks map[int]string, position int) (string, error) {
+	prev, next := "", ""
+	if position > 0 {
+		prev = ranks[cards[position-1].ID]
+	}
+	if position < len(cards) {
+		next = ranks[cards[position].ID]
+	}
+	return rank.Between(prev, next)
+}
+
+// layoutBoard puts the tasks in the columns of the board in the order of their rank.
+func layoutBoard(columns []domain.BoardColumn, tasks []domain.Task, ranks map[int]string) [][]domain.Task {
+	layout := make([][]domain.Task, len(columns))
+	for _, task := range tasks {
+		if i := boardColumnOf(columns, task); i >= 0 {
+			layout[i] = append(layout[i], task)
+		}
+	}
+	for _, cards := range layout {
+		sort.SliceStable(cards, func(i, j int) bool {
+			ri, iRanked := ranks[cards[i].ID]
+			rj, jRanked := ranks[cards[j].ID]
+			if iRanked != jRanked {
+				return iRanked
+			}
+			if ri != rj {
+				return ri < rj
+			}
+			return cards[i].ID < cards[j].ID
+		})
+	}
+	return layout
+}
+
+// boardColumnOf returns the first label column matching the task, otherwise the
+// first status column, and -1 when the task is not on the board.
+func boardColumnOf(columns []domain.BoardColumn, task domain.Task) int {
+	status := -1
+	for i, column := range columns {
+		if !column.Matches(task) {
+			continue
+		}
+		if column.Kind == domain.BoardColumnLabel {
+			return i
+		}
+		if status < 0 {
+			status = i
+		}
+	}
+	return status
+}
+
+func isBoardLabel(columns []domain.BoardColumn, label string) bool {
+	for _, column := range columns {
+		if column.Kind == domain.BoardColumnLabel && column.Value == label {
+			return true
+		}
+	}
+	return false
+}
+
+func validateBoardColumns(columns []domain.BoardColumn) ([]domain.BoardColumn, error) {
+	validated := make([]domain.BoardColumn, 0, len(columns))
+	for i, column := range columns {
+		column.Value = strings.TrimSpace(column.Value)
+		column.Tasks = nil
+		if column.Value == "" {
+			return nil, myerror.ErrValidation.WithDescription(fmt.Sprintf("columns[%d]: value is empty", i))
+		}
+		if column.Kind == domain.BoardColumnStatus &&
+			column.Value != domain.BoardStatusOpen && column.Value != domain.BoardStatusCompleted {
+			return nil, myerror.ErrValidation.WithDescription(
+				fmt.Sprintf("columns[%d]: the value of a status column must be open or completed", i))
+		}
+		for _, v := range validated {
+			if v.Kind == column.Kind && v.Value == column.Value {
+				return nil, myerror.ErrValidation.WithDescription(
+					fmt.Sprintf("columns[%d]: the %s %s has another column", i, column.Kind, column.Value))
+			}
+		}
+		validated = append(validated, column)
+	}
+	return validated, nil
+}
@@ -0,0 +1,258 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/transaction"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func testBoard(userID int) *domain.Board {
+	return &domain.Board{
+		ID:     1,
+		UserID: userID,
+		Name:   "standup",
+		Columns: []domain.BoardColumn{
+			{Name: "Todo", Kind: domain.BoardColumnStatus, Value: domain.BoardStatusOpen},
+			{Name: "Doing", Kind: domain.BoardColumnLabel, Value: "doing"},
+			{Name: "Done", Kind: domain.BoardColumnStatus, Value: domain.BoardStatusCompleted},
+		},
+	}
+}
+
+func TestCreateBoard(t *testing.T) {
+	tests := []struct {
+		title     string
+		columns   []domain.BoardColumn
+		wantError error
+	}{
+		{
+			"success",
+			testBoard(1).Columns,
+			nil,
+		},
+		{
+			"unknown status",
+			[]domain.BoardColumn{{Name: "Todo", Kind: domain.BoardColumnStatus, Value: "todo"}},
+			myerror.ErrValidation,
+		},
+		{
+			"two columns of the same label",
+			[]domain.BoardColumn{
+				{Name: "Doing", Kind: domain.BoardColumnLabel, Value: "doing"},
+				{Name: "In progress", Kind: domain.BoardColumnLabel, Value: " doing "},
+			},
+			myerror.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockBoardRepo := mock.NewMockBoardRepository(ctrl)
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+			if tt.wantError == nil {
+				mockBoardRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+			}
+
+			// run
+			uc := usecase.NewBoardUsecase(mockBoardRepo, mockTaskUsecase, &transaction.Noop{})
+			board, err := uc.Create(context.TODO(), 1, "standup", tt.columns)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.columns, board.Columns)
+			}
+		})
+	}
+}
+
+func TestFetchBoardByID(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockBoardRepo := mock.NewMockBoardRepository(ctrl)
+	mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+	tasks := []domain.Task{
+		{ID: 1},
+		{ID: 2, Completed: true},
+		{ID: 3, Labels: domain.StringList{"doing"}},
+		{ID: 4, Completed: true, Labels: domain.StringList{"doing"}},
+		{ID: 5, Labels: domain.StringList{"backend"}},
+	}
+	mockBoardRepo.EXPECT().FetchBoardByID(context.TODO(), 1).Return(testBoard(1), nil)
+	mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{}).Return(tasks, nil)
+	mockBoardRepo.EXPECT().FetchCardsByBoardID(context.TODO(), 1).
+		Return([]domain.BoardCard{{BoardID: 1, TaskID: 1, Rank: "b"}, {BoardID: 1, TaskID: 5, Rank: "a"}}, nil)
+
+	// run
+	uc := usecase.NewBoardUsecase(mockBoardRepo, mockTaskUsecase, &transaction.Noop{})
+	board, err := uc.FetchBoardByID(context.TODO(), 1, 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Task{tasks[4], tasks[0]}, board.Columns[0].Tasks)
+	assert.Equal(t, []domain.Task{tasks[2], tasks[3]}, board.Columns[1].Tasks)
+	assert.Equal(t, []domain.Task{tasks[1]}, board.Columns[2].Tasks)
+}
+
+func TestMoveBoardCard(t *testing.T) {
+	archivedAt := time.Now()
+
+	tests := []struct {
+		title                string
+		board                *domain.Board
+		column               int
+		position             int
+		setupMockTaskUsecase func(*mock.MockTaskUsecase)
+		setupMockBoardRepo   func(*mock.MockBoardRepository)
+		wantCard             *domain.BoardCard
+		wantError            error
+	}{
+		{
+			"move between two cards",
+			testBoard(1),
+			1,
+			1,
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 3, 1).Return(&domain.Task{ID: 3}, nil)
+				mockTaskUsecase.EXPECT().SetLabels(context.TODO(), 3, 1, []string{"doing"}).Return(nil)
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{}).
+					Return([]domain.Task{
+						{ID: 1, Labels: domain.StringList{"doing"}},
+						{ID: 2, Labels: domain.StringList{"doing"}},
+						{ID: 3},
+					}, nil)
+			},
+			func(mockBoardRepo *mock.MockBoardRepository) {
+				mockBoardRepo.EXPECT().FetchCardsByBoardID(context.TODO(), 1).
+					Return([]domain.BoardCard{{BoardID: 1, TaskID: 1, Rank: "a"}, {BoardID: 1, TaskID: 2, Rank: "c"}}, nil)
+				mockBoardRepo.EXPECT().SaveCards(context.TODO(), domain.BoardCard{BoardID: 1, TaskID: 3, Rank: "b"}).Return(nil)
+			},
+			&domain.BoardCard{BoardID: 1, TaskID: 3, Column: 1, Rank: "b"},
+			nil,
+		},
+		{
+			"move to the end of the done column",
+			testBoard(1),
+			2,
+			5,
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 3, 1).
+					Return(&domain.Task{ID: 3, Labels: domain.StringList{"doing", "urgent"}}, nil)
+				mockTaskUsecase.EXPECT().SetLabels(context.TODO(), 3, 1, []string{"urgent"}).Return(nil)
+				mockTaskUsecase.EXPECT().Complete(context.TODO(), 3, 1, true).Return(nil)
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{}).
+					Return([]domain.Task{{ID: 1, Completed: true}, {ID: 3, Labels: domain.StringList{"doing", "urgent"}}}, nil)
+			},
+			func(mockBoardRepo *mock.MockBoardRepository) {
+				mockBoardRepo.EXPECT().FetchCardsByBoardID(context.TODO(), 1).Return(nil, nil)
+				// the card without a rank is ranked first
+				mockBoardRepo.EXPECT().SaveCards(context.TODO(),
+					domain.BoardCard{BoardID: 1, TaskID: 1, Rank: "i"},
+					domain.BoardCard{BoardID: 1, TaskID: 3, Rank: "r"}).Return(nil)
+			},
+			&domain.BoardCard{BoardID: 1, TaskID: 3, Column: 2, Rank: "r"},
+			nil,
+		},
+		{
+			"the column is ranked again when the neighbours share a rank",
+			testBoard(1),
+			0,
+			1,
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 3, 1).Return(&domain.Task{ID: 3}, nil)
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{}).
+					Return([]domain.Task{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+			},
+			func(mockBoardRepo *mock.MockBoardRepository) {
+				mockBoardRepo.EXPECT().FetchCardsByBoardID(context.TODO(), 1).
+					Return([]domain.BoardCard{{BoardID: 1, TaskID: 1, Rank: "i"}, {BoardID: 1, TaskID: 2, Rank: "i"}}, nil)
+				mockBoardRepo.EXPECT().SaveCards(context.TODO(),
+					domain.BoardCard{BoardID: 1, TaskID: 1, Rank: "i"},
+					domain.BoardCard{BoardID: 1, TaskID: 2, Rank: "r"},
+					domain.BoardCard{BoardID: 1, TaskID: 3, Rank: "m"}).Return(nil)
+			},
+			&domain.BoardCard{BoardID: 1, TaskID: 3, Column: 0, Rank: "m"},
+			nil,
+		},
+		{
+			"archived task",
+			testBoard(1),
+			0,
+			0,
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 3, 1).
+					Return(&domain.Task{ID: 3, ArchivedAt: &archivedAt}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrTaskArchived,
+		},
+		{
+			"column out of range",
+			testBoard(1),
+			3,
+			0,
+			nil,
+			nil,
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"board of another user",
+			testBoard(2),
+			0,
+			0,
+			nil,
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockBoardRepo := mock.NewMockBoardRepository(ctrl)
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+			mockBoardRepo.EXPECT().FetchBoardByID(context.TODO(), 1).Return(tt.board, nil)
+			if tt.setupMockTaskUsecase != nil {
+				mockBoardRepo.EXPECT().Lock(context.TODO(), 1).Return(nil)
+				tt.setupMockTaskUsecase(mockTaskUsecase)
+			}
+			if tt.setupMockBoardRepo != nil {
+				tt.setupMockBoardRepo(mockBoardRepo)
+			}
+
+			// run
+			uc := usecase.NewBoardUsecase(mockBoardRepo, mockTaskUsecase, &transaction.Noop{})
+			card, err := uc.Move(context.TODO(), 1, 1, 3, tt.column, tt.position)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCard, card)
+			}
+		})
+	}
+}
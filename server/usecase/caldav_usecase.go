@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/ical"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/transaction"
+)
+
+type calDAVUsecase struct {
+	taskUsecase              domain.TaskUsecase
+	taskEventRepository      domain.TaskEventRepository
+	calDAVResourceRepository domain.CalDAVResourceRepository
+	transaction              transaction.Transaction
+}
+
+// NewCalDAVUsecase creates the usecase of the CalDAV task collection. The changes go
+// through taskUsecase, so they are published and recorded like the changes of the API,
+// and the task events of the user are used for the sync token.
+func NewCalDAVUsecase(taskUsecase domain.TaskUsecase,
+	taskEventRepo domain.TaskEventRepository,
+	calDAVResourceRepo domain.CalDAVResourceRepository,
+	transaction transaction.Transaction) domain.CalDAVUsecase {
+	return &calDAVUsecase{
+		taskUsecase:              taskUsecase,
+		taskEventRepository:      taskEventRepo,
+		calDAVResourceRepository: calDAVResourceRepo,
+		transaction:              transaction,
+	}
+}
+
+func (u *calDAVUsecase) FetchSyncToken(ctx context.Context, userID int) (int64, error) {
+	return u.taskEventRepository.FetchLatestEventID(ctx, userID)
+}
+
+// FetchObjects returns the tasks the user can read, the archived tasks are excluded.
+// The tasks shared read-only are served too, but a PUT to them is denied.
+func (u *calDAVUsecase) FetchObjects(ctx context.Context, userID int) ([]domain.CalDAVObject, error) {
+	tasks, err := u.taskUsecase.FetchAllTaskByUserID(ctx, userID, domain.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	taskIDs := make([]int, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	resources, err := u.fetchResources(ctx, taskIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]domain.CalDAVObject, len(tasks))
+	for i, task := range tasks {
+		objects[i] = calDAVObject(userID, task, resources)
+	}
+	return objects, nil
+}
+
+func (u *calDAVUsecase) FetchObjectByName(ctx context.Context, userID int, name string) (*domain.CalDAVObject, error) {
+	resource, err := u.resolve(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := u.taskUsecase.FetchTaskByTaskID(ctx, resource.TaskID, userID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrPermissionNotFound) || errors.Is(err, myerror.ErrTaskNotFound) {
+			return nil, myerror.ErrCalDAVObjectNotFound
+		}
+		return nil, err
+	}
+	if task.ArchivedAt != nil {
+		return nil, myerror.ErrCalDAVObjectNotFound
+	}
+	return &domain.CalDAVObject{Name: resource.Name, UID: resource.UID, Task: *task}, nil
+}
+
+// FetchChanges returns the tasks changed after the sync token. A sync token of 0 is
+// the initial sync, which returns every task.
+func (u *calDAVUsecase) FetchChanges(ctx context.Context, userID int, syncToken int64) (*domain.CalDAVChanges, error) {
+	if syncToken == 0 {
+		// read the token before the tasks so that no change falls in between
+		latest, err := u.FetchSyncToken(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		objects, err := u.FetchObjects(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.CalDAVChanges{Changed: objects, SyncToken: latest}, nil
+	}
+
+	events, err := u.taskEventRepository.FetchEventsAfterID(ctx, userID, syncToken)
+	if err != nil {
+		return nil, err
+	}
+	changes := &domain.CalDAVChanges{SyncToken: syncToken}
+	if len(events) == 0 {
+		return changes, nil
+	}
+	// the events are fetched in batches, a client syncs again with the returned token
+	changes.SyncToken = events[len(events)-1].ID
+
+	objects, err := u.FetchObjects(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[int]domain.CalDAVObject, len(objects))
+	for _, object := range objects {
+		current[object.Task.ID] = object
+	}
+
+	seen := map[int]bool{}
+	var deletedIDs []int
+	for _, event := range events {
+		if seen[event.TaskID] {
+			continue
+		}
+		seen[event.TaskID] = true
+		if object, ok := current[event.TaskID]; ok {
+			changes.Changed = append(changes.Changed, object)
+		} else {
+			deletedIDs = append(deletedIDs, event.TaskID)
+		}
+	}
+
+	resources, err := u.fetchResources(ctx, deletedIDs...)
+	if err != nil {
+		return nil, err
+	}
+	for _, taskID := range deletedIDs {
+		changes.Deleted = append(changes.Deleted, calDAVObject(userID, domain.Task{ID: taskID}, resources).Name)
+	}
+	return changes, nil
+}
+
+func (u *calDAVUsecase) Put(ctx context.Context, userID int, name, uid string, task domain.Task) (*domain.CalDAVObject, bool, error) {
+	if task.Title == "" {
+		return nil, false, myerror.ErrValidation.WithDescription("missing fields: SUMMARY")
+	}
+	if task.Priority == "" {
+		task.Priority = domain.TaskPriorityNone
+	}
+
+	// the lookup and the creation share the transaction, a concurrent PUT creating the
+	// same name fails on the unique name with ErrPreconditionFailed
+	var object *domain.CalDAVObject
+	var created bool
+	_, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		var err error
+		object, err = u.FetchObjectByName(ctx, userID, name)
+		if err != nil && !errors.Is(err, myerror.ErrCalDAVObjectNotFound) {
+			return nil, err
+		}
+		created = object == nil
+		var taskID int
+		if _, err := fmt.Sscanf(name, "task-%d.ics", &taskID); created && err == nil {
+			// the default names are reserved, otherwise the name could hide the task of another user
+			return nil, myerror.ErrValidation.WithDescription("resource names of the form task-<id>.ics are reserved")
+		}
+
+		if created {
+			taskID, err := u.taskUsecase.Create(ctx, task.Title, task.Description, userID, task.DueDate, task.Priority, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			object = &domain.CalDAVObject{Name: name, UID: uid, Task: domain.Task{ID: taskID}}
+			if uid == "" {
+				object.UID = ical.UID(taskID)
+			}
+			if err := u.calDAVResourceRepository.Create(ctx, &domain.CalDAVResource{
+				TaskID: taskID,
+				UserID: userID,
+				Name:   object.Name,
+				UID:    object.UID,
+			}); err != nil {
+				return nil, err
+			}
+		} else if task.Title != object.Task.Title || task.Description != object.Task.Description ||
+			!task.DueDate.Equal(object.Task.DueDate.Time) || task.Priority != object.Task.Priority {
+			// iCalendar has no estimate nor custom fields, they are kept
+			if err := u.taskUsecase.Update(ctx, object.Task.ID, userID,
+				task.Title, task.Description, task.DueDate, task.Priority, object.Task.EstimateMinutes, nil); err != nil {
+				return nil, err
+			}
+		}
+
+		if task.Completed != object.Task.Completed {
+			return nil, u.taskUsecase.Complete(ctx, object.Task.ID, userID, task.Completed)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	object, err = u.FetchObjectByName(ctx, userID, object.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	return object, created, nil
+}
+
+func (u *calDAVUsecase) Delete(ctx context.Context, userID int, name string) error {
+	object, err := u.FetchObjectByName(ctx, userID, name)
+	if err != nil {
+		return err
+	}
+	return u.taskUsecase.Delete(ctx, object.Task.ID, userID)
+}
+
+// resolve finds the task of the resource name, which is either the name given by the
+// user's client that created the task or the default name of the task.
+func (u *calDAVUsecase) resolve(ctx context.Context, userID int, name string) (*domain.CalDAVResource, error) {
+	resource, err := u.calDAVResourceRepository.FetchResourceByName(ctx, userID, name)
+	if err == nil {
+		return resource, nil
+	}
+	if !errors.Is(err, myerror.ErrCalDAVObjectNotFound) {
+		return nil, err
+	}
+
+	var taskID int
+	if _, err := fmt.Sscanf(name, "task-%d.ics", &taskID); err != nil || calDAVObjectName(taskID) != name {
+		return nil, myerror.ErrCalDAVObjectNotFound
+	}
+	// a task created by the user's client is only found by the client's name
+	resources, err := u.fetchResources(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	object := calDAVObject(userID, domain.Task{ID: taskID}, resources)
+	if object.Name != name {
+		return nil, myerror.ErrCalDAVObjectNotFound
+	}
+	return &domain.CalDAVResource{TaskID: taskID, Name: object.Name, UID: object.UID}, nil
+}
+
+func (u *calDAVUsecase) fetchResources(ctx context.Context, taskIDs ...int) (map[int]domain.CalDAVResource, error) {
+	resources, err := u.calDAVResourceRepository.FetchResourcesByTaskID(ctx, taskIDs...)
+	if err != nil {
+		return nil, err
+	}
+	byTaskID := make(map[int]domain.CalDAVResource, len(resources))
+	for _, resource := range resources {
+		byTaskID[resource.TaskID] = resource
+	}
+	return byTaskID, nil
+}
+
+// calDAVObject names the task with the resource name for the user who created it by
+// CalDAV, the other users see the default name. The UID of the client is kept for all.
+func calDAVObject(userID int, task domain.Task, resources map[int]domain.CalDAVResource) domain.CalDAVObject {
+	resource, ok := resources[task.ID]
+	if !ok {
+		return domain.CalDAVObject{Name: calDAVObjectName(task.ID), UID: ical.UID(task.ID), Task: task}
+	}
+	if resource.UserID != userID {
+		return domain.CalDAVObject{Name: calDAVObjectName(task.ID), UID: resource.UID, Task: task}
+	}
+	return domain.CalDAVObject{Name: resource.Name, UID: resource.UID, Task: task}
+}
+
+func calDAVObjectName(taskID int) string {
+	return fmt.Sprintf("task-%d.ics", taskID)
+}
@@ -0,0 +1,349 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/transaction"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchChanges(t *testing.T) {
+	tests := []struct {
+		title                  string
+		syncToken              int64
+		setupMockTaskEventRepo func(*mock.MockTaskEventRepository)
+		setupMockTaskUsecase   func(*mock.MockTaskUsecase)
+		setupMockResourceRepo  func(*mock.MockCalDAVResourceRepository)
+		wantChanges            *domain.CalDAVChanges
+		wantError              error
+	}{
+		{
+			"initial sync",
+			0,
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				mockTaskEventRepo.EXPECT().FetchLatestEventID(context.TODO(), 1).Return(int64(7), nil)
+			},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{}).
+					Return([]domain.Task{{ID: 1}, {ID: 2}, {ID: 3}}, nil)
+			},
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 1, 2, 3).
+					Return([]domain.CalDAVResource{
+						{TaskID: 2, UserID: 1, Name: "abc.ics", UID: "abc"},
+						{TaskID: 3, UserID: 2, Name: "abc.ics", UID: "def"},
+					}, nil)
+			},
+			&domain.CalDAVChanges{
+				Changed: []domain.CalDAVObject{
+					{Name: "task-1.ics", UID: "task-1@task-management-app", Task: domain.Task{ID: 1}},
+					{Name: "abc.ics", UID: "abc", Task: domain.Task{ID: 2}},
+					// the name of another user's client is not used for the shared task
+					{Name: "task-3.ics", UID: "def", Task: domain.Task{ID: 3}},
+				},
+				SyncToken: 7,
+			},
+			nil,
+		},
+		{
+			"changed and deleted tasks",
+			3,
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				mockTaskEventRepo.EXPECT().FetchEventsAfterID(context.TODO(), 1, int64(3)).
+					Return([]domain.TaskEvent{
+						{ID: 4, Type: domain.TaskEventUpdated, TaskID: 1},
+						{ID: 5, Type: domain.TaskEventDeleted, TaskID: 2},
+						{ID: 6, Type: domain.TaskEventCompleted, TaskID: 1},
+					}, nil)
+			},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{}).
+					Return([]domain.Task{{ID: 1, Completed: true}}, nil)
+			},
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 1).Return(nil, nil)
+				mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 2).
+					Return([]domain.CalDAVResource{{TaskID: 2, UserID: 1, Name: "abc.ics", UID: "abc"}}, nil)
+			},
+			&domain.CalDAVChanges{
+				Changed: []domain.CalDAVObject{
+					{Name: "task-1.ics", UID: "task-1@task-management-app", Task: domain.Task{ID: 1, Completed: true}},
+				},
+				Deleted:   []string{"abc.ics"},
+				SyncToken: 6,
+			},
+			nil,
+		},
+		{
+			"no changes",
+			6,
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				mockTaskEventRepo.EXPECT().FetchEventsAfterID(context.TODO(), 1, int64(6)).Return(nil, nil)
+			},
+			nil,
+			nil,
+			&domain.CalDAVChanges{SyncToken: 6},
+			nil,
+		},
+		{
+			"fetch events failed",
+			6,
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				mockTaskEventRepo.EXPECT().FetchEventsAfterID(context.TODO(), 1, int64(6)).Return(nil, myerror.ErrQueryFailed)
+			},
+			nil,
+			nil,
+			nil,
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+			mockTaskEventRepo := mock.NewMockTaskEventRepository(ctrl)
+			mockResourceRepo := mock.NewMockCalDAVResourceRepository(ctrl)
+
+			if tt.setupMockTaskEventRepo != nil {
+				tt.setupMockTaskEventRepo(mockTaskEventRepo)
+			}
+			if tt.setupMockTaskUsecase != nil {
+				tt.setupMockTaskUsecase(mockTaskUsecase)
+			}
+			if tt.setupMockResourceRepo != nil {
+				tt.setupMockResourceRepo(mockResourceRepo)
+			}
+
+			// run
+			uc := usecase.NewCalDAVUsecase(mockTaskUsecase, mockTaskEventRepo, mockResourceRepo, &transaction.Noop{})
+			changes, err := uc.FetchChanges(context.TODO(), 1, tt.syncToken)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantChanges, changes)
+			}
+		})
+	}
+}
+
+func TestPutCalDAVObject(t *testing.T) {
+	dueDate := domain.MustDateOnly("2024-12-31")
+
+	tests := []struct {
+		title                 string
+		name                  string
+		task                  domain.Task
+		setupMockTaskUsecase  func(*mock.MockTaskUsecase)
+		setupMockResourceRepo func(*mock.MockCalDAVResourceRepository)
+		wantCreated           bool
+		wantError             error
+	}{
+		{
+			"create a task with the client's name",
+			"abc.ics",
+			domain.Task{Title: "buy milk", DueDate: dueDate, Completed: true},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().Create(context.TODO(), "buy milk", "", 1, dueDate, domain.TaskPriorityNone, nil, nil).Return(5, nil)
+				mockTaskUsecase.EXPECT().Complete(context.TODO(), 5, 1, true).Return(nil)
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 5, 1).
+					Return(&domain.Task{ID: 5, Title: "buy milk", DueDate: dueDate, Completed: true}, nil)
+			},
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				gomock.InOrder(
+					mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "abc.ics").
+						Return(nil, myerror.ErrCalDAVObjectNotFound),
+					mockResourceRepo.EXPECT().Create(context.TODO(), &domain.CalDAVResource{TaskID: 5, UserID: 1, Name: "abc.ics", UID: "abc"}).
+						Return(nil),
+					mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "abc.ics").
+						Return(&domain.CalDAVResource{TaskID: 5, UserID: 1, Name: "abc.ics", UID: "abc"}, nil),
+				)
+			},
+			true,
+			nil,
+		},
+		{
+			"complete an existing task",
+			"task-1.ics",
+			domain.Task{Title: "buy milk", DueDate: dueDate, Completed: true},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				gomock.InOrder(
+					mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 1, 1).
+						Return(&domain.Task{ID: 1, Title: "buy milk", DueDate: dueDate, Priority: domain.TaskPriorityNone}, nil),
+					mockTaskUsecase.EXPECT().Complete(context.TODO(), 1, 1, true).Return(nil),
+					mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 1, 1).
+						Return(&domain.Task{ID: 1, Title: "buy milk", DueDate: dueDate, Completed: true}, nil),
+				)
+			},
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "task-1.ics").
+					Return(nil, myerror.ErrCalDAVObjectNotFound).Times(2)
+				mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 1).Return(nil, nil).Times(2)
+			},
+			false,
+			nil,
+		},
+		{
+			"update a task shared by the creator by CalDAV",
+			"task-2.ics",
+			domain.Task{Title: "buy bread", DueDate: dueDate, Priority: domain.TaskPriorityNone},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				gomock.InOrder(
+					mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 2, 1).
+						Return(&domain.Task{ID: 2, Title: "buy milk", DueDate: dueDate, Priority: domain.TaskPriorityNone}, nil),
+					mockTaskUsecase.EXPECT().Update(context.TODO(), 2, 1, "buy bread", "", dueDate, domain.TaskPriorityNone, nil, nil).Return(nil),
+					mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 2, 1).
+						Return(&domain.Task{ID: 2, Title: "buy bread", DueDate: dueDate, Priority: domain.TaskPriorityNone}, nil),
+				)
+			},
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "task-2.ics").
+					Return(nil, myerror.ErrCalDAVObjectNotFound).Times(2)
+				mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 2).
+					Return([]domain.CalDAVResource{{TaskID: 2, UserID: 2, Name: "abc.ics", UID: "abc"}}, nil).Times(2)
+			},
+			false,
+			nil,
+		},
+		{
+			"default name of a task created by the user's client",
+			"task-2.ics",
+			domain.Task{Title: "buy bread", DueDate: dueDate},
+			nil,
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "task-2.ics").
+					Return(nil, myerror.ErrCalDAVObjectNotFound)
+				mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 2).
+					Return([]domain.CalDAVResource{{TaskID: 2, UserID: 1, Name: "abc.ics", UID: "abc"}}, nil)
+			},
+			false,
+			myerror.ErrValidation,
+		},
+		{
+			"name created by a concurrent request",
+			"abc.ics",
+			domain.Task{Title: "buy milk", DueDate: dueDate},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().Create(context.TODO(), "buy milk", "", 1, dueDate, domain.TaskPriorityNone, nil, nil).Return(5, nil)
+			},
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "abc.ics").
+					Return(nil, myerror.ErrCalDAVObjectNotFound)
+				mockResourceRepo.EXPECT().Create(context.TODO(), gomock.Any()).
+					Return(myerror.ErrPreconditionFailed)
+			},
+			false,
+			myerror.ErrPreconditionFailed,
+		},
+		{
+			"missing summary",
+			"abc.ics",
+			domain.Task{DueDate: dueDate},
+			nil,
+			nil,
+			false,
+			myerror.ErrValidation,
+		},
+		{
+			"reserved name",
+			"task-9.ics",
+			domain.Task{Title: "buy milk"},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 9, 1).Return(nil, myerror.ErrPermissionNotFound)
+			},
+			func(mockResourceRepo *mock.MockCalDAVResourceRepository) {
+				mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "task-9.ics").
+					Return(nil, myerror.ErrCalDAVObjectNotFound)
+				mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 9).Return(nil, nil)
+			},
+			false,
+			myerror.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+			mockTaskEventRepo := mock.NewMockTaskEventRepository(ctrl)
+			mockResourceRepo := mock.NewMockCalDAVResourceRepository(ctrl)
+
+			if tt.setupMockTaskUsecase != nil {
+				tt.setupMockTaskUsecase(mockTaskUsecase)
+			}
+			if tt.setupMockResourceRepo != nil {
+				tt.setupMockResourceRepo(mockResourceRepo)
+			}
+
+			// run
+			uc := usecase.NewCalDAVUsecase(mockTaskUsecase, mockTaskEventRepo, mockResourceRepo, &transaction.Noop{})
+			object, created, err := uc.Put(context.TODO(), 1, tt.name, "abc", tt.task)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCreated, created)
+				assert.Equal(t, tt.name, object.Name)
+				assert.Equal(t, tt.task.Completed, object.Task.Completed)
+			}
+		})
+	}
+}
+
+func TestPutCalDAVObjectSharedReadOnly(t *testing.T) {
+	dueDate := domain.MustDateOnly("2024-12-31")
+	stored := &domain.Task{ID: 3, Title: "buy milk", DueDate: dueDate, Priority: domain.TaskPriorityNone}
+
+	tests := []struct {
+		title string
+		task  domain.Task
+	}{
+		{"update", domain.Task{Title: "buy bread", DueDate: dueDate}},
+		{"complete", domain.Task{Title: "buy milk", DueDate: dueDate, Completed: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskEventRepo := mock.NewMockTaskEventRepository(ctrl)
+			mockResourceRepo := mock.NewMockCalDAVResourceRepository(ctrl)
+
+			// the task is shared with user 1 read-only, it is served by CalDAV but not writable
+			mockResourceRepo.EXPECT().FetchResourceByName(context.TODO(), 1, "task-3.ics").
+				Return(nil, myerror.ErrCalDAVObjectNotFound)
+			mockResourceRepo.EXPECT().FetchResourcesByTaskID(context.TODO(), 3).Return(nil, nil)
+			mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 3, 1).
+				Return(&domain.TaskPermission{TaskID: 3, UserID: 1, CanRead: true}, nil).Times(2)
+			mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 3).Return(stored, nil)
+
+			// run
+			tu := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, getMockTaskActivityRepository(ctrl),
+				getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), getMockTaskEventUsecase(ctrl), &transaction.Noop{})
+			uc := usecase.NewCalDAVUsecase(tu, mockTaskEventRepo, mockResourceRepo, &transaction.Noop{})
+			_, _, err := uc.Put(context.TODO(), 1, "task-3.ics", "abc", tt.task)
+
+			// assert
+			assert.ErrorIs(t, err, myerror.ErrPermissionDenied)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/security"
+)
+
+type calendarFeedUsecase struct {
+	calendarFeedRepository domain.CalendarFeedRepository
+	taskUsecase            domain.TaskUsecase
+}
+
+func NewCalendarFeedUsecase(calendarFeedRepo domain.CalendarFeedRepository, taskUsecase domain.TaskUsecase) domain.CalendarFeedUsecase {
+	return &calendarFeedUsecase{
+		calendarFeedRepository: calendarFeedRepo,
+		taskUsecase:            taskUsecase,
+	}
+}
+
+func (u *calendarFeedUsecase) CreateFeed(ctx context.Context, userID int) (string, error) {
+	token, err := security.GenerateToken()
+	if err != nil {
+		return "", myerror.ErrUnExpected.WrapWithDescription(err, "failed to generate calendar feed token")
+	}
+	feed := &domain.CalendarFeed{
+		UserID:    userID,
+		TokenHash: security.HashToken(token),
+	}
+	if err := u.calendarFeedRepository.Save(ctx, feed); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (u *calendarFeedUsecase) RevokeFeed(ctx context.Context, userID int) error {
+	return u.calendarFeedRepository.DeleteByUserID(ctx, userID)
+}
+
+// FetchTasksByToken returns the tasks with a due date of the feed's owner.
+func (u *calendarFeedUsecase) FetchTasksByToken(ctx context.Context, token string, filter domain.TaskFilter) ([]domain.Task, error) {
+	feed, err := u.calendarFeedRepository.FetchFeedByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := u.taskUsecase.FetchAllTaskByUserID(ctx, feed.UserID, filter)
+	if err != nil {
+		return nil, err
+	}
+	dueTasks := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.DueDate.IsZero() {
+			dueTasks = append(dueTasks, task)
+		}
+	}
+	return dueTasks, nil
+}
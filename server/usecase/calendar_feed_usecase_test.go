@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/security"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateFeed(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCalendarFeedRepo := mock.NewMockCalendarFeedRepository(ctrl)
+	mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+	var saved *domain.CalendarFeed
+	mockCalendarFeedRepo.EXPECT().Save(context.TODO(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, feed *domain.CalendarFeed) error {
+			saved = feed
+			return nil
+		})
+
+	// run
+	uc := usecase.NewCalendarFeedUsecase(mockCalendarFeedRepo, mockTaskUsecase)
+	token, err := uc.CreateFeed(context.TODO(), 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, token, 64)
+	assert.Equal(t, 1, saved.UserID)
+	assert.Equal(t, security.HashToken(token), saved.TokenHash)
+}
+
+func TestFetchTasksByToken(t *testing.T) {
+	tests := []struct {
+		title                     string
+		setupMockCalendarFeedRepo func(*mock.MockCalendarFeedRepository)
+		setupMockTaskUsecase      func(*mock.MockTaskUsecase)
+		wantTasks                 []domain.Task
+		wantError                 error
+	}{
+		{
+			"tasks without a due date are excluded",
+			func(mockCalendarFeedRepo *mock.MockCalendarFeedRepository) {
+				mockCalendarFeedRepo.EXPECT().FetchFeedByTokenHash(context.TODO(), security.HashToken("token")).
+					Return(&domain.CalendarFeed{UserID: 1}, nil)
+			},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{Overdue: true}).
+					Return([]domain.Task{{ID: 1, DueDate: domain.MustDateOnly("2024-12-31")}, {ID: 2}}, nil)
+			},
+			[]domain.Task{{ID: 1, DueDate: domain.MustDateOnly("2024-12-31")}},
+			nil,
+		},
+		{
+			"unknown token",
+			func(mockCalendarFeedRepo *mock.MockCalendarFeedRepository) {
+				mockCalendarFeedRepo.EXPECT().FetchFeedByTokenHash(context.TODO(), security.HashToken("token")).
+					Return(nil, myerror.ErrCalendarFeedNotFound)
+			},
+			nil,
+			nil,
+			myerror.ErrCalendarFeedNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCalendarFeedRepo := mock.NewMockCalendarFeedRepository(ctrl)
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+			if tt.setupMockCalendarFeedRepo != nil {
+				tt.setupMockCalendarFeedRepo(mockCalendarFeedRepo)
+			}
+			if tt.setupMockTaskUsecase != nil {
+				tt.setupMockTaskUsecase(mockTaskUsecase)
+			}
+
+			// run
+			uc := usecase.NewCalendarFeedUsecase(mockCalendarFeedRepo, mockTaskUsecase)
+			tasks, err := uc.FetchTasksByToken(context.TODO(), "token", domain.TaskFilter{Overdue: true})
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTasks, tasks)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/transaction"
+)
+
+const customFieldTextMaxLength = 1000
+
+var customFieldKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+type customFieldUsecase struct {
+	customFieldRepository domain.CustomFieldRepository
+	adminUserIDs          []int
+	transaction           transaction.Transaction
+}
+
+// NewCustomFieldUsecase returns the usecase of the custom fields, only the admins can
+// change the fields while every user can read them.
+func NewCustomFieldUsecase(customFieldRepo domain.CustomFieldRepository, adminUserIDs []int,
+	transaction transaction.Transaction) domain.CustomFieldUsecase {
+	return &customFieldUsecase{
+		customFieldRepository: customFieldRepo,
+		adminUserIDs:          adminUserIDs,
+		transaction:           transaction,
+	}
+}
+
+func (u *customFieldUsecase) Create(ctx context.Context, userID int, field domain.CustomField) (*domain.CustomField, error) {
+	if !slices.Contains(u.adminUserIDs, userID) {
+		return nil, myerror.ErrPermissionDenied.WithDescription("only the admins can manage the custom fields")
+	}
+	if !customFieldKey.MatchString(field.Key) {
+		return nil, myerror.ErrValidation.WithDescription(
+			fmt.Sprintf("invalid key: %q, expect lowercase letters, digits and underscores", field.Key))
+	}
+	options, err := validateCustomFieldOptions(field.Type, field.Options)
+	if err != nil {
+		return nil, err
+	}
+	field.ID = 0
+	field.Options = options
+	if err := u.customFieldRepository.Create(ctx, &field); err != nil {
+		return nil, err
+	}
+	return &field, nil
+}
+
+func (u *customFieldUsecase) FetchFields(ctx context.Context) ([]domain.CustomField, error) {
+	return u.customFieldRepository.FetchFields(ctx)
+}
+
+// Update renames the field and replaces its options. The values of the tasks are kept
+// when an option is removed, they are only checked against the options when they are set.
+func (u *customFieldUsecase) Update(ctx context.Context, fieldID, userID int, name string, options []string) (*domain.CustomField, error) {
+	if !slices.Contains(u.adminUserIDs, userID) {
+		return nil, myerror.ErrPermissionDenied.WithDescription("only the admins can manage the custom fields")
+	}
+	field, err := u.customFieldRepository.FetchFieldByID(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	field.Name = name
+	field.Options, err = validateCustomFieldOptions(field.Type, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.customFieldRepository.Update(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// Delete deletes the field and its values on all the tasks.
+func (u *customFieldUsecase) Delete(ctx context.Context, fieldID, userID int) error {
+	if !slices.Contains(u.adminUserIDs, userID) {
+		return myerror.ErrPermissionDenied.WithDescription("only the admins can manage the custom fields")
+	}
+	_, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		field, err := u.customFieldRepository.FetchFieldByID(ctx, fieldID)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.customFieldRepository.Delete(ctx, fieldID); err != nil {
+			return nil, err
+		}
+		return nil, u.customFieldRepository.RemoveTaskValues(ctx, field.Key)
+	})
+	return err
+}
+
+func validateCustomFieldOptions(fieldType domain.CustomFieldType, options []string) (domain.StringList, error) {
+	if fieldType != domain.CustomFieldSelect && fieldType != domain.CustomFieldMultiSelect {
+		if len(options) > 0 {
+			return nil, myerror.ErrValidation.WithDescription(
+				fmt.Sprintf("a %s field cannot have options", fieldType))
+		}
+		return domain.StringList{}, nil
+	}
+	if len(options) == 0 {
+		return nil, myerror.ErrValidation.WithDescription(
+			fmt.Sprintf("a %s field needs at least one option", fieldType))
+	}
+	return domain.StringList(options), nil
+}
+
+// normalizeCustomFieldValues checks the values against the fields and returns them as
+// they are stored, a number as float64, a user as int and a multiSelect as []string.
+// A nil value is kept as it is, it removes the value of the field on update.
+func normalizeCustomFieldValues(fields []domain.CustomField, values domain.CustomFieldValues) (domain.CustomFieldValues, error) {
+	normalized := make(domain.CustomFieldValues, len(values))
+	for key, value := range values {
+		i := slices.IndexFunc(fields, func(field domain.CustomField) bool { return field.Key == key })
+		if i < 0 {
+			return nil, myerror.ErrValidation.WithDescription(fmt.Sprintf("unknown custom field: %s", key))
+		}
+		if value == nil {
+			normalized[key] = nil
+			continue
+		}
+		v, err := normalizeCustomFieldValue(fields[i], value)
+		if err != nil {
+			return nil, myerror.ErrValidation.WithDescription(fmt.Sprintf("customFields.%s: %s", key, err))
+		}
+		normalized[key] = v
+	}
+	return normalized, nil
+}
+
+func normalizeCustomFieldValue(field domain.CustomField, value any) (any, error) {
+	switch field.Type {
+	case domain.CustomFieldText:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expect a string")
+		}
+		if utf8.RuneCountInString(s) > customFieldTextMaxLength {
+			return nil, fmt.Errorf("must be at most %d characters", customFieldTextMaxLength)
+		}
+		return s, nil
+
+	case domain.CustomFieldNumber:
+		switch n := value.(type) {
+		case float64:
+			return n, nil
+		case int:
+			return float64(n), nil
+		}
+		return nil, fmt.Errorf("expect a number")
+
+	case domain.CustomFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expect a date")
+		}
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return nil, fmt.Errorf("expect format: 2006-01-02")
+		}
+		return s, nil
+
+	case domain.CustomFieldSelect:
+		s, ok := value.(string)
+		if !ok || !field.Options.Contains(s) {
+			return nil, fmt.Errorf("expect one of: %s", strings.Join(field.Options, ", "))
+		}
+		return s, nil
+
+	case domain.CustomFieldMultiSelect:
+		var items []any
+		switch v := value.(type) {
+		case []any:
+			items = v
+		case []string:
+			for _, s := range v {
+				items = append(items, s)
+			}
+		default:
+			return nil, fmt.Errorf("expect a list of: %s", strings.Join(field.Options, ", "))
+		}
+		selected := []string{}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok || !field.Options.Contains(s) {
+				return nil, fmt.Errorf("expect a list of: %s", strings.Join(field.Options, ", "))
+			}
+			if !slices.Contains(selected, s) {
+				selected = append(selected, s)
+			}
+		}
+		return selected, nil
+
+	case domain.CustomFieldUser:
+		switch id := value.(type) {
+		case float64:
+			if id > 0 && id <= math.MaxInt32 && id == math.Trunc(id) {
+				return int(id), nil
+			}
+		case int:
+			if id > 0 {
+				return id, nil
+			}
+		}
+		return nil, fmt.Errorf("expect a user ID")
+	}
+	return nil, fmt.Errorf("unknown type: %s", field.Type)
+}
+
+// customFieldMatch returns the jsonb document matching the tasks whose field has the
+// value given as key:value in the task filter.
+func customFieldMatch(fields []domain.CustomField, condition string, userID int) (domain.CustomFieldValues, error) {
+	key, value, ok := strings.Cut(condition, ":")
+	if !ok {
+		return nil, myerror.ErrValidation.WithDescription(
+			fmt.Sprintf("invalid field filter: %q, expect format: key:value", condition))
+	}
+	i := slices.IndexFunc(fields, func(field domain.CustomField) bool { return field.Key == key })
+	if i < 0 {
+		return nil, myerror.ErrValidation.WithDescription(fmt.Sprintf("unknown custom field: %s", key))
+	}
+
+	switch fields[i].Type {
+	case domain.CustomFieldNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, myerror.ErrValidation.WithDescription(fmt.Sprintf("field %s: expect a number", key))
+		}
+		return domain.CustomFieldValues{key: n}, nil
+	case domain.CustomFieldMultiSelect:
+		return domain.CustomFieldValues{key: []string{value}}, nil
+	case domain.CustomFieldUser:
+		if value == "me" {
+			return domain.CustomFieldValues{key: userID}, nil
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, myerror.ErrValidation.WithDescription(fmt.Sprintf("field %s: expect a user ID or me", key))
+		}
+		return domain.CustomFieldValues{key: id}, nil
+	}
+	return domain.CustomFieldValues{key: value}, nil
+}
@@ -0,0 +1,96 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/transaction"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCustomField(t *testing.T) {
+	tests := []struct {
+		title     string
+		userID    int
+		field     domain.CustomField
+		wantError error
+	}{
+		{
+			"success",
+			1,
+			domain.CustomField{Key: "env", Name: "Environment", Type: domain.CustomFieldSelect, Options: domain.StringList{"dev", "prod"}},
+			nil,
+		},
+		{
+			"not an admin",
+			2,
+			domain.CustomField{Key: "env", Name: "Environment", Type: domain.CustomFieldSelect, Options: domain.StringList{"dev", "prod"}},
+			myerror.ErrPermissionDenied,
+		},
+		{
+			"invalid key",
+			1,
+			domain.CustomField{Key: "Story Points", Name: "Story points", Type: domain.CustomFieldNumber},
+			myerror.ErrValidation,
+		},
+		{
+			"select without options",
+			1,
+			domain.CustomField{Key: "env", Name: "Environment", Type: domain.CustomFieldSelect},
+			myerror.ErrValidation,
+		},
+		{
+			"text with options",
+			1,
+			domain.CustomField{Key: "customer", Name: "Customer", Type: domain.CustomFieldText, Options: domain.StringList{"acme"}},
+			myerror.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockCustomFieldRepo := mock.NewMockCustomFieldRepository(ctrl)
+
+			if tt.wantError == nil {
+				mockCustomFieldRepo.EXPECT().Create(context.TODO(), &tt.field).Return(nil)
+			}
+
+			// run
+			uc := usecase.NewCustomFieldUsecase(mockCustomFieldRepo, []int{1}, &transaction.Noop{})
+			field, err := uc.Create(context.TODO(), tt.userID, tt.field)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tt.field, field)
+			}
+		})
+	}
+}
+
+func TestDeleteCustomField(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCustomFieldRepo := mock.NewMockCustomFieldRepository(ctrl)
+
+	mockCustomFieldRepo.EXPECT().FetchFieldByID(context.TODO(), 1).
+		Return(&domain.CustomField{ID: 1, Key: "customer", Type: domain.CustomFieldText}, nil)
+	mockCustomFieldRepo.EXPECT().Delete(context.TODO(), 1).Return(nil)
+	mockCustomFieldRepo.EXPECT().RemoveTaskValues(context.TODO(), "customer").Return(nil)
+
+	uc := usecase.NewCustomFieldUsecase(mockCustomFieldRepo, []int{1}, &transaction.Noop{})
+
+	assert.NoError(t, uc.Delete(context.TODO(), 1, 1))
+	assert.ErrorIs(t, uc.Delete(context.TODO(), 1, 2), myerror.ErrPermissionDenied)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/digest"
+	"github.com/keitatwr/task-management-app/internal/mailer"
+)
+
+type digestUsecase struct {
+	digestRepository domain.DigestRepository
+	mailer           mailer.Mailer
+}
+
+func NewDigestUsecase(digestRepository domain.DigestRepository, mailer mailer.Mailer) domain.DigestUsecase {
+	return &digestUsecase{
+		digestRepository: digestRepository,
+		mailer:           mailer,
+	}
+}
+
+// SendDueDigests sends the digest once a day per user at the first run after the send
+// hour, so that a digest missed while the server was down is sent when it starts again.
+// A digest is claimed before it is sent and released when it fails, another run or
+// another replica skips the claimed digests. The users without an overdue or a due
+// today task receive nothing.
+func (u *digestUsecase) SendDueDigests(ctx context.Context, now time.Time) (int, error) {
+	defaults := domain.NewUserSetting(0)
+	recipients, err := u.digestRepository.FetchRecipients(ctx, defaults)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, recipient := range recipients {
+		ok, err := u.send(ctx, recipient, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", recipient.UserID, err))
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+func (u *digestUsecase) send(ctx context.Context, recipient domain.DigestRecipient, now time.Time) (bool, error) {
+	loc, err := time.LoadLocation(recipient.Timezone)
+	if err != nil {
+		return false, err
+	}
+	local := now.In(loc)
+	if local.Hour() < recipient.DigestHour {
+		return false, nil
+	}
+	today := domain.DateOf(local)
+
+	ok, err := u.digestRepository.Claim(ctx, recipient.UserID, today)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	ok, err = u.sendTasks(ctx, recipient, today)
+	if err != nil {
+		if releaseErr := u.digestRepository.Release(ctx, recipient.UserID, today); releaseErr != nil {
+			return false, errors.Join(err, releaseErr)
+		}
+		return false, err
+	}
+	return ok, nil
+}
+
+func (u *digestUsecase) sendTasks(ctx context.Context, recipient domain.DigestRecipient, today domain.DateOnly) (bool, error) {
+	tasks, err := u.digestRepository.FetchDueTasks(ctx, recipient.UserID, today)
+	if err != nil {
+		return false, err
+	}
+	d := digest.Digest{Name: recipient.Name, Date: today.Time}
+	for _, task := range tasks {
+		if task.DueDate.Before(today.Time) {
+			d.Overdue = append(d.Overdue, task)
+		} else {
+			d.DueToday = append(d.DueToday, task)
+		}
+	}
+	if len(tasks) == 0 {
+		return false, nil
+	}
+
+	msg, err := digest.Render(recipient.Locale, d)
+	if err != nil {
+		return false, err
+	}
+	msg.To = recipient.Email
+	if err := u.mailer.Send(ctx, *msg); err != nil {
+		return false, err
+	}
+	return true, nil
+}
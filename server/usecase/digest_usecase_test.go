@@ -0,0 +1,134 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/mailer"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type stubMailer struct {
+	err      error
+	messages []mailer.Message
+}
+
+func (m *stubMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.messages = append(m.messages, msg)
+	return m.err
+}
+
+func TestSendDueDigests(t *testing.T) {
+	// 9:30 on Dec 2 in Tokyo, 19:30 on Dec 1 in New York
+	now := time.Date(2024, 12, 2, 0, 30, 0, 0, time.UTC)
+	tokyo := domain.DigestRecipient{UserID: 1, Name: "alice", Email: "alice@example.com", DigestHour: 8, Timezone: "Asia/Tokyo", Locale: "en"}
+	dueTasks := []domain.Task{
+		{ID: 1, Title: "write report", DueDate: domain.MustDateOnly("2024-12-01")},
+		{ID: 2, Title: "buy milk", DueDate: domain.MustDateOnly("2024-12-02")},
+	}
+
+	tests := []struct {
+		title         string
+		recipient     domain.DigestRecipient
+		setupMockRepo func(*mock.MockDigestRepository)
+		mailer        *stubMailer
+		wantSent      int
+		wantMessages  int
+		wantError     bool
+	}{
+		{
+			"send",
+			tokyo,
+			func(mockDigestRepo *mock.MockDigestRepository) {
+				mockDigestRepo.EXPECT().Claim(context.TODO(), 1, domain.MustDateOnly("2024-12-02")).Return(true, nil)
+				mockDigestRepo.EXPECT().FetchDueTasks(context.TODO(), 1, domain.MustDateOnly("2024-12-02")).Return(dueTasks, nil)
+			},
+			&stubMailer{},
+			1,
+			1,
+			false,
+		},
+		{
+			"before the send hour",
+			domain.DigestRecipient{UserID: 1, DigestHour: 10, Timezone: "Asia/Tokyo"},
+			func(mockDigestRepo *mock.MockDigestRepository) {},
+			&stubMailer{},
+			0,
+			0,
+			false,
+		},
+		{
+			"day in the user's timezone",
+			domain.DigestRecipient{UserID: 1, DigestHour: 19, Timezone: "America/New_York"},
+			func(mockDigestRepo *mock.MockDigestRepository) {
+				mockDigestRepo.EXPECT().Claim(context.TODO(), 1, domain.MustDateOnly("2024-12-01")).Return(true, nil)
+				mockDigestRepo.EXPECT().FetchDueTasks(context.TODO(), 1, domain.MustDateOnly("2024-12-01")).Return(nil, nil)
+			},
+			&stubMailer{},
+			0,
+			0,
+			false,
+		},
+		{
+			"already sent",
+			tokyo,
+			func(mockDigestRepo *mock.MockDigestRepository) {
+				mockDigestRepo.EXPECT().Claim(context.TODO(), 1, domain.MustDateOnly("2024-12-02")).Return(false, nil)
+			},
+			&stubMailer{},
+			0,
+			0,
+			false,
+		},
+		{
+			"failed to send",
+			tokyo,
+			func(mockDigestRepo *mock.MockDigestRepository) {
+				mockDigestRepo.EXPECT().Claim(context.TODO(), 1, domain.MustDateOnly("2024-12-02")).Return(true, nil)
+				mockDigestRepo.EXPECT().FetchDueTasks(context.TODO(), 1, domain.MustDateOnly("2024-12-02")).Return(dueTasks, nil)
+				mockDigestRepo.EXPECT().Release(context.TODO(), 1, domain.MustDateOnly("2024-12-02")).Return(nil)
+			},
+			&stubMailer{err: errors.New("connection refused")},
+			0,
+			1,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDigestRepo := mock.NewMockDigestRepository(ctrl)
+
+			mockDigestRepo.EXPECT().FetchRecipients(context.TODO(), domain.NewUserSetting(0)).
+				Return([]domain.DigestRecipient{tt.recipient}, nil)
+			tt.setupMockRepo(mockDigestRepo)
+
+			// run
+			uc := usecase.NewDigestUsecase(mockDigestRepo, tt.mailer)
+			sent, err := uc.SendDueDigests(context.TODO(), now)
+
+			// assert
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantSent, sent)
+			assert.Len(t, tt.mailer.messages, tt.wantMessages)
+			if tt.wantMessages > 0 {
+				msg := tt.mailer.messages[0]
+				assert.Equal(t, "alice@example.com", msg.To)
+				assert.Equal(t, "Tasks for Dec 2, 2024: 1 overdue, 1 due today", msg.Subject)
+			}
+		})
+	}
+}
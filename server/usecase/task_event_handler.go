@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/mailer"
+	"github.com/keitatwr/task-management-app/internal/notification"
+)
+
+// NewTaskAuditHandler keeps every task event in the audit log.
+func NewTaskAuditHandler(taskAuditLogRepo domain.TaskAuditLogRepository) domain.TaskEventHandler {
+	return NewTaskEventHandler("audit", func(ctx context.Context, event domain.TaskEvent) error {
+		return taskAuditLogRepo.Create(ctx, &domain.TaskAuditLog{
+			EventID:    event.ID,
+			Type:       event.Type,
+			TaskID:     event.TaskID,
+			Task:       event.Task,
+			Recipients: event.Recipients,
+			OccurredAt: event.CreatedAt,
+		})
+	})
+}
+
+// NewTaskSearchIndexHandler indexes the tasks for the full-text search when they are
+// created or changed, the other events do not change the indexed text.
+func NewTaskSearchIndexHandler(taskRepo domain.TaskRepository) domain.TaskEventHandler {
+	return NewTaskEventHandler("search", func(ctx context.Context, event domain.TaskEvent) error {
+		switch event.Type {
+		case domain.TaskEventCreated, domain.TaskEventUpdated:
+			return taskRepo.IndexSearchVector(ctx, event.TaskID)
+		}
+		return nil
+	})
+}
+
+type taskAssignmentNotifier struct {
+	userRepository             domain.UserRepository
+	userSettingRepository      domain.UserSettingRepository
+	taskNotificationRepository domain.TaskNotificationRepository
+	mailer                     mailer.Mailer
+}
+
+// NewTaskAssignmentNotifier emails the users assigned to a task, in the locale of
+// each user. An assignee is notified once per task, the later changes of the
+// assignees do not notify the users who have been assigned already.
+func NewTaskAssignmentNotifier(userRepo domain.UserRepository,
+	userSettingRepo domain.UserSettingRepository,
+	taskNotificationRepo domain.TaskNotificationRepository,
+	mailer mailer.Mailer) domain.TaskEventHandler {
+	return &taskAssignmentNotifier{
+		userRepository:             userRepo,
+		userSettingRepository:      userSettingRepo,
+		taskNotificationRepository: taskNotificationRepo,
+		mailer:                     mailer,
+	}
+}
+
+func (n *taskAssignmentNotifier) Name() string {
+	return "notification"
+}
+
+// Handle records the notified assignees in the savepoint of the handler, so that a
+// failed email rolls them back and they are notified again when the event is retried.
+// The assignees emailed before the failure may receive the email twice.
+func (n *taskAssignmentNotifier) Handle(ctx context.Context, event domain.TaskEvent) error {
+	if event.Type != domain.TaskEventAssigned {
+		return nil
+	}
+	for _, assigneeID := range event.Task.Assignees {
+		first, err := n.taskNotificationRepository.MarkAssigneeNotified(ctx, event.TaskID, assigneeID)
+		if err != nil {
+			return err
+		}
+		if !first {
+			continue
+		}
+		if err := n.notify(ctx, assigneeID, event.Task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *taskAssignmentNotifier) notify(ctx context.Context, userID int, task domain.Task) error {
+	user, err := n.userRepository.FetchUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	setting, err := n.userSettingRepository.FetchSettingByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	msg, err := notification.Render(setting.Locale, notification.Assignment{Name: user.Name, Task: task})
+	if err != nil {
+		return err
+	}
+	msg.To = user.Email
+	return n.mailer.Send(ctx, *msg)
+}
@@ -0,0 +1,158 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaskSearchIndexHandler(t *testing.T) {
+	tests := []struct {
+		title     string
+		eventType domain.TaskEventType
+		wantIndex bool
+	}{
+		{"created", domain.TaskEventCreated, true},
+		{"updated", domain.TaskEventUpdated, true},
+		{"assigned", domain.TaskEventAssigned, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			if tt.wantIndex {
+				mockTaskRepo.EXPECT().IndexSearchVector(context.TODO(), 1).Return(nil)
+			}
+
+			// run
+			handler := usecase.NewTaskSearchIndexHandler(mockTaskRepo)
+			err := handler.Handle(context.TODO(), domain.TaskEvent{ID: 4, Type: tt.eventType, TaskID: 1})
+
+			// assert
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTaskAuditHandler(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskAuditLogRepo := mock.NewMockTaskAuditLogRepository(ctrl)
+
+	event := domain.TaskEvent{ID: 4, Type: domain.TaskEventDeleted, TaskID: 1, Task: domain.Task{ID: 1}, Recipients: domain.IntList{1, 2}}
+	mockTaskAuditLogRepo.EXPECT().Create(context.TODO(), &domain.TaskAuditLog{
+		EventID:    4,
+		Type:       domain.TaskEventDeleted,
+		TaskID:     1,
+		Task:       domain.Task{ID: 1},
+		Recipients: domain.IntList{1, 2},
+	}).Return(nil)
+
+	// run
+	err := usecase.NewTaskAuditHandler(mockTaskAuditLogRepo).Handle(context.TODO(), event)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestTaskAssignmentNotifier(t *testing.T) {
+	task := domain.Task{ID: 1, Title: "write report", Assignees: domain.IntList{2, 3}}
+
+	tests := []struct {
+		title                         string
+		eventType                     domain.TaskEventType
+		setupMockTaskNotificationRepo func(*mock.MockTaskNotificationRepository)
+		setupMockUserRepo             func(*mock.MockUserRepository)
+		mailer                        *stubMailer
+		wantTo                        []string
+		wantError                     bool
+	}{
+		{
+			"notify the new assignee",
+			domain.TaskEventAssigned,
+			func(mockTaskNotificationRepo *mock.MockTaskNotificationRepository) {
+				// user 2 was assigned before
+				mockTaskNotificationRepo.EXPECT().MarkAssigneeNotified(context.TODO(), 1, 2).Return(false, nil)
+				mockTaskNotificationRepo.EXPECT().MarkAssigneeNotified(context.TODO(), 1, 3).Return(true, nil)
+			},
+			func(mockUserRepo *mock.MockUserRepository) {
+				mockUserRepo.EXPECT().FetchUserByID(context.TODO(), 3).
+					Return(&domain.User{ID: 3, Name: "carol", Email: "carol@example.com"}, nil)
+			},
+			&stubMailer{},
+			[]string{"carol@example.com"},
+			false,
+		},
+		{
+			"mail failed",
+			domain.TaskEventAssigned,
+			func(mockTaskNotificationRepo *mock.MockTaskNotificationRepository) {
+				mockTaskNotificationRepo.EXPECT().MarkAssigneeNotified(context.TODO(), 1, 2).Return(true, nil)
+			},
+			func(mockUserRepo *mock.MockUserRepository) {
+				mockUserRepo.EXPECT().FetchUserByID(context.TODO(), 2).
+					Return(&domain.User{ID: 2, Name: "bob", Email: "bob@example.com"}, nil)
+			},
+			&stubMailer{err: errors.New("connection refused")},
+			[]string{"bob@example.com"},
+			true,
+		},
+		{
+			"not an assignment",
+			domain.TaskEventUpdated,
+			nil,
+			nil,
+			&stubMailer{},
+			nil,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUserRepo := mock.NewMockUserRepository(ctrl)
+			mockUserSettingRepo := getMockUserSettingRepository(ctrl)
+			mockTaskNotificationRepo := mock.NewMockTaskNotificationRepository(ctrl)
+
+			if tt.setupMockTaskNotificationRepo != nil {
+				tt.setupMockTaskNotificationRepo(mockTaskNotificationRepo)
+			}
+			if tt.setupMockUserRepo != nil {
+				tt.setupMockUserRepo(mockUserRepo)
+			}
+			mockUserSettingRepo.EXPECT().FetchSettingByUserID(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, userID int) (*domain.UserSetting, error) {
+					return domain.NewUserSetting(userID), nil
+				}).AnyTimes()
+
+			// run
+			notifier := usecase.NewTaskAssignmentNotifier(mockUserRepo, mockUserSettingRepo, mockTaskNotificationRepo, tt.mailer)
+			err := notifier.Handle(context.TODO(), domain.TaskEvent{ID: 4, Type: tt.eventType, TaskID: 1, Task: task})
+
+			// assert
+			if tt.wantError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			var to []string
+			for _, msg := range tt.mailer.messages {
+				to = append(to, msg.To)
+			}
+			assert.Equal(t, tt.wantTo, to)
+		})
+	}
+}
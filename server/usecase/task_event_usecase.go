@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/transaction"
+)
+
+const (
+	relayBatchSize = 100
+	// maxRelayAttempts gives up an event a handler keeps failing on, about half a minute
+	// of retries at the interval of the relay worker, so that the events behind it are
+	// not blocked forever.
+	maxRelayAttempts = 30
+)
+
+type taskEventUsecase struct {
+	taskEventRepository      domain.TaskEventRepository
+	taskPermissionRepository domain.TaskPermissionRepository
+	transaction              transaction.Transaction
+	hub                      *eventstream.Hub
+	handlers                 []domain.TaskEventHandler
+}
+
+// NewTaskEventUsecase creates the usecase of the task events. The events are
+// always relayed to the stream handler, which wakes up the subscribers of every
+// server replica, followed by the given handlers.
+func NewTaskEventUsecase(taskEventRepo domain.TaskEventRepository,
+	taskPermissionRepo domain.TaskPermissionRepository,
+	transaction transaction.Transaction,
+	hub *eventstream.Hub,
+	handlers ...domain.TaskEventHandler) domain.TaskEventUsecase {
+	stream := NewTaskEventHandler("stream", func(ctx context.Context, event domain.TaskEvent) error {
+		return taskEventRepo.Notify(ctx, event.ID)
+	})
+	return &taskEventUsecase{
+		taskEventRepository:      taskEventRepo,
+		taskPermissionRepository: taskPermissionRepo,
+		transaction:              transaction,
+		hub:                      hub,
+		handlers:                 append([]domain.TaskEventHandler{stream}, handlers...),
+	}
+}
+
+// Publish writes the event to the outbox, ctx must carry the transaction of the change.
+func (u *taskEventUsecase) Publish(ctx context.Context, eventType domain.TaskEventType, task domain.Task) error {
+	userIDs, err := u.taskPermissionRepository.FetchUserIDByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	event := &domain.TaskEvent{
+		Type:       eventType,
+		TaskID:     task.ID,
+		Task:       task,
+		Recipients: userIDs,
+	}
+	return u.taskEventRepository.Create(ctx, event)
+}
+
+func (u *taskEventUsecase) Subscribe(ctx context.Context, userID int, lastEventID int64) (*domain.TaskEventSubscription, error) {
+	// subscribe before reading the backlog so that no event falls in between
+	events, unsubscribe := u.hub.Subscribe(userID)
+
+	var missed []domain.TaskEvent
+	if lastEventID > 0 {
+		var err error
+		missed, err = u.taskEventRepository.FetchEventsAfterID(ctx, userID, lastEventID)
+		if err != nil {
+			unsubscribe()
+			return nil, err
+		}
+	}
+
+	return &domain.TaskEventSubscription{
+		Missed: missed,
+		Events: events,
+		Close:  unsubscribe,
+	}, nil
+}
+
+func (u *taskEventUsecase) Dispatch(ctx context.Context, eventID int64) error {
+	event, err := u.taskEventRepository.FetchEventByID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	u.hub.Broadcast(*event)
+	return nil
+}
+
+// Relay hands the outbox events to the handlers in order and returns how many were relayed.
+// The delivery is at least once, each event is handled in its own transaction and each
+// handler in its own savepoint, which records that the handler processed the event with
+// the changes of the handler. A failing handler rolls back only its savepoint, the other
+// handlers are committed and skip the event when it is retried on the next call, since
+// a failing handler stops the relay and leaves the event pending. After maxRelayAttempts
+// the event is marked failed with the last error and the relay moves on to the next one.
+func (u *taskEventUsecase) Relay(ctx context.Context) (int, error) {
+	for n := 0; n < relayBatchSize; n++ {
+		var handlerErr error
+		_, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+			event, err := u.taskEventRepository.FetchNextPendingEvent(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, handler := range u.handlers {
+				if err := u.handle(ctx, handler, *event); err != nil {
+					if handlerErr == nil {
+						handlerErr = fmt.Errorf("%s: %w", handler.Name(), err)
+					}
+					logger.W(ctx, "failed to handle task event", err, "handler", handler.Name(), "eventID", event.ID)
+				}
+			}
+			if handlerErr != nil {
+				// the processed handlers are committed with the attempt
+				failed := event.Attempts+1 >= maxRelayAttempts
+				if err := u.taskEventRepository.MarkAttemptFailed(ctx, event.ID, handlerErr.Error(), failed); err != nil {
+					return nil, err
+				}
+				if failed {
+					logger.E(ctx, "gave up relaying task event", handlerErr, "eventID", event.ID, "attempts", event.Attempts+1)
+					handlerErr = nil
+				}
+				return nil, nil
+			}
+			return nil, u.taskEventRepository.MarkDispatched(ctx, event.ID)
+		})
+		if err != nil {
+			if errors.Is(err, myerror.ErrTaskEventNotFound) {
+				return n, nil
+			}
+			return n, err
+		}
+		if handlerErr != nil {
+			return n, handlerErr
+		}
+	}
+	return relayBatchSize, nil
+}
+
+// handle runs the handler in a savepoint unless it has already processed the event.
+func (u *taskEventUsecase) handle(ctx context.Context, handler domain.TaskEventHandler, event domain.TaskEvent) error {
+	_, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		first, err := u.taskEventRepository.MarkProcessed(ctx, handler.Name(), event.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			return nil, nil
+		}
+		return nil, handler.Handle(ctx, event)
+	})
+	return err
+}
+
+type taskEventHandler struct {
+	name   string
+	handle func(ctx context.Context, event domain.TaskEvent) error
+}
+
+func NewTaskEventHandler(name string, handle func(ctx context.Context, event domain.TaskEvent) error) domain.TaskEventHandler {
+	return &taskEventHandler{
+		name:   name,
+		handle: handle,
+	}
+}
+
+func (h *taskEventHandler) Name() string {
+	return h.name
+}
+
+func (h *taskEventHandler) Handle(ctx context.Context, event domain.TaskEvent) error {
+	return h.handle(ctx, event)
+}
@@ -0,0 +1,303 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/eventstream"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/transaction"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPublishTaskEvent(t *testing.T) {
+	tests := []struct {
+		title                       string
+		setupMockTaskEventRepo      func(*mock.MockTaskEventRepository)
+		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		wantError                   error
+	}{
+		{
+			"success",
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				mockTaskEventRepo.EXPECT().Create(context.TODO(), &domain.TaskEvent{
+					Type:       domain.TaskEventUpdated,
+					TaskID:     1,
+					Task:       domain.Task{ID: 1, Title: "test title"},
+					Recipients: domain.IntList{1, 2},
+				}).Return(nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchUserIDByTaskID(context.TODO(), 1).
+					Return([]int{1, 2}, nil)
+			},
+			nil,
+		},
+		{
+			"fetch recipients failed",
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchUserIDByTaskID(context.TODO(), 1).
+					Return(nil, myerror.ErrQueryFailed)
+			},
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskEventRepo := mock.NewMockTaskEventRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+
+			if tt.setupMockTaskEventRepo != nil {
+				tt.setupMockTaskEventRepo(mockTaskEventRepo)
+			}
+			if tt.setupMockTaskPermissionRepo != nil {
+				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
+			}
+
+			// run
+			uc := usecase.NewTaskEventUsecase(mockTaskEventRepo, mockTaskPermissionRepo, &transaction.Noop{}, eventstream.NewHub())
+			err := uc.Publish(context.TODO(), domain.TaskEventUpdated, domain.Task{ID: 1, Title: "test title"})
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSubscribeAndDispatchTaskEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskEventRepo := mock.NewMockTaskEventRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+
+	missed := []domain.TaskEvent{{ID: 4, Type: domain.TaskEventCreated, TaskID: 1, Recipients: domain.IntList{1}}}
+	mockTaskEventRepo.EXPECT().FetchEventsAfterID(context.TODO(), 1, int64(3)).Return(missed, nil)
+	mockTaskEventRepo.EXPECT().FetchEventByID(context.TODO(), int64(5)).
+		Return(&domain.TaskEvent{ID: 5, Type: domain.TaskEventUpdated, TaskID: 1, Recipients: domain.IntList{1}}, nil)
+	mockTaskEventRepo.EXPECT().FetchEventByID(context.TODO(), int64(6)).
+		Return(&domain.TaskEvent{ID: 6, Type: domain.TaskEventUpdated, TaskID: 2, Recipients: domain.IntList{2}}, nil)
+
+	uc := usecase.NewTaskEventUsecase(mockTaskEventRepo, mockTaskPermissionRepo, &transaction.Noop{}, eventstream.NewHub())
+
+	subscription, err := uc.Subscribe(context.TODO(), 1, 3)
+	assert.NoError(t, err)
+	defer subscription.Close()
+	assert.Equal(t, missed, subscription.Missed)
+
+	// only the event whose recipients include the user is delivered
+	assert.NoError(t, uc.Dispatch(context.TODO(), 6))
+	assert.NoError(t, uc.Dispatch(context.TODO(), 5))
+
+	event := <-subscription.Events
+	assert.Equal(t, int64(5), event.ID)
+	assert.Empty(t, subscription.Events)
+}
+
+func TestRelayTaskEvent(t *testing.T) {
+	event := &domain.TaskEvent{ID: 4, Type: domain.TaskEventCreated, TaskID: 1, Recipients: domain.IntList{1}}
+	handlerErr := errors.New("handler error")
+
+	tests := []struct {
+		title                  string
+		setupMockTaskEventRepo func(*mock.MockTaskEventRepository)
+		handle                 func(context.Context, domain.TaskEvent) error
+		wantRelayed            int
+		wantHandled            []int64
+		wantError              error
+	}{
+		{
+			"success",
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				gomock.InOrder(
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(event, nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "stream", int64(4)).Return(true, nil),
+					mockTaskEventRepo.EXPECT().Notify(context.TODO(), int64(4)).Return(nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "test", int64(4)).Return(true, nil),
+					mockTaskEventRepo.EXPECT().MarkDispatched(context.TODO(), int64(4)).Return(nil),
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(nil, myerror.ErrTaskEventNotFound),
+				)
+			},
+			nil,
+			1,
+			[]int64{4},
+			nil,
+		},
+		{
+			"already processed",
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				gomock.InOrder(
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(event, nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "stream", int64(4)).Return(false, nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "test", int64(4)).Return(false, nil),
+					mockTaskEventRepo.EXPECT().MarkDispatched(context.TODO(), int64(4)).Return(nil),
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(nil, myerror.ErrTaskEventNotFound),
+				)
+			},
+			nil,
+			1,
+			nil,
+			nil,
+		},
+		{
+			"handler failed",
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				gomock.InOrder(
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(event, nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "stream", int64(4)).Return(true, nil),
+					mockTaskEventRepo.EXPECT().Notify(context.TODO(), int64(4)).Return(nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "test", int64(4)).Return(true, nil),
+					mockTaskEventRepo.EXPECT().MarkAttemptFailed(context.TODO(), int64(4), "test: handler error", false).Return(nil),
+				)
+			},
+			func(ctx context.Context, event domain.TaskEvent) error {
+				return handlerErr
+			},
+			0,
+			nil,
+			handlerErr,
+		},
+		{
+			"handler failed too many times",
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				failing := &domain.TaskEvent{ID: 4, Type: domain.TaskEventCreated, TaskID: 1, Attempts: 29}
+				next := &domain.TaskEvent{ID: 5, Type: domain.TaskEventCreated, TaskID: 2}
+				gomock.InOrder(
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(failing, nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "stream", int64(4)).Return(false, nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "test", int64(4)).Return(true, nil),
+					// the event is given up and the relay moves on to the next one
+					mockTaskEventRepo.EXPECT().MarkAttemptFailed(context.TODO(), int64(4), "test: handler error", true).Return(nil),
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(next, nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "stream", int64(5)).Return(true, nil),
+					mockTaskEventRepo.EXPECT().Notify(context.TODO(), int64(5)).Return(nil),
+					mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "test", int64(5)).Return(true, nil),
+					mockTaskEventRepo.EXPECT().MarkDispatched(context.TODO(), int64(5)).Return(nil),
+					mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(nil, myerror.ErrTaskEventNotFound),
+				)
+			},
+			func(ctx context.Context, event domain.TaskEvent) error {
+				if event.ID == 4 {
+					return handlerErr
+				}
+				return nil
+			},
+			2,
+			nil,
+			nil,
+		},
+		{
+			"no pending event",
+			func(mockTaskEventRepo *mock.MockTaskEventRepository) {
+				mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(nil, myerror.ErrTaskEventNotFound)
+			},
+			nil,
+			0,
+			nil,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskEventRepo := mock.NewMockTaskEventRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			tt.setupMockTaskEventRepo(mockTaskEventRepo)
+
+			var handled []int64
+			handle := tt.handle
+			if handle == nil {
+				handle = func(ctx context.Context, event domain.TaskEvent) error {
+					handled = append(handled, event.ID)
+					return nil
+				}
+			}
+
+			// run
+			uc := usecase.NewTaskEventUsecase(mockTaskEventRepo, mockTaskPermissionRepo, &transaction.Noop{}, eventstream.NewHub(),
+				usecase.NewTaskEventHandler("test", handle))
+			n, err := uc.Relay(context.TODO())
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRelayed, n)
+			assert.Equal(t, tt.wantHandled, handled)
+		})
+	}
+}
+
+func TestRelayTaskEventHandlerFailed(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskEventRepo := mock.NewMockTaskEventRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+
+	event := &domain.TaskEvent{ID: 4, Type: domain.TaskEventCreated, TaskID: 1, Recipients: domain.IntList{1}}
+	handlerErr := errors.New("handler error")
+	gomock.InOrder(
+		// the failing handler does not stop the handlers after it, the event is kept pending
+		mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(event, nil),
+		mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "stream", int64(4)).Return(true, nil),
+		mockTaskEventRepo.EXPECT().Notify(context.TODO(), int64(4)).Return(nil),
+		mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "webhook", int64(4)).Return(true, nil),
+		mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "audit", int64(4)).Return(true, nil),
+		mockTaskEventRepo.EXPECT().MarkAttemptFailed(context.TODO(), int64(4), "webhook: handler error", false).Return(nil),
+		// the retry only runs the failed handler, the others are recorded as processed
+		mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(event, nil),
+		mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "stream", int64(4)).Return(false, nil),
+		mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "webhook", int64(4)).Return(true, nil),
+		mockTaskEventRepo.EXPECT().MarkProcessed(context.TODO(), "audit", int64(4)).Return(false, nil),
+		mockTaskEventRepo.EXPECT().MarkDispatched(context.TODO(), int64(4)).Return(nil),
+		mockTaskEventRepo.EXPECT().FetchNextPendingEvent(context.TODO()).Return(nil, myerror.ErrTaskEventNotFound),
+	)
+
+	var webhookCalls, auditCalls int
+	webhook := usecase.NewTaskEventHandler("webhook", func(ctx context.Context, event domain.TaskEvent) error {
+		webhookCalls++
+		if webhookCalls == 1 {
+			return handlerErr
+		}
+		return nil
+	})
+	audit := usecase.NewTaskEventHandler("audit", func(ctx context.Context, event domain.TaskEvent) error {
+		auditCalls++
+		return nil
+	})
+
+	// run
+	uc := usecase.NewTaskEventUsecase(mockTaskEventRepo, mockTaskPermissionRepo, &transaction.Noop{}, eventstream.NewHub(),
+		webhook, audit)
+	n, err := uc.Relay(context.TODO())
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Equal(t, 0, n)
+
+	n, err = uc.Relay(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// assert
+	assert.Equal(t, 2, webhookCalls)
+	assert.Equal(t, 1, auditCalls)
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/cache"
+)
+
+const (
+	taskStatsDefaultDays = 30
+	// taskStatsCacheTTL is how long the statistics are reused, the dashboards poll
+	// them and a few seconds of staleness is fine.
+	taskStatsCacheTTL = 30 * time.Second
+)
+
+type taskStatsKey struct {
+	userID int
+	owner  domain.TaskOwner
+	days   int
+	today  domain.DateOnly
+}
+
+type taskStatsUsecase struct {
+	taskStatsRepository   domain.TaskStatsRepository
+	userSettingRepository domain.UserSettingRepository
+	cache                 *cache.Cache[taskStatsKey, *domain.TaskStats]
+}
+
+func NewTaskStatsUsecase(taskStatsRepository domain.TaskStatsRepository,
+	userSettingRepository domain.UserSettingRepository) domain.TaskStatsUsecase {
+	return &taskStatsUsecase{
+		taskStatsRepository:   taskStatsRepository,
+		userSettingRepository: userSettingRepository,
+		cache:                 cache.New[taskStatsKey, *domain.TaskStats](taskStatsCacheTTL),
+	}
+}
+
+// FetchStats computes the statistics of the tasks of the user, the trend covers the
+// last days days including today. The days are in the user's timezone.
+func (u *taskStatsUsecase) FetchStats(ctx context.Context, userID int, owner domain.TaskOwner, days int) (*domain.TaskStats, error) {
+	if days <= 0 {
+		days = taskStatsDefaultDays
+	}
+	setting, err := u.userSettingRepository.FetchSettingByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := setting.Location()
+	today := domain.Today(loc)
+	key := taskStatsKey{userID: userID, owner: owner, days: days, today: today}
+	if stats, ok := u.cache.Get(key); ok {
+		return stats, nil
+	}
+
+	// the week ends on Sunday
+	weekEnd := today.AddDays((7 - int(today.Weekday())) % 7)
+	counts, err := u.taskStatsRepository.FetchCounts(ctx, userID, owner, today, weekEnd)
+	if err != nil {
+		return nil, err
+	}
+	from := today.AddDays(1 - days)
+	completedDays, err := u.taskStatsRepository.FetchCompletionTrend(ctx, userID, owner, from, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.TaskStats{
+		Open:        counts.Open,
+		Completed:   counts.Completed,
+		Overdue:     counts.Overdue,
+		DueThisWeek: counts.DueThisWeek,
+		Trend:       make([]domain.TaskStatsDay, days),
+	}
+	if counts.AverageCompletionSeconds != nil {
+		hours := *counts.AverageCompletionSeconds / time.Hour.Seconds()
+		stats.AverageCompletionHours = &hours
+	}
+	for i := range stats.Trend {
+		stats.Trend[i].Date = from.AddDays(i)
+	}
+	for _, day := range completedDays {
+		i := int(day.Date.Sub(from.Time) / (24 * time.Hour))
+		if i >= 0 && i < days {
+			stats.Trend[i].Completed = day.Completed
+		}
+	}
+
+	u.cache.Set(key, stats)
+	return stats, nil
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchTaskStats(t *testing.T) {
+	setting := domain.NewUserSetting(1)
+	setting.Timezone = "America/New_York"
+	loc, _ := time.LoadLocation(setting.Timezone)
+	today := domain.Today(loc)
+	weekEnd := today.AddDays((7 - int(today.Weekday())) % 7)
+	from := today.AddDays(-2)
+	averageSeconds := 5400.0
+	averageHours := 1.5
+
+	tests := []struct {
+		title                  string
+		setupMockTaskStatsRepo func(*mock.MockTaskStatsRepository)
+		wantStats              *domain.TaskStats
+		wantError              error
+	}{
+		{
+			"success",
+			func(mockTaskStatsRepo *mock.MockTaskStatsRepository) {
+				mockTaskStatsRepo.EXPECT().FetchCounts(context.TODO(), 1, domain.TaskOwnerMe, today, weekEnd).
+					Return(&domain.TaskStatsCounts{Open: 3, Completed: 2, Overdue: 1, DueThisWeek: 2, AverageCompletionSeconds: &averageSeconds}, nil)
+				mockTaskStatsRepo.EXPECT().FetchCompletionTrend(context.TODO(), 1, domain.TaskOwnerMe, from, loc).
+					Return([]domain.TaskStatsDay{{Date: today, Completed: 2}}, nil)
+			},
+			&domain.TaskStats{
+				Open: 3, Completed: 2, Overdue: 1, DueThisWeek: 2, AverageCompletionHours: &averageHours,
+				Trend: []domain.TaskStatsDay{
+					{Date: from},
+					{Date: from.AddDays(1)},
+					{Date: today, Completed: 2},
+				},
+			},
+			nil,
+		},
+		{
+			"no completed task",
+			func(mockTaskStatsRepo *mock.MockTaskStatsRepository) {
+				mockTaskStatsRepo.EXPECT().FetchCounts(context.TODO(), 1, domain.TaskOwnerMe, today, weekEnd).
+					Return(&domain.TaskStatsCounts{Open: 1}, nil)
+				mockTaskStatsRepo.EXPECT().FetchCompletionTrend(context.TODO(), 1, domain.TaskOwnerMe, from, loc).
+					Return(nil, nil)
+			},
+			&domain.TaskStats{
+				Open: 1,
+				Trend: []domain.TaskStatsDay{
+					{Date: from},
+					{Date: from.AddDays(1)},
+					{Date: today},
+				},
+			},
+			nil,
+		},
+		{
+			"failed to fetch counts",
+			func(mockTaskStatsRepo *mock.MockTaskStatsRepository) {
+				mockTaskStatsRepo.EXPECT().FetchCounts(context.TODO(), 1, domain.TaskOwnerMe, today, weekEnd).
+					Return(nil, myerror.ErrQueryFailed)
+			},
+			nil,
+			myerror.ErrQueryFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskStatsRepo := mock.NewMockTaskStatsRepository(ctrl)
+			mockUserSettingRepo := mock.NewMockUserSettingRepository(ctrl)
+			mockUserSettingRepo.EXPECT().FetchSettingByUserID(context.TODO(), 1).Return(setting, nil).AnyTimes()
+			tt.setupMockTaskStatsRepo(mockTaskStatsRepo)
+
+			// run
+			uc := usecase.NewTaskStatsUsecase(mockTaskStatsRepo, mockUserSettingRepo)
+			stats, err := uc.FetchStats(context.TODO(), 1, domain.TaskOwnerMe, 3)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStats, stats)
+
+			// the second call is served from the cache
+			cached, err := uc.FetchStats(context.TODO(), 1, domain.TaskOwnerMe, 3)
+			assert.NoError(t, err)
+			assert.Same(t, stats, cached)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/transaction"
+)
+
+var (
+	templatePlaceholder = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+	templateDueOffset   = regexp.MustCompile(`(?i)^\+?\s*(\d{1,3})\s*(d|days?|w|weeks?|m|months?)$`)
+)
+
+type taskTemplateUsecase struct {
+	taskTemplateRepository domain.TaskTemplateRepository
+	taskUsecase            domain.TaskUsecase
+	userSettingRepository  domain.UserSettingRepository
+	transaction            transaction.Transaction
+}
+
+func NewTaskTemplateUsecase(taskTemplateRepo domain.TaskTemplateRepository, taskUsecase domain.TaskUsecase,
+	userSettingRepo domain.UserSettingRepository, transaction transaction.Transaction) domain.TaskTemplateUsecase {
+	return &taskTemplateUsecase{
+		taskTemplateRepository: taskTemplateRepo,
+		taskUsecase:            taskUsecase,
+		userSettingRepository:  userSettingRepo,
+		transaction:            transaction,
+	}
+}
+
+func (u *taskTemplateUsecase) Create(ctx context.Context, userID int, template domain.TaskTemplate) (*domain.TaskTemplate, error) {
+	template.DueOffset = strings.TrimSpace(template.DueOffset)
+	if _, err := dueDateAfter(domain.Today(time.UTC), template.DueOffset); err != nil {
+		return nil, err
+	}
+	template.ID = 0
+	template.UserID = userID
+	if template.Subtasks == nil {
+		template.Subtasks = []domain.TaskTemplateSubtask{}
+	}
+	if template.Labels == nil {
+		template.Labels = domain.StringList{}
+	}
+	if err := u.taskTemplateRepository.Create(ctx, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (u *taskTemplateUsecase) FetchTemplatesByUserID(ctx context.Context, userID int) ([]domain.TaskTemplate, error) {
+	return u.taskTemplateRepository.FetchTemplatesByUserID(ctx, userID)
+}
+
+func (u *taskTemplateUsecase) FetchTemplateByID(ctx context.Context, templateID, userID int) (*domain.TaskTemplate, error) {
+	template, err := u.taskTemplateRepository.FetchTemplateByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.UserID != userID {
+		return nil, myerror.ErrPermissionDenied
+	}
+	return template, nil
+}
+
+func (u *taskTemplateUsecase) Delete(ctx context.Context, templateID, userID int) error {
+	if _, err := u.FetchTemplateByID(ctx, templateID, userID); err != nil {
+		return err
+	}
+	return u.taskTemplateRepository.Delete(ctx, templateID)
+}
+
+// Instantiate fills in the placeholders and creates the task, its labels and its
+// subtasks in one transaction, so that a failure leaves no half-built task behind.
+// Every placeholder must be given a value.
+func (u *taskTemplateUsecase) Instantiate(ctx context.Context, templateID, userID int,
+	variables map[string]string) ([]domain.Task, error) {
+	template, err := u.FetchTemplateByID(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingVariables(template, variables); len(missing) > 0 {
+		return nil, myerror.ErrValidation.WithDescription(
+			fmt.Sprintf("missing variables: %s", strings.Join(missing, ", ")))
+	}
+	setting, err := u.userSettingRepository.FetchSettingByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	dueDate, err := dueDateAfter(domain.Today(setting.Location()), template.DueOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		taskID, err := u.taskUsecase.Create(ctx, fillVariables(template.Title, variables),
+			fillVariables(template.Description, variables), userID, dueDate, domain.TaskPriorityNone, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(template.Labels) > 0 {
+			labels := make([]string, len(template.Labels))
+			for i, label := range template.Labels {
+				labels[i] = fillVariables(label, variables)
+			}
+			if err := u.taskUsecase.SetLabels(ctx, taskID, userID, labels); err != nil {
+				return nil, err
+			}
+		}
+		taskIDs := []int{taskID}
+		for _, subtask := range template.Subtasks {
+			subtaskID, err := u.taskUsecase.AddSubtask(ctx, taskID, userID,
+				fillVariables(subtask.Title, variables), fillVariables(subtask.Description, variables))
+			if err != nil {
+				return nil, err
+			}
+			taskIDs = append(taskIDs, subtaskID)
+		}
+
+		tasks := make([]domain.Task, 0, len(taskIDs))
+		for _, id := range taskIDs {
+			task, err := u.taskUsecase.FetchTaskByTaskID(ctx, id, userID)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, *task)
+		}
+		return tasks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created.([]domain.Task), nil
+}
+
+// missingVariables returns the sorted names of the placeholders of the template without a value.
+func missingVariables(template *domain.TaskTemplate, variables map[string]string) []string {
+	texts := []string{template.Title, template.Description}
+	texts = append(texts, template.Labels...)
+	for _, subtask := range template.Subtasks {
+		texts = append(texts, subtask.Title, subtask.Description)
+	}
+
+	seen := map[string]bool{}
+	missing := []string{}
+	for _, text := range texts {
+		for _, match := range templatePlaceholder.FindAllStringSubmatch(text, -1) {
+			name := match[1]
+			if _, ok := variables[name]; !ok && !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func fillVariables(text string, variables map[string]string) string {
+	return templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return variables[templatePlaceholder.FindStringSubmatch(placeholder)[1]]
+	})
+}
+
+// dueDateAfter returns the day the offset such as "+3 days", "2w" or "+1 month" after
+// today, and today itself when the offset is empty.
+func dueDateAfter(today domain.DateOnly, offset string) (domain.DateOnly, error) {
+	day := today.Time
+	if offset == "" {
+		return today, nil
+	}
+	match := templateDueOffset.FindStringSubmatch(offset)
+	if match == nil {
+		return domain.DateOnly{}, myerror.ErrValidation.WithDescription(
+			fmt.Sprintf("invalid due offset: %q, expect format: +3 days", offset))
+	}
+	n, _ := strconv.Atoi(match[1])
+	switch strings.ToLower(match[2])[0] {
+	case 'w':
+		day = day.AddDate(0, 0, 7*n)
+	case 'm':
+		day = day.AddDate(0, n, 0)
+	default:
+		day = day.AddDate(0, 0, n)
+	}
+	return domain.DateOnly{Time: day}, nil
+}
@@ -0,0 +1,165 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/transaction"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func testTaskTemplate(userID int) *domain.TaskTemplate {
+	return &domain.TaskTemplate{
+		ID:          1,
+		UserID:      userID,
+		Name:        "onboarding",
+		Title:       "onboard {{client}}",
+		Description: "kick-off with {{ client }}",
+		Subtasks: []domain.TaskTemplateSubtask{
+			{Title: "send the contract to {{client}}"},
+			{Title: "create the account", Description: "owner: {{owner}}"},
+		},
+		Labels:    domain.StringList{"client-{{client}}"},
+		DueOffset: "+3 days",
+	}
+}
+
+func TestCreateTaskTemplate(t *testing.T) {
+	tests := []struct {
+		title     string
+		dueOffset string
+		wantError error
+	}{
+		{"days", "+3 days", nil},
+		{"weeks", "2w", nil},
+		{"no offset", "", nil},
+		{"invalid offset", "next friday", myerror.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskTemplateRepo := mock.NewMockTaskTemplateRepository(ctrl)
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+			if tt.wantError == nil {
+				mockTaskTemplateRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+			}
+
+			// run
+			uc := usecase.NewTaskTemplateUsecase(mockTaskTemplateRepo, mockTaskUsecase,
+				mock.NewMockUserSettingRepository(ctrl), &transaction.Noop{})
+			template, err := uc.Create(context.TODO(), 1, domain.TaskTemplate{Name: "weekly", Title: "report", DueOffset: tt.dueOffset})
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, template.UserID)
+				assert.Equal(t, []domain.TaskTemplateSubtask{}, template.Subtasks)
+			}
+		})
+	}
+}
+
+func TestInstantiateTaskTemplate(t *testing.T) {
+	setting := domain.NewUserSetting(1)
+	loc, _ := time.LoadLocation(setting.Timezone)
+	dueDate := domain.Today(loc).AddDays(3)
+
+	tests := []struct {
+		title                string
+		template             *domain.TaskTemplate
+		variables            map[string]string
+		setupMockTaskUsecase func(*mock.MockTaskUsecase)
+		wantTasks            []domain.Task
+		wantError            error
+	}{
+		{
+			"success",
+			testTaskTemplate(1),
+			map[string]string{"client": "acme", "owner": "alice"},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().Create(context.TODO(), "onboard acme", "kick-off with acme", 1,
+					dueDate, domain.TaskPriorityNone, nil, nil).Return(10, nil)
+				mockTaskUsecase.EXPECT().SetLabels(context.TODO(), 10, 1, []string{"client-acme"}).Return(nil)
+				mockTaskUsecase.EXPECT().AddSubtask(context.TODO(), 10, 1, "send the contract to acme", "").Return(11, nil)
+				mockTaskUsecase.EXPECT().AddSubtask(context.TODO(), 10, 1, "create the account", "owner: alice").Return(12, nil)
+				for _, id := range []int{10, 11, 12} {
+					mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), id, 1).Return(&domain.Task{ID: id}, nil)
+				}
+			},
+			[]domain.Task{{ID: 10}, {ID: 11}, {ID: 12}},
+			nil,
+		},
+		{
+			"missing variable",
+			testTaskTemplate(1),
+			map[string]string{"client": "acme"},
+			nil,
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"a subtask fails",
+			testTaskTemplate(1),
+			map[string]string{"client": "acme", "owner": "alice"},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().Create(context.TODO(), "onboard acme", "kick-off with acme", 1,
+					dueDate, domain.TaskPriorityNone, nil, nil).Return(10, nil)
+				mockTaskUsecase.EXPECT().SetLabels(context.TODO(), 10, 1, []string{"client-acme"}).Return(nil)
+				mockTaskUsecase.EXPECT().AddSubtask(context.TODO(), 10, 1, "send the contract to acme", "").
+					Return(0, myerror.ErrQueryFailed)
+			},
+			nil,
+			myerror.ErrQueryFailed,
+		},
+		{
+			"template of another user",
+			testTaskTemplate(2),
+			map[string]string{"client": "acme", "owner": "alice"},
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskTemplateRepo := mock.NewMockTaskTemplateRepository(ctrl)
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+			mockUserSettingRepo := mock.NewMockUserSettingRepository(ctrl)
+
+			mockTaskTemplateRepo.EXPECT().FetchTemplateByID(context.TODO(), 1).Return(tt.template, nil)
+			if tt.setupMockTaskUsecase != nil {
+				mockUserSettingRepo.EXPECT().FetchSettingByUserID(context.TODO(), 1).Return(setting, nil)
+				tt.setupMockTaskUsecase(mockTaskUsecase)
+			}
+
+			// run
+			uc := usecase.NewTaskTemplateUsecase(mockTaskTemplateRepo, mockTaskUsecase, mockUserSettingRepo, &transaction.Noop{})
+			tasks, err := uc.Instantiate(context.TODO(), 1, 1, tt.variables)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTasks, tasks)
+			}
+		})
+	}
+}
@@ -2,38 +2,132 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/keitatwr/task-management-app/domain"
 	"github.com/keitatwr/task-management-app/internal/myerror"
 	"github.com/keitatwr/task-management-app/transaction"
 )
 
+const (
+	purgeBatchSize     = 100
+	defaultSearchLimit = 20
+	bulkMaxTasks       = 500
+	exportBatchSize    = 200
+	importMaxRows      = 1000
+)
+
+// importValidator validates the imported rows with the binding rules of TaskCreateRequest.
+var importValidator = func() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	return v
+}()
+
+// errBulkRolledBack rolls back a bulk operation in the all-or-nothing mode.
+var errBulkRolledBack = errors.New("bulk operation rolled back")
+
 type taskUsecase struct {
 	taskRepository           domain.TaskRepository
 	taskPermissionRepository domain.TaskPermissionRepository
+	taskActivityRepository   domain.TaskActivityRepository
+	customFieldRepository    domain.CustomFieldRepository
+	userSettingRepository    domain.UserSettingRepository
+	taskEventUsecase         domain.TaskEventUsecase
 	transaction              transaction.Transaction
 }
 
 func NewTaskUsecase(taskRepo domain.TaskRepository,
 	taskPermissionRepo domain.TaskPermissionRepository,
+	taskActivityRepo domain.TaskActivityRepository,
+	customFieldRepo domain.CustomFieldRepository,
+	userSettingRepo domain.UserSettingRepository,
+	taskEventUsecase domain.TaskEventUsecase,
 	transaction transaction.Transaction) domain.TaskUsecase {
 	return &taskUsecase{
 		taskRepository:           taskRepo,
 		taskPermissionRepository: taskPermissionRepo,
+		taskActivityRepository:   taskActivityRepo,
+		customFieldRepository:    customFieldRepo,
+		userSettingRepository:    userSettingRepo,
+		taskEventUsecase:         taskEventUsecase,
 		transaction:              transaction,
 	}
 }
 
+// Create returns the ID of the created task. A task without priority has the priority none.
 func (u *taskUsecase) Create(ctx context.Context,
-	title, description string, userID int, dueDate domain.DateOnly) error {
-	_, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
-		todo := &domain.Task{
+	title, description string, userID int, dueDate domain.DateOnly, priority domain.TaskPriority, estimateMinutes *int,
+	customFields domain.CustomFieldValues) (int, error) {
+	if priority == "" {
+		priority = domain.TaskPriorityNone
+	}
+	customFields, err := u.normalizeCustomFields(ctx, customFields)
+	if err != nil {
+		return 0, err
+	}
+	// a new task has no value to remove
+	for key, value := range customFields {
+		if value == nil {
+			delete(customFields, key)
+		}
+	}
+	return u.create(ctx, &domain.Task{
+		Title:           title,
+		Description:     description,
+		Completed:       false,
+		CreatedBy:       userID,
+		DueDate:         dueDate,
+		Priority:        priority,
+		EstimateMinutes: estimateMinutes,
+		CustomFields:    customFields,
+	})
+}
+
+// AddSubtask creates a subtask due on the same day as the parent. A subtask cannot
+// have subtasks of its own.
+func (u *taskUsecase) AddSubtask(ctx context.Context, parentID, userID int, title, description string) (int, error) {
+	permisison, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, parentID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !permisison.CanEdit {
+		return 0, myerror.ErrPermissionDenied
+	}
+
+	created, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		parent, err := u.fetchWritableTask(ctx, parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.ParentID != nil {
+			return nil, myerror.ErrValidation.WithDescription("a subtask cannot have subtasks")
+		}
+		return u.create(ctx, &domain.Task{
 			Title:       title,
 			Description: description,
-			Completed:   false,
 			CreatedBy:   userID,
-			DueDate:     dueDate,
-		}
+			ParentID:    &parentID,
+			DueDate:     parent.DueDate,
+			Priority:    domain.TaskPriorityNone,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created.(int), nil
+}
+
+// create stores the task and gives its creator the permission to edit it.
+func (u *taskUsecase) create(ctx context.Context, todo *domain.Task) (int, error) {
+	userID := todo.CreatedBy
+	created, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		todoID, err := u.taskRepository.Create(ctx, todo)
 		if err != nil {
 			return nil, err
@@ -49,26 +143,93 @@ func (u *taskUsecase) Create(ctx context.Context,
 		if err != nil {
 			return nil, err
 		}
-		return nil, nil
+
+		if err := u.taskEventUsecase.Publish(ctx, domain.TaskEventCreated, *todo); err != nil {
+			return nil, err
+		}
+		return todoID, u.recordActivity(ctx, todoID, userID, domain.TaskActivityCreated,
+			domain.Diff(nil, taskFields(*todo)))
 	})
+	if err != nil {
+		return 0, err
+	}
+	return created.(int), nil
+}
 
+// FetchAllTaskByUserID lists the tasks the user can read, the tasks shared read-only and
+// the tasks assigned to the user included.
+func (u *taskUsecase) FetchAllTaskByUserID(ctx context.Context, userID int, filter domain.TaskFilter) ([]domain.Task, error) {
+	taskIDs, err := u.readableTaskIDs(ctx, userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if len(filter.Field) > 0 || filter.SortField != "" {
+		if err := u.resolveFieldFilter(ctx, userID, &filter); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Overdue || filter.DueWithinDays != nil {
+		setting, err := u.userSettingRepository.FetchSettingByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		filter.Today = domain.Today(setting.Location())
+	}
+	tasks, err := u.taskRepository.FetchAllTaskByTaskID(ctx, userID, filter, taskIDs...)
+	if err != nil {
+		return nil, err
 	}
+	return tasks, nil
+}
 
+// resolveFieldFilter types the custom field conditions of the filter by the definitions
+// of the fields, so that the repository can match them with the index of the values.
+func (u *taskUsecase) resolveFieldFilter(ctx context.Context, userID int, filter *domain.TaskFilter) error {
+	fields, err := u.customFieldRepository.FetchFields(ctx)
+	if err != nil {
+		return err
+	}
+	if filter.SortField != "" &&
+		!slices.ContainsFunc(fields, func(field domain.CustomField) bool { return field.Key == filter.SortField }) {
+		return myerror.ErrValidation.WithDescription(fmt.Sprintf("unknown custom field: %s", filter.SortField))
+	}
+	filter.FieldMatches = make([]domain.CustomFieldValues, 0, len(filter.Field))
+	for _, condition := range filter.Field {
+		match, err := customFieldMatch(fields, condition, userID)
+		if err != nil {
+			return err
+		}
+		filter.FieldMatches = append(filter.FieldMatches, match)
+	}
 	return nil
 }
 
-func (u *taskUsecase) FetchAllTaskByUserID(ctx context.Context, userID int) ([]domain.Task, error) {
-	taskIDs, err := u.taskPermissionRepository.FetchTaskIDByUserID(ctx, userID, true, true)
+// normalizeCustomFields validates the values against the custom fields, see normalizeCustomFieldValues.
+func (u *taskUsecase) normalizeCustomFields(ctx context.Context, values domain.CustomFieldValues) (domain.CustomFieldValues, error) {
+	if len(values) == 0 {
+		return values, nil
+	}
+	fields, err := u.customFieldRepository.FetchFields(ctx)
 	if err != nil {
 		return nil, err
 	}
-	tasks, err := u.taskRepository.FetchAllTaskByTaskID(ctx, taskIDs...)
+	return normalizeCustomFieldValues(fields, values)
+}
+
+// Search searches the tasks the user can read, the tasks shared read-only and the tasks
+// assigned to the user included.
+func (u *taskUsecase) Search(ctx context.Context, userID int, query string, limit int) ([]domain.TaskSearchResult, error) {
+	taskIDs, err := u.readableTaskIDs(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	return tasks, nil
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	return u.taskRepository.Search(ctx, query, limit, taskIDs...)
 }
 
 func (u *taskUsecase) FetchTaskByTaskID(ctx context.Context, taskID, userID int) (*domain.Task, error) {
@@ -88,23 +249,104 @@ func (u *taskUsecase) FetchTaskByTaskID(ctx context.Context, taskID, userID int)
 	return task, nil
 }
 
-func (u *taskUsecase) Update(ctx context.Context, taskID, userID int, title, description string, dueDate domain.DateOnly) error {
+// Update replaces the fields of the task. The custom fields are merged into the values
+// of the task, they are kept when customFields is nil.
+func (u *taskUsecase) Update(ctx context.Context, taskID, userID int, title, description string, dueDate domain.DateOnly,
+	priority domain.TaskPriority, estimateMinutes *int, customFields domain.CustomFieldValues) error {
 	permisison, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
 	if err != nil {
 		return err
 	}
-	if !permisison.CanRead && !permisison.CanEdit {
+	if !permisison.CanEdit {
 		return myerror.ErrPermissionDenied
 	}
 
+	if priority == "" {
+		priority = domain.TaskPriorityNone
+	}
 	update_fileds := map[string]any{
-		"title":       title,
-		"description": description,
-		"due_date":    dueDate,
+		"title":            title,
+		"description":      description,
+		"due_date":         dueDate,
+		"priority":         priority,
+		"estimate_minutes": estimateMinutes,
+	}
+	if customFields != nil {
+		if customFields, err = u.normalizeCustomFields(ctx, customFields); err != nil {
+			return err
+		}
 	}
 
-	return u.taskRepository.Update(ctx,
-		taskID, update_fileds)
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		before, err := u.fetchWritableTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if customFields != nil {
+			merged := domain.CustomFieldValues{}
+			for key, value := range before.CustomFields {
+				merged[key] = value
+			}
+			for key, value := range customFields {
+				if value == nil {
+					delete(merged, key)
+				} else {
+					merged[key] = value
+				}
+			}
+			update_fileds["custom_fields"] = merged
+		}
+		if err := u.taskRepository.Update(ctx,
+			taskID, update_fileds); err != nil {
+			return nil, err
+		}
+		after, err := u.publishByTaskID(ctx, domain.TaskEventUpdated, taskID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, u.recordActivity(ctx, taskID, userID, domain.TaskActivityUpdated,
+			domain.Diff(taskFields(*before), taskFields(*after)))
+	})
+	return err
+}
+
+func (u *taskUsecase) Complete(ctx context.Context, taskID, userID int, completed bool) error {
+	permission, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !permission.CanEdit {
+		return myerror.ErrPermissionDenied
+	}
+
+	eventType, action := domain.TaskEventUpdated, domain.TaskActivityReopened
+	if completed {
+		eventType, action = domain.TaskEventCompleted, domain.TaskActivityCompleted
+	}
+
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		before, err := u.fetchWritableTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		var completedAt *time.Time
+		if completed {
+			now := time.Now()
+			completedAt = &now
+		}
+		if err := u.taskRepository.Update(ctx, taskID, map[string]any{
+			"completed":    completed,
+			"completed_at": completedAt,
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := u.publishByTaskID(ctx, eventType, taskID); err != nil {
+			return nil, err
+		}
+		return nil, u.recordActivity(ctx, taskID, userID, action,
+			domain.Diff(map[string]any{"completed": before.Completed}, map[string]any{"completed": completed}))
+	})
+	return err
 }
 
 func (u *taskUsecase) Delete(ctx context.Context, taskID, userID int) error {
@@ -116,5 +358,640 @@ func (u *taskUsecase) Delete(ctx context.Context, taskID, userID int) error {
 		return myerror.ErrPermissionDenied
 	}
 
-	return u.taskRepository.Delete(ctx, taskID)
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		before, err := u.fetchWritableTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.taskRepository.Delete(ctx, taskID); err != nil {
+			return nil, err
+		}
+		if err := u.taskEventUsecase.Publish(ctx, domain.TaskEventDeleted, domain.Task{ID: taskID}); err != nil {
+			return nil, err
+		}
+		return nil, u.recordActivity(ctx, taskID, userID, domain.TaskActivityDeleted,
+			domain.Diff(taskFields(*before), nil))
+	})
+	return err
+}
+
+func (u *taskUsecase) Share(ctx context.Context, taskID, userID, targetUserID int, canEdit bool) error {
+	permisison, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !permisison.CanEdit {
+		return myerror.ErrPermissionDenied
+	}
+
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		taskPermission := &domain.TaskPermission{
+			TaskID:  taskID,
+			UserID:  targetUserID,
+			CanEdit: canEdit,
+			CanRead: true,
+		}
+
+		// overwrite the permission if the task is already shared with the user
+		var before any
+		current, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, targetUserID)
+		switch {
+		case err == nil:
+			taskPermission.ID = current.ID
+			before = permissionFields(*current)
+		case !errors.Is(err, myerror.ErrPermissionNotFound):
+			return nil, err
+		}
+
+		if err := u.taskPermissionRepository.GrantPermission(ctx, taskPermission); err != nil {
+			return nil, err
+		}
+		if _, err := u.publishByTaskID(ctx, domain.TaskEventPermissionGranted, taskID); err != nil {
+			return nil, err
+		}
+		return nil, u.recordActivity(ctx, taskID, userID, domain.TaskActivityPermissionGranted,
+			map[string]domain.FieldChange{
+				"permission": {Before: before, After: permissionFields(*taskPermission)},
+			})
+	})
+	return err
+}
+
+// Assign replaces the assignees of the task. The assignees who cannot access the task
+// are granted read access, the permission of the others is kept as it is.
+func (u *taskUsecase) Assign(ctx context.Context, taskID, userID int, assigneeIDs []int) error {
+	permisison, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !permisison.CanEdit {
+		return myerror.ErrPermissionDenied
+	}
+
+	assignees := domain.IntList{}
+	for _, id := range assigneeIDs {
+		if !assignees.Contains(id) {
+			assignees = append(assignees, id)
+		}
+	}
+	sort.Ints(assignees)
+
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		before, err := u.fetchWritableTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Equal(before.Assignees, assignees) {
+			return nil, nil
+		}
+
+		for _, assigneeID := range assignees {
+			if before.Assignees.Contains(assigneeID) {
+				continue
+			}
+			_, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, assigneeID)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, myerror.ErrPermissionNotFound) {
+				return nil, err
+			}
+			taskPermission := &domain.TaskPermission{TaskID: taskID, UserID: assigneeID, CanRead: true}
+			if err := u.taskPermissionRepository.GrantPermission(ctx, taskPermission); err != nil {
+				return nil, err
+			}
+			if err := u.recordActivity(ctx, taskID, userID, domain.TaskActivityPermissionGranted,
+				map[string]domain.FieldChange{
+					"permission": {After: permissionFields(*taskPermission)},
+				}); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := u.taskRepository.Update(ctx, taskID, map[string]any{"assignees": assignees}); err != nil {
+			return nil, err
+		}
+		// published after granting the permissions, so that the new assignees are notified
+		after, err := u.publishByTaskID(ctx, domain.TaskEventAssigned, taskID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, u.recordActivity(ctx, taskID, userID, domain.TaskActivityAssigned,
+			domain.Diff(map[string]any{"assignees": []int(before.Assignees)}, map[string]any{"assignees": []int(after.Assignees)}))
+	})
+	return err
+}
+
+// SetLabels replaces the labels of the task. The labels are trimmed and the duplicates
+// dropped, the order given by the user is kept.
+func (u *taskUsecase) SetLabels(ctx context.Context, taskID, userID int, labels []string) error {
+	permission, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !permission.CanEdit {
+		return myerror.ErrPermissionDenied
+	}
+
+	normalized := domain.StringList{}
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label != "" && !normalized.Contains(label) {
+			normalized = append(normalized, label)
+		}
+	}
+
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		before, err := u.fetchWritableTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Equal(before.Labels, normalized) {
+			return nil, nil
+		}
+		if err := u.taskRepository.Update(ctx, taskID, map[string]any{"labels": normalized}); err != nil {
+			return nil, err
+		}
+		after, err := u.publishByTaskID(ctx, domain.TaskEventUpdated, taskID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, u.recordActivity(ctx, taskID, userID, domain.TaskActivityLabeled,
+			domain.Diff(map[string]any{"labels": []string(before.Labels)}, map[string]any{"labels": []string(after.Labels)}))
+	})
+	return err
+}
+
+func (u *taskUsecase) FetchActivitiesByTaskID(ctx context.Context, taskID, userID int) ([]domain.TaskActivity, error) {
+	permisison, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !permisison.CanRead && !permisison.CanEdit {
+		return nil, myerror.ErrPermissionDenied
+	}
+	return u.taskActivityRepository.FetchActivitiesByTaskID(ctx, taskID)
+}
+
+func (u *taskUsecase) FetchTrashByUserID(ctx context.Context, userID int) ([]domain.Task, error) {
+	taskIDs, err := u.taskPermissionRepository.FetchTaskIDByUserID(ctx, userID, true, true)
+	if err != nil {
+		return nil, err
+	}
+	return u.taskRepository.FetchDeletedTasksByTaskID(ctx, taskIDs...)
+}
+
+func (u *taskUsecase) Restore(ctx context.Context, taskID, userID int) error {
+	permisison, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !permisison.CanEdit {
+		return myerror.ErrPermissionDenied
+	}
+
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		if err := u.taskRepository.Restore(ctx, taskID); err != nil {
+			return nil, err
+		}
+		if _, err := u.publishByTaskID(ctx, domain.TaskEventRestored, taskID); err != nil {
+			return nil, err
+		}
+		return nil, u.recordActivity(ctx, taskID, userID, domain.TaskActivityRestored,
+			map[string]domain.FieldChange{})
+	})
+	return err
+}
+
+// PurgeDeleted permanently removes a batch of the tasks deleted more than retention ago,
+// together with their permissions and activities, and returns how many were removed.
+func (u *taskUsecase) PurgeDeleted(ctx context.Context, retention time.Duration) (int, error) {
+	purged, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		taskIDs, err := u.taskRepository.FetchTaskIDsDeletedBefore(ctx, time.Now().Add(-retention), purgeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(taskIDs) == 0 {
+			return 0, nil
+		}
+
+		if err := u.taskPermissionRepository.DeleteByTaskID(ctx, taskIDs...); err != nil {
+			return nil, err
+		}
+		if err := u.taskActivityRepository.DeleteByTaskID(ctx, taskIDs...); err != nil {
+			return nil, err
+		}
+		if err := u.taskRepository.Purge(ctx, taskIDs...); err != nil {
+			return nil, err
+		}
+		return len(taskIDs), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged.(int), nil
+}
+
+func (u *taskUsecase) Archive(ctx context.Context, taskID, userID int, archived bool) error {
+	permisison, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+	if !permisison.CanEdit {
+		return myerror.ErrPermissionDenied
+	}
+
+	_, err = u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, u.archive(ctx, taskID, userID, archived)
+	})
+	return err
+}
+
+// AutoArchive archives the tasks created by the user which were completed more than
+// completedFor ago and returns how many were archived.
+func (u *taskUsecase) AutoArchive(ctx context.Context, userID int, completedFor time.Duration) (int, error) {
+	taskIDs, err := u.taskRepository.FetchTaskIDsToAutoArchive(ctx, userID, time.Now().Add(-completedFor))
+	if err != nil {
+		return 0, err
+	}
+
+	for i, taskID := range taskIDs {
+		if _, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+			return nil, u.archive(ctx, taskID, userID, true)
+		}); err != nil {
+			return i, err
+		}
+	}
+	return len(taskIDs), nil
+}
+
+// archive sets or clears archived_at of the task, it does nothing when the task is already in that state.
+func (u *taskUsecase) archive(ctx context.Context, taskID, actorID int, archived bool) error {
+	before, err := u.taskRepository.FetchTaskByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if (before.ArchivedAt != nil) == archived {
+		return nil
+	}
+
+	var archivedAt *time.Time
+	eventType, action := domain.TaskEventUnarchived, domain.TaskActivityUnarchived
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+		eventType, action = domain.TaskEventArchived, domain.TaskActivityArchived
+	}
+
+	if err := u.taskRepository.Update(ctx, taskID, map[string]any{
+		"archived_at": archivedAt,
+	}); err != nil {
+		return err
+	}
+	if _, err := u.publishByTaskID(ctx, eventType, taskID); err != nil {
+		return err
+	}
+	return u.recordActivity(ctx, taskID, actorID, action,
+		domain.Diff(map[string]any{"archived": !archived}, map[string]any{"archived": archived}))
+}
+
+// fetchWritableTask returns the task unless it is archived, archived tasks are read-only.
+func (u *taskUsecase) fetchWritableTask(ctx context.Context, taskID int) (*domain.Task, error) {
+	task, err := u.taskRepository.FetchTaskByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt != nil {
+		return nil, myerror.ErrTaskArchived
+	}
+	return task, nil
+}
+
+// Bulk applies the operation to each task in one transaction and reports the result
+// for each of them. Every task runs in its own savepoint, so a task failing for
+// example on permission does not undo the others unless allOrNothing is set.
+func (u *taskUsecase) Bulk(ctx context.Context, userID int, taskIDs []int, filter *domain.TaskFilter,
+	op domain.TaskBulkOperation, allOrNothing bool) ([]domain.TaskBulkResult, error) {
+	if err := validateBulkOperation(op, userID); err != nil {
+		return nil, err
+	}
+
+	if filter != nil {
+		tasks, err := u.FetchAllTaskByUserID(ctx, userID, *filter)
+		if err != nil {
+			return nil, err
+		}
+		taskIDs = make([]int, len(tasks))
+		for i, task := range tasks {
+			taskIDs[i] = task.ID
+		}
+	}
+	if len(taskIDs) > bulkMaxTasks {
+		return nil, myerror.ErrValidation.WithDescription(
+			fmt.Sprintf("too many tasks, at most %d tasks can be changed at once", bulkMaxTasks))
+	}
+
+	results := make([]domain.TaskBulkResult, len(taskIDs))
+	_, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		failed := false
+		for i, taskID := range taskIDs {
+			results[i] = domain.TaskBulkResult{TaskID: taskID, Status: domain.TaskBulkSucceeded}
+			if err := u.applyBulkOperation(ctx, taskID, userID, op); err != nil {
+				results[i].Status = domain.TaskBulkFailed
+				results[i].Error = bulkErrorItem(err)
+				failed = true
+			}
+		}
+		if failed && allOrNothing {
+			return nil, errBulkRolledBack
+		}
+		return nil, nil
+	})
+	if errors.Is(err, errBulkRolledBack) {
+		for i := range results {
+			if results[i].Status == domain.TaskBulkSucceeded {
+				results[i].Status = domain.TaskBulkRolledBack
+			}
+		}
+		return results, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (u *taskUsecase) applyBulkOperation(ctx context.Context, taskID, userID int, op domain.TaskBulkOperation) error {
+	switch op.Action {
+	case domain.TaskBulkComplete:
+		completed := true
+		if op.Completed != nil {
+			completed = *op.Completed
+		}
+		return u.Complete(ctx, taskID, userID, completed)
+
+	case domain.TaskBulkDelete:
+		return u.Delete(ctx, taskID, userID)
+
+	case domain.TaskBulkMoveDueDate:
+		task, err := u.FetchTaskByTaskID(ctx, taskID, userID)
+		if err != nil {
+			return err
+		}
+		dueDate := task.DueDate.AddDays(op.ShiftDays)
+		if op.DueDate != nil {
+			dueDate = *op.DueDate
+		}
+		return u.Update(ctx, taskID, userID, task.Title, task.Description, dueDate, task.Priority, task.EstimateMinutes, nil)
+
+	case domain.TaskBulkShare:
+		return u.Share(ctx, taskID, userID, op.UserID, op.CanEdit)
+
+	case domain.TaskBulkAddLabel, domain.TaskBulkRemoveLabel:
+		task, err := u.FetchTaskByTaskID(ctx, taskID, userID)
+		if err != nil {
+			return err
+		}
+		label := strings.TrimSpace(op.Label)
+		labels := slices.DeleteFunc(slices.Clone(task.Labels), func(l string) bool { return l == label })
+		if op.Action == domain.TaskBulkAddLabel {
+			labels = append(labels, label)
+		}
+		return u.SetLabels(ctx, taskID, userID, labels)
+	}
+	return myerror.ErrValidation.WithDescription(fmt.Sprintf("unknown bulk action: %s", op.Action))
+}
+
+func validateBulkOperation(op domain.TaskBulkOperation, userID int) error {
+	switch op.Action {
+	case domain.TaskBulkMoveDueDate:
+		if (op.DueDate == nil) == (op.ShiftDays == 0) {
+			return myerror.ErrValidation.WithDescription("either dueDate or shiftDays is required to move the due date")
+		}
+	case domain.TaskBulkShare:
+		if op.UserID == 0 {
+			return myerror.ErrValidation.WithDescription("userID is required to share tasks")
+		}
+		if op.UserID == userID {
+			return myerror.ErrValidation.WithDescription("cannot share task with yourself")
+		}
+	case domain.TaskBulkAddLabel, domain.TaskBulkRemoveLabel:
+		if strings.TrimSpace(op.Label) == "" {
+			return myerror.ErrValidation.WithDescription("label is required to add or remove a label")
+		}
+	}
+	return nil
+}
+
+func bulkErrorItem(err error) *domain.ErrorItem {
+	var appErr *myerror.AppError
+	if errors.As(err, &appErr) {
+		return &domain.ErrorItem{Code: int(appErr.Code), Message: appErr.Message, Description: appErr.Description}
+	}
+	return &domain.ErrorItem{Code: int(myerror.CodeUnExpected), Message: err.Error()}
+}
+
+// Export passes all the tasks the user can read to fn batch by batch.
+func (u *taskUsecase) Export(ctx context.Context, userID int, fn func([]domain.Task) error) error {
+	taskIDs, err := u.readableTaskIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(taskIDs) == 0 {
+		return nil
+	}
+	return u.taskRepository.FetchAllTaskInBatches(ctx, exportBatchSize, fn, taskIDs...)
+}
+
+// Import validates every record and creates the tasks in one transaction. The records
+// matched with an existing task only complete or uncomplete it, so importing an exported
+// Markdown checklist again does not duplicate the tasks. Nothing is changed when any of
+// the records is invalid or in the dry-run mode, the results then tell which records are valid.
+func (u *taskUsecase) Import(ctx context.Context, userID int, records []domain.TaskImportRecord, dryRun bool) ([]domain.TaskImportResult, error) {
+	if len(records) > importMaxRows {
+		return nil, myerror.ErrValidation.WithDescription(
+			fmt.Sprintf("too many rows, at most %d rows can be imported at once", importMaxRows))
+	}
+
+	results := make([]domain.TaskImportResult, len(records))
+	requests := make([]domain.TaskCreateRequest, len(records))
+	// completions holds the current completion of the matched tasks
+	completions := make([]bool, len(records))
+	valid := true
+	for i, record := range records {
+		var errs []string
+		if record.TaskID > 0 {
+			var err error
+			completions[i], errs, err = u.validateMatchedRecord(ctx, userID, record)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			requests[i], errs = validateImportRecord(record)
+		}
+		results[i] = domain.TaskImportResult{Row: record.Row, Status: domain.TaskImportValid}
+		if len(errs) > 0 {
+			results[i].Status = domain.TaskImportInvalid
+			results[i].Errors = errs
+			valid = false
+		}
+	}
+	if !valid || dryRun {
+		return results, nil
+	}
+
+	statuses := make([]domain.TaskImportStatus, len(records))
+	_, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		for i, record := range records {
+			switch {
+			case record.TaskID == 0:
+				request := requests[i]
+				if _, err := u.Create(ctx, request.Title, request.Description, userID, request.DueDate, request.Priority, request.EstimateMinutes,
+					request.CustomFields); err != nil {
+					return nil, err
+				}
+				statuses[i] = domain.TaskImportCreated
+			case record.Completed != nil && *record.Completed != completions[i]:
+				if err := u.Complete(ctx, record.TaskID, userID, *record.Completed); err != nil {
+					return nil, err
+				}
+				statuses[i] = domain.TaskImportUpdated
+			default:
+				statuses[i] = domain.TaskImportUnchanged
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		results[i].Status = statuses[i]
+	}
+	return results, nil
+}
+
+// validateMatchedRecord returns the current completion of the matched task. The task only
+// has to be editable when its completion changes, so that the tasks shared read-only can
+// be imported back unchanged.
+func (u *taskUsecase) validateMatchedRecord(ctx context.Context, userID int, record domain.TaskImportRecord) (bool, []string, error) {
+	permission, err := u.taskPermissionRepository.FetchPermissionByTaskID(ctx, record.TaskID, userID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrPermissionNotFound) {
+			return false, []string{"id: task not found"}, nil
+		}
+		return false, nil, err
+	}
+	task, err := u.taskRepository.FetchTaskByTaskID(ctx, record.TaskID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrTaskNotFound) {
+			return false, []string{"id: task not found"}, nil
+		}
+		return false, nil, err
+	}
+
+	if record.Completed == nil || *record.Completed == task.Completed {
+		return task.Completed, nil, nil
+	}
+	switch {
+	case !permission.CanEdit:
+		return task.Completed, []string{"completed: permission denied"}, nil
+	case task.ArchivedAt != nil:
+		return task.Completed, []string{"completed: task is archived"}, nil
+	}
+	return task.Completed, nil, nil
+}
+
+func validateImportRecord(record domain.TaskImportRecord) (domain.TaskCreateRequest, []string) {
+	var errs []string
+	request := domain.TaskCreateRequest{Title: record.Title, Description: record.Description}
+
+	if record.DueDate == "" {
+		errs = append(errs, "dueDate: required")
+	} else if dueDate, err := domain.NewDateOnly(record.DueDate); err != nil {
+		errs = append(errs, "dueDate: expect format yyyy-mm-dd")
+	} else {
+		request.DueDate = dueDate
+	}
+
+	if err := importValidator.Struct(request); err != nil {
+		var vErrs validator.ValidationErrors
+		if !errors.As(err, &vErrs) {
+			return request, append(errs, err.Error())
+		}
+		for _, fieldErr := range vErrs {
+			if fieldErr.Field() == "DueDate" {
+				continue
+			}
+			errs = append(errs, fmt.Sprintf("%s: %s", jsonFieldName(fieldErr.Field()), fieldErr.Tag()))
+		}
+	}
+	return request, errs
+}
+
+// jsonFieldName returns the name of the field in the request body, e.g. "Title" becomes "title".
+func jsonFieldName(field string) string {
+	if field == "" {
+		return field
+	}
+	return strings.ToLower(field[:1]) + field[1:]
+}
+
+// readableTaskIDs returns the tasks the user can read, including the tasks shared read-only.
+func (u *taskUsecase) readableTaskIDs(ctx context.Context, userID int) ([]int, error) {
+	editable, err := u.taskPermissionRepository.FetchTaskIDByUserID(ctx, userID, true, true)
+	if err != nil {
+		return nil, err
+	}
+	readOnly, err := u.taskPermissionRepository.FetchTaskIDByUserID(ctx, userID, false, true)
+	if err != nil {
+		return nil, err
+	}
+	return append(editable, readOnly...), nil
+}
+
+// publishByTaskID publishes the event with the task as stored in the transaction and returns the task.
+func (u *taskUsecase) publishByTaskID(ctx context.Context, eventType domain.TaskEventType, taskID int) (*domain.Task, error) {
+	task, err := u.taskRepository.FetchTaskByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.taskEventUsecase.Publish(ctx, eventType, *task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (u *taskUsecase) recordActivity(ctx context.Context, taskID, actorID int,
+	action domain.TaskActivityAction, changes map[string]domain.FieldChange) error {
+	return u.taskActivityRepository.Create(ctx, &domain.TaskActivity{
+		TaskID:  taskID,
+		ActorID: actorID,
+		Action:  action,
+		Changes: changes,
+	})
+}
+
+// taskFields returns the fields of the task tracked by the activity history.
+func taskFields(task domain.Task) map[string]any {
+	fields := map[string]any{
+		"title":           task.Title,
+		"description":     task.Description,
+		"completed":       task.Completed,
+		"dueDate":         task.DueDate.Format("2006-01-02"),
+		"priority":        task.Priority,
+		"estimateMinutes": task.EstimateMinutes,
+	}
+	if len(task.CustomFields) > 0 {
+		fields["customFields"] = map[string]any(task.CustomFields)
+	}
+	return fields
+}
+
+func permissionFields(permission domain.TaskPermission) map[string]any {
+	return map[string]any{
+		"userID":  permission.UserID,
+		"canEdit": permission.CanEdit,
+		"canRead": permission.CanRead,
+	}
 }
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/keitatwr/task-management-app/domain"
 	"github.com/keitatwr/task-management-app/internal/myerror"
@@ -23,15 +24,38 @@ func getMockTaskPermissionRepository(mockCtrl *gomock.Controller) *mock.MockTask
 	return mock.NewMockTaskPermissionRepository(mockCtrl)
 }
 
+func getMockTaskActivityRepository(mockCtrl *gomock.Controller) *mock.MockTaskActivityRepository {
+
+	return mock.NewMockTaskActivityRepository(mockCtrl)
+}
+
+func getMockCustomFieldRepository(mockCtrl *gomock.Controller) *mock.MockCustomFieldRepository {
+
+	return mock.NewMockCustomFieldRepository(mockCtrl)
+}
+
+func getMockUserSettingRepository(mockCtrl *gomock.Controller) *mock.MockUserSettingRepository {
+
+	return mock.NewMockUserSettingRepository(mockCtrl)
+}
+
+func getMockTaskEventUsecase(mockCtrl *gomock.Controller) *mock.MockTaskEventUsecase {
+
+	return mock.NewMockTaskEventUsecase(mockCtrl)
+}
+
 var AnyDate domain.DateOnly
 
 func TestCreateTask(t *testing.T) {
+	estimate := 30
 	type args struct {
-		ctx         context.Context
-		title       string
-		description string
-		userID      int
-		dueDate     domain.DateOnly
+		ctx             context.Context
+		title           string
+		description     string
+		userID          int
+		dueDate         domain.DateOnly
+		priority        domain.TaskPriority
+		estimateMinutes *int
 	}
 
 	tests := []struct {
@@ -39,24 +63,30 @@ func TestCreateTask(t *testing.T) {
 		args                        args
 		setupMockTaskRepo           func(*mock.MockTaskRepository)
 		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		setupMockTaskEventUsecase   func(*mock.MockTaskEventUsecase)
+		setupMockTaskActivityRepo   func(*mock.MockTaskActivityRepository)
 		wantError                   error
 	}{
 		{
 			"success",
 			args{
-				ctx:         context.TODO(),
-				title:       "test title",
-				description: "test description",
-				userID:      1,
-				dueDate:     AnyDate,
+				ctx:             context.TODO(),
+				title:           "test title",
+				description:     "test description",
+				userID:          1,
+				dueDate:         AnyDate,
+				priority:        domain.TaskPriorityHigh,
+				estimateMinutes: &estimate,
 			},
 			func(mockTaskRepo *mock.MockTaskRepository) {
 				mockTaskRepo.EXPECT().Create(context.TODO(), &domain.Task{
-					Title:       "test title",
-					Description: "test description",
-					Completed:   false,
-					CreatedBy:   1,
-					DueDate:     AnyDate,
+					Title:           "test title",
+					Description:     "test description",
+					Completed:       false,
+					CreatedBy:       1,
+					DueDate:         AnyDate,
+					Priority:        domain.TaskPriorityHigh,
+					EstimateMinutes: &estimate,
 				}).Return(1, nil)
 			},
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
@@ -67,6 +97,32 @@ func TestCreateTask(t *testing.T) {
 					CanRead: true,
 				}).Return(nil)
 			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventCreated, domain.Task{
+					Title:           "test title",
+					Description:     "test description",
+					Completed:       false,
+					CreatedBy:       1,
+					DueDate:         AnyDate,
+					Priority:        domain.TaskPriorityHigh,
+					EstimateMinutes: &estimate,
+				}).Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityCreated,
+					Changes: map[string]domain.FieldChange{
+						"title":           {After: "test title"},
+						"description":     {After: "test description"},
+						"completed":       {After: false},
+						"dueDate":         {After: "0001-01-01"},
+						"priority":        {After: domain.TaskPriorityHigh},
+						"estimateMinutes": {After: &estimate},
+					},
+				}).Return(nil)
+			},
 			nil,
 		},
 		{
@@ -85,9 +141,12 @@ func TestCreateTask(t *testing.T) {
 					Completed:   false,
 					CreatedBy:   1,
 					DueDate:     AnyDate,
+					Priority:    domain.TaskPriorityNone,
 				}).Return(1, myerror.ErrTransactionNotFound)
 			},
 			nil,
+			nil,
+			nil,
 			myerror.ErrTransactionNotFound,
 		},
 		{
@@ -106,9 +165,12 @@ func TestCreateTask(t *testing.T) {
 					Completed:   false,
 					CreatedBy:   1,
 					DueDate:     AnyDate,
+					Priority:    domain.TaskPriorityNone,
 				}).Return(0, myerror.ErrQueryFailed)
 			},
 			nil,
+			nil,
+			nil,
 			myerror.ErrQueryFailed,
 		},
 		{
@@ -127,6 +189,7 @@ func TestCreateTask(t *testing.T) {
 					Completed:   false,
 					CreatedBy:   1,
 					DueDate:     AnyDate,
+					Priority:    domain.TaskPriorityNone,
 				}).Return(1, nil)
 			},
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
@@ -137,6 +200,8 @@ func TestCreateTask(t *testing.T) {
 					CanRead: true,
 				}).Return(myerror.ErrPermissionDenied)
 			},
+			nil,
+			nil,
 			myerror.ErrPermissionDenied,
 		},
 	}
@@ -148,6 +213,8 @@ func TestCreateTask(t *testing.T) {
 			defer ctrl.Finish()
 			mockTaskRepo := getMockTaskRepository(ctrl)
 			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
 
 			if tt.setupMockTaskRepo != nil {
 				tt.setupMockTaskRepo(mockTaskRepo)
@@ -155,10 +222,17 @@ func TestCreateTask(t *testing.T) {
 			if tt.setupMockTaskPermissionRepo != nil {
 				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
 			}
+			if tt.setupMockTaskEventUsecase != nil {
+				tt.setupMockTaskEventUsecase(mockTaskEventUsecase)
+			}
+			if tt.setupMockTaskActivityRepo != nil {
+				tt.setupMockTaskActivityRepo(mockTaskActivityRepo)
+			}
 
 			// run
-			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, &transaction.Noop{})
-			err := uc.Create(tt.args.ctx, tt.args.title, tt.args.description, tt.args.userID, tt.args.dueDate)
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			taskID, err := uc.Create(tt.args.ctx, tt.args.title, tt.args.description, tt.args.userID, tt.args.dueDate,
+				tt.args.priority, tt.args.estimateMinutes, nil)
 
 			// assert
 			if tt.wantError != nil {
@@ -166,6 +240,7 @@ func TestCreateTask(t *testing.T) {
 				assert.Equal(t, tt.wantError, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, 1, taskID)
 			}
 		})
 	}
@@ -175,6 +250,7 @@ func TestFetchAllTaskByUserID(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		userID int
+		filter domain.TaskFilter
 	}
 
 	tests := []struct {
@@ -192,7 +268,7 @@ func TestFetchAllTaskByUserID(t *testing.T) {
 				userID: 1,
 			},
 			func(mockTaskRepo *mock.MockTaskRepository) {
-				mockTaskRepo.EXPECT().FetchAllTaskByTaskID(context.TODO(), 1, 2).
+				mockTaskRepo.EXPECT().FetchAllTaskByTaskID(context.TODO(), 1, domain.TaskFilter{}, 1, 2).
 					Return([]domain.Task{
 						{ID: 1, Title: "Task 1"},
 						{ID: 2, Title: "Task 2"},
@@ -201,6 +277,8 @@ func TestFetchAllTaskByUserID(t *testing.T) {
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
 				mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, true, true).
 					Return([]int{1, 2}, nil)
+				mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, false, true).
+					Return(nil, nil)
 			},
 			[]domain.Task{
 				{ID: 1, Title: "Task 1"},
@@ -208,6 +286,28 @@ func TestFetchAllTaskByUserID(t *testing.T) {
 			},
 			nil,
 		},
+		{
+			"assigned to a read-only user",
+			args{
+				ctx:    context.TODO(),
+				userID: 1,
+				filter: domain.TaskFilter{Assignee: domain.TaskAssigneeMe},
+			},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchAllTaskByTaskID(context.TODO(), 1,
+					domain.TaskFilter{Assignee: domain.TaskAssigneeMe}, 1, 3).
+					Return([]domain.Task{{ID: 3, Title: "Task 3", Assignees: domain.IntList{1}}}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, true, true).
+					Return([]int{1}, nil)
+				// the assignees are granted read only
+				mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, false, true).
+					Return([]int{3}, nil)
+			},
+			[]domain.Task{{ID: 3, Title: "Task 3", Assignees: domain.IntList{1}}},
+			nil,
+		},
 		{
 			"fetch task IDs failed",
 			args{
@@ -229,12 +329,14 @@ func TestFetchAllTaskByUserID(t *testing.T) {
 				userID: 1,
 			},
 			func(mockTaskRepo *mock.MockTaskRepository) {
-				mockTaskRepo.EXPECT().FetchAllTaskByTaskID(context.TODO(), 1, 2).
+				mockTaskRepo.EXPECT().FetchAllTaskByTaskID(context.TODO(), 1, domain.TaskFilter{}, 1, 2).
 					Return(nil, myerror.ErrQueryFailed)
 			},
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
 				mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, true, true).
 					Return([]int{1, 2}, nil)
+				mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, false, true).
+					Return(nil, nil)
 			},
 			nil,
 			myerror.ErrQueryFailed,
@@ -262,6 +364,8 @@ func TestFetchAllTaskByUserID(t *testing.T) {
 			defer ctrl.Finish()
 			mockTaskRepo := getMockTaskRepository(ctrl)
 			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
 
 			if tt.setupMockTaskRepo != nil {
 				tt.setupMockTaskRepo(mockTaskRepo)
@@ -271,8 +375,8 @@ func TestFetchAllTaskByUserID(t *testing.T) {
 			}
 
 			// run
-			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, &transaction.Noop{})
-			tasks, err := uc.FetchAllTaskByUserID(tt.args.ctx, tt.args.userID)
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			tasks, err := uc.FetchAllTaskByUserID(tt.args.ctx, tt.args.userID, tt.args.filter)
 
 			// assert
 			if tt.wantError != nil {
@@ -376,6 +480,8 @@ func TestFetchTaskByTaskID(t *testing.T) {
 			defer ctrl.Finish()
 			mockTaskRepo := getMockTaskRepository(ctrl)
 			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
 
 			if tt.setupMockTaskRepo != nil {
 				tt.setupMockTaskRepo(mockTaskRepo)
@@ -385,7 +491,7 @@ func TestFetchTaskByTaskID(t *testing.T) {
 			}
 
 			// run
-			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, &transaction.Noop{})
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
 			task, err := uc.FetchTaskByTaskID(tt.args.ctx, tt.args.taskID, tt.args.userID)
 
 			// assert
@@ -415,6 +521,8 @@ func TestUpdateTask(t *testing.T) {
 		args                        args
 		setupMockTaskRepo           func(*mock.MockTaskRepository)
 		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		setupMockTaskEventUsecase   func(*mock.MockTaskEventUsecase)
+		setupMockTaskActivityRepo   func(*mock.MockTaskActivityRepository)
 		wantError                   error
 	}{
 		{
@@ -428,17 +536,74 @@ func TestUpdateTask(t *testing.T) {
 				dueDate:     AnyDate,
 			},
 			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "old title", Description: "test description", DueDate: AnyDate}, nil)
+				mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{
+					"title":            "test title",
+					"description":      "test description",
+					"due_date":         AnyDate,
+					"priority":         domain.TaskPriorityNone,
+					"estimate_minutes": (*int)(nil),
+				}).Return(nil)
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "test title", Description: "test description", DueDate: AnyDate}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventUpdated, domain.Task{
+					ID: 1, Title: "test title", Description: "test description", DueDate: AnyDate,
+				}).Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				// only the changed fields are recorded
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityUpdated,
+					Changes: map[string]domain.FieldChange{
+						"title": {Before: "old title", After: "test title"},
+					},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"publish task event failed",
+			args{
+				ctx:         context.TODO(),
+				taskID:      1,
+				title:       "test title",
+				description: "test description",
+				userID:      1,
+				dueDate:     AnyDate,
+			},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "old title", Description: "test description", DueDate: AnyDate}, nil)
 				mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{
-					"title":       "test title",
-					"description": "test description",
-					"due_date":    AnyDate,
+					"title":            "test title",
+					"description":      "test description",
+					"due_date":         AnyDate,
+					"priority":         domain.TaskPriorityNone,
+					"estimate_minutes": (*int)(nil),
 				}).Return(nil)
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "test title", Description: "test description", DueDate: AnyDate}, nil)
 			},
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
 			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventUpdated, domain.Task{
+					ID: 1, Title: "test title", Description: "test description", DueDate: AnyDate,
+				}).Return(myerror.ErrQueryFailed)
+			},
 			nil,
+			myerror.ErrQueryFailed,
 		},
 		{
 			"update task permission not found",
@@ -455,6 +620,8 @@ func TestUpdateTask(t *testing.T) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(nil, myerror.ErrPermissionNotFound)
 			},
+			nil,
+			nil,
 			myerror.ErrPermissionNotFound,
 		},
 		{
@@ -472,6 +639,28 @@ func TestUpdateTask(t *testing.T) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(&domain.TaskPermission{CanRead: false, CanEdit: false}, nil)
 			},
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+		{
+			// the assignees are granted read only
+			"update task by a read-only assignee",
+			args{
+				ctx:         context.TODO(),
+				taskID:      1,
+				title:       "test title",
+				description: "test description",
+				userID:      2,
+				dueDate:     AnyDate,
+			},
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 2).
+					Return(&domain.TaskPermission{TaskID: 1, UserID: 2, CanRead: true}, nil)
+			},
+			nil,
+			nil,
 			myerror.ErrPermissionDenied,
 		},
 		{
@@ -485,16 +674,22 @@ func TestUpdateTask(t *testing.T) {
 				dueDate:     AnyDate,
 			},
 			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "old title", Description: "test description", DueDate: AnyDate}, nil)
 				mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{
-					"title":       "test title",
-					"description": "test description",
-					"due_date":    AnyDate,
+					"title":            "test title",
+					"description":      "test description",
+					"due_date":         AnyDate,
+					"priority":         domain.TaskPriorityNone,
+					"estimate_minutes": (*int)(nil),
 				}).Return(myerror.ErrQueryFailed)
 			},
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
 			},
+			nil,
+			nil,
 			myerror.ErrQueryFailed,
 		},
 	}
@@ -506,6 +701,8 @@ func TestUpdateTask(t *testing.T) {
 			defer ctrl.Finish()
 			mockTaskRepo := getMockTaskRepository(ctrl)
 			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
 
 			if tt.setupMockTaskRepo != nil {
 				tt.setupMockTaskRepo(mockTaskRepo)
@@ -513,10 +710,16 @@ func TestUpdateTask(t *testing.T) {
 			if tt.setupMockTaskPermissionRepo != nil {
 				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
 			}
+			if tt.setupMockTaskEventUsecase != nil {
+				tt.setupMockTaskEventUsecase(mockTaskEventUsecase)
+			}
+			if tt.setupMockTaskActivityRepo != nil {
+				tt.setupMockTaskActivityRepo(mockTaskActivityRepo)
+			}
 
 			// run
-			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, &transaction.Noop{})
-			err := uc.Update(tt.args.ctx, tt.args.taskID, tt.args.userID, tt.args.title, tt.args.description, tt.args.dueDate)
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			err := uc.Update(tt.args.ctx, tt.args.taskID, tt.args.userID, tt.args.title, tt.args.description, tt.args.dueDate, "", nil, nil)
 
 			// assert
 			if tt.wantError != nil {
@@ -541,6 +744,8 @@ func TestDeleteTask(t *testing.T) {
 		args                        args
 		setupMockTaskRepo           func(*mock.MockTaskRepository)
 		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		setupMockTaskEventUsecase   func(*mock.MockTaskEventUsecase)
+		setupMockTaskActivityRepo   func(*mock.MockTaskActivityRepository)
 		wantError                   error
 	}{
 		{
@@ -551,12 +756,33 @@ func TestDeleteTask(t *testing.T) {
 				userID: 1,
 			},
 			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "test title", Description: "test description", DueDate: AnyDate}, nil)
 				mockTaskRepo.EXPECT().Delete(context.TODO(), 1).Return(nil)
 			},
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
 			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventDeleted, domain.Task{ID: 1}).
+					Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityDeleted,
+					Changes: map[string]domain.FieldChange{
+						"title":           {Before: "test title"},
+						"description":     {Before: "test description"},
+						"completed":       {Before: false},
+						"dueDate":         {Before: "0001-01-01"},
+						"priority":        {Before: domain.TaskPriority("")},
+						"estimateMinutes": {Before: (*int)(nil)},
+					},
+				}).Return(nil)
+			},
 			nil,
 		},
 		{
@@ -571,6 +797,8 @@ func TestDeleteTask(t *testing.T) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(nil, myerror.ErrPermissionNotFound)
 			},
+			nil,
+			nil,
 			myerror.ErrPermissionNotFound,
 		},
 		{
@@ -585,6 +813,8 @@ func TestDeleteTask(t *testing.T) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(&domain.TaskPermission{CanRead: false, CanEdit: false}, nil)
 			},
+			nil,
+			nil,
 			myerror.ErrPermissionDenied,
 		},
 		{
@@ -595,12 +825,16 @@ func TestDeleteTask(t *testing.T) {
 				userID: 1,
 			},
 			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "test title", Description: "test description", DueDate: AnyDate}, nil)
 				mockTaskRepo.EXPECT().Delete(context.TODO(), 1).Return(myerror.ErrQueryFailed)
 			},
 			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
 				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
 					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
 			},
+			nil,
+			nil,
 			myerror.ErrQueryFailed,
 		},
 	}
@@ -612,6 +846,8 @@ func TestDeleteTask(t *testing.T) {
 			defer ctrl.Finish()
 			mockTaskRepo := getMockTaskRepository(ctrl)
 			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
 
 			if tt.setupMockTaskRepo != nil {
 				tt.setupMockTaskRepo(mockTaskRepo)
@@ -619,9 +855,15 @@ func TestDeleteTask(t *testing.T) {
 			if tt.setupMockTaskPermissionRepo != nil {
 				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
 			}
+			if tt.setupMockTaskEventUsecase != nil {
+				tt.setupMockTaskEventUsecase(mockTaskEventUsecase)
+			}
+			if tt.setupMockTaskActivityRepo != nil {
+				tt.setupMockTaskActivityRepo(mockTaskActivityRepo)
+			}
 
 			// run
-			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, &transaction.Noop{})
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
 			err := uc.Delete(tt.args.ctx, tt.args.taskID, tt.args.userID)
 
 			// assert
@@ -634,3 +876,1563 @@ func TestDeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestShareTask(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		taskID       int
+		userID       int
+		targetUserID int
+		canEdit      bool
+	}
+
+	tests := []struct {
+		title                       string
+		args                        args
+		setupMockTaskRepo           func(*mock.MockTaskRepository)
+		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		setupMockTaskEventUsecase   func(*mock.MockTaskEventUsecase)
+		setupMockTaskActivityRepo   func(*mock.MockTaskActivityRepository)
+		wantError                   error
+	}{
+		{
+			"success",
+			args{
+				ctx:          context.TODO(),
+				taskID:       1,
+				userID:       1,
+				targetUserID: 2,
+				canEdit:      false,
+			},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "test title"}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 2).
+					Return(nil, myerror.ErrPermissionNotFound)
+				mockTaskPermissionRepo.EXPECT().GrantPermission(context.TODO(), &domain.TaskPermission{
+					TaskID:  1,
+					UserID:  2,
+					CanEdit: false,
+					CanRead: true,
+				}).Return(nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventPermissionGranted, domain.Task{ID: 1, Title: "test title"}).
+					Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityPermissionGranted,
+					Changes: map[string]domain.FieldChange{
+						"permission": {After: map[string]any{"userID": 2, "canEdit": false, "canRead": true}},
+					},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"success already shared",
+			args{
+				ctx:          context.TODO(),
+				taskID:       1,
+				userID:       1,
+				targetUserID: 2,
+				canEdit:      true,
+			},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "test title"}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 2).
+					Return(&domain.TaskPermission{ID: 5, TaskID: 1, UserID: 2, CanRead: true}, nil)
+				mockTaskPermissionRepo.EXPECT().GrantPermission(context.TODO(), &domain.TaskPermission{
+					ID:      5,
+					TaskID:  1,
+					UserID:  2,
+					CanEdit: true,
+					CanRead: true,
+				}).Return(nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventPermissionGranted, domain.Task{ID: 1, Title: "test title"}).
+					Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityPermissionGranted,
+					Changes: map[string]domain.FieldChange{
+						"permission": {
+							Before: map[string]any{"userID": 2, "canEdit": false, "canRead": true},
+							After:  map[string]any{"userID": 2, "canEdit": true, "canRead": true},
+						},
+					},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"share task permission denied",
+			args{
+				ctx:          context.TODO(),
+				taskID:       1,
+				userID:       1,
+				targetUserID: 2,
+				canEdit:      false,
+			},
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: false}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+		{
+			"grant permission failed",
+			args{
+				ctx:          context.TODO(),
+				taskID:       1,
+				userID:       1,
+				targetUserID: 2,
+				canEdit:      false,
+			},
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 2).
+					Return(nil, myerror.ErrPermissionNotFound)
+				mockTaskPermissionRepo.EXPECT().GrantPermission(context.TODO(), gomock.Any()).
+					Return(myerror.ErrGrantPermission)
+			},
+			nil,
+			nil,
+			myerror.ErrGrantPermission,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			if tt.setupMockTaskRepo != nil {
+				tt.setupMockTaskRepo(mockTaskRepo)
+			}
+			if tt.setupMockTaskPermissionRepo != nil {
+				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
+			}
+			if tt.setupMockTaskEventUsecase != nil {
+				tt.setupMockTaskEventUsecase(mockTaskEventUsecase)
+			}
+			if tt.setupMockTaskActivityRepo != nil {
+				tt.setupMockTaskActivityRepo(mockTaskActivityRepo)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			err := uc.Share(tt.args.ctx, tt.args.taskID, tt.args.userID, tt.args.targetUserID, tt.args.canEdit)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAssignTask(t *testing.T) {
+	archivedAt := time.Now()
+
+	tests := []struct {
+		title                       string
+		assigneeIDs                 []int
+		setupMockTaskRepo           func(*mock.MockTaskRepository)
+		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		setupMockTaskEventUsecase   func(*mock.MockTaskEventUsecase)
+		setupMockTaskActivityRepo   func(*mock.MockTaskActivityRepository)
+		wantError                   error
+	}{
+		{
+			"the new assignee is granted read access",
+			[]int{3, 2},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Assignees: domain.IntList{3}}, nil)
+				mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{"assignees": domain.IntList{2, 3}}).
+					Return(nil)
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Assignees: domain.IntList{2, 3}}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 2).
+					Return(nil, myerror.ErrPermissionNotFound)
+				mockTaskPermissionRepo.EXPECT().GrantPermission(context.TODO(), &domain.TaskPermission{
+					TaskID:  1,
+					UserID:  2,
+					CanRead: true,
+				}).Return(nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventAssigned,
+					domain.Task{ID: 1, Assignees: domain.IntList{2, 3}}).Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityPermissionGranted,
+					Changes: map[string]domain.FieldChange{
+						"permission": {After: map[string]any{"userID": 2, "canEdit": false, "canRead": true}},
+					},
+				}).Return(nil)
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityAssigned,
+					Changes: map[string]domain.FieldChange{
+						"assignees": {Before: []int{3}, After: []int{2, 3}},
+					},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"the permission of the assignee is kept",
+			[]int{2},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Assignees: domain.IntList{3}}, nil)
+				mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{"assignees": domain.IntList{2}}).
+					Return(nil)
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Assignees: domain.IntList{2}}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 2).
+					Return(&domain.TaskPermission{TaskID: 1, UserID: 2, CanRead: true, CanEdit: true}, nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventAssigned,
+					domain.Task{ID: 1, Assignees: domain.IntList{2}}).Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityAssigned,
+					Changes: map[string]domain.FieldChange{
+						"assignees": {Before: []int{3}, After: []int{2}},
+					},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"unchanged assignees",
+			[]int{3, 3},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Assignees: domain.IntList{3}}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"archived task",
+			[]int{2},
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, ArchivedAt: &archivedAt}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrTaskArchived,
+		},
+		{
+			"read-only user",
+			[]int{2},
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: false}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			if tt.setupMockTaskRepo != nil {
+				tt.setupMockTaskRepo(mockTaskRepo)
+			}
+			if tt.setupMockTaskPermissionRepo != nil {
+				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
+			}
+			if tt.setupMockTaskEventUsecase != nil {
+				tt.setupMockTaskEventUsecase(mockTaskEventUsecase)
+			}
+			if tt.setupMockTaskActivityRepo != nil {
+				tt.setupMockTaskActivityRepo(mockTaskActivityRepo)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			err := uc.Assign(context.TODO(), 1, 1, tt.assigneeIDs)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetTaskLabels(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskRepo := getMockTaskRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+	mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+	mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+	mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+		Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil).Times(2)
+	mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+		Return(&domain.Task{ID: 1, Labels: domain.StringList{"backend"}}, nil)
+	mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{"labels": domain.StringList{"doing", "backend"}}).
+		Return(nil)
+	mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+		Return(&domain.Task{ID: 1, Labels: domain.StringList{"doing", "backend"}}, nil)
+	mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventUpdated,
+		domain.Task{ID: 1, Labels: domain.StringList{"doing", "backend"}}).Return(nil)
+	mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+		TaskID:  1,
+		ActorID: 1,
+		Action:  domain.TaskActivityLabeled,
+		Changes: map[string]domain.FieldChange{
+			"labels": {Before: []string{"backend"}, After: []string{"doing", "backend"}},
+		},
+	}).Return(nil)
+	// unchanged labels
+	mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+		Return(&domain.Task{ID: 1, Labels: domain.StringList{"doing", "backend"}}, nil)
+
+	uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+
+	// the labels are trimmed and the duplicates dropped
+	assert.NoError(t, uc.SetLabels(context.TODO(), 1, 1, []string{" doing", "backend", "doing", ""}))
+	assert.NoError(t, uc.SetLabels(context.TODO(), 1, 1, []string{"doing", "backend"}))
+}
+
+func TestSetTaskLabelsReadOnly(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+
+	mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+		Return(&domain.TaskPermission{CanRead: true, CanEdit: false}, nil)
+
+	// run
+	uc := usecase.NewTaskUsecase(getMockTaskRepository(ctrl), mockTaskPermissionRepo, getMockTaskActivityRepository(ctrl), getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), getMockTaskEventUsecase(ctrl), &transaction.Noop{})
+	err := uc.SetLabels(context.TODO(), 1, 1, []string{"doing"})
+
+	// assert
+	assert.Equal(t, myerror.ErrPermissionDenied, err)
+}
+
+func TestAddSubtask(t *testing.T) {
+	dueDate := domain.MustDateOnly("2024-12-31")
+	parentID := 1
+
+	tests := []struct {
+		title                       string
+		setupMockTaskRepo           func(*mock.MockTaskRepository)
+		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		wantID                      int
+		wantError                   error
+	}{
+		{
+			"success",
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, DueDate: dueDate, Priority: domain.TaskPriorityHigh}, nil)
+				mockTaskRepo.EXPECT().Create(context.TODO(), &domain.Task{
+					Title:       "step 1",
+					Description: "first",
+					CreatedBy:   1,
+					ParentID:    &parentID,
+					DueDate:     dueDate,
+					Priority:    domain.TaskPriorityNone,
+				}).Return(2, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+				mockTaskPermissionRepo.EXPECT().GrantPermission(context.TODO(),
+					&domain.TaskPermission{TaskID: 2, UserID: 1, CanEdit: true, CanRead: true}).Return(nil)
+			},
+			2,
+			nil,
+		},
+		{
+			"subtask of a subtask",
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, ParentID: &parentID}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			0,
+			myerror.ErrValidation,
+		},
+		{
+			"read only",
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true}, nil)
+			},
+			0,
+			myerror.ErrPermissionDenied,
+		},
+		{
+			"permission not found",
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(nil, myerror.ErrPermissionNotFound)
+			},
+			0,
+			myerror.ErrPermissionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			if tt.setupMockTaskRepo != nil {
+				tt.setupMockTaskRepo(mockTaskRepo)
+			}
+			tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
+			if tt.wantError == nil {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventCreated, gomock.Any()).Return(nil)
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			id, err := uc.AddSubtask(context.TODO(), 1, 1, "step 1", "first")
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantID, id)
+			}
+		})
+	}
+}
+
+func testCustomFields() []domain.CustomField {
+	return []domain.CustomField{
+		{Key: "customer", Type: domain.CustomFieldText},
+		{Key: "points", Type: domain.CustomFieldNumber},
+		{Key: "release", Type: domain.CustomFieldDate},
+		{Key: "env", Type: domain.CustomFieldSelect, Options: domain.StringList{"dev", "prod"}},
+		{Key: "platforms", Type: domain.CustomFieldMultiSelect, Options: domain.StringList{"ios", "android", "web"}},
+		{Key: "reviewer", Type: domain.CustomFieldUser},
+	}
+}
+
+func TestCreateTaskWithCustomFields(t *testing.T) {
+	tests := []struct {
+		title        string
+		customFields domain.CustomFieldValues
+		wantFields   domain.CustomFieldValues
+		wantError    error
+	}{
+		{
+			"values of every type",
+			domain.CustomFieldValues{
+				"customer":  "acme",
+				"points":    float64(3),
+				"release":   "2024-12-31",
+				"env":       "prod",
+				"platforms": []any{"ios", "web", "ios"},
+				"reviewer":  float64(2),
+			},
+			domain.CustomFieldValues{
+				"customer":  "acme",
+				"points":    float64(3),
+				"release":   "2024-12-31",
+				"env":       "prod",
+				"platforms": []string{"ios", "web"},
+				"reviewer":  2,
+			},
+			nil,
+		},
+		{
+			"a null value is dropped",
+			domain.CustomFieldValues{"customer": "acme", "points": nil},
+			domain.CustomFieldValues{"customer": "acme"},
+			nil,
+		},
+		{
+			"unknown field",
+			domain.CustomFieldValues{"severity": "high"},
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"not an option",
+			domain.CustomFieldValues{"env": "staging"},
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"invalid date",
+			domain.CustomFieldValues{"release": "31/12/2024"},
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"number given as a string",
+			domain.CustomFieldValues{"points": "3"},
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"user ID with a fraction",
+			domain.CustomFieldValues{"reviewer": 1.5},
+			nil,
+			myerror.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockCustomFieldRepo := getMockCustomFieldRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			mockCustomFieldRepo.EXPECT().FetchFields(context.TODO()).Return(testCustomFields(), nil)
+			if tt.wantError == nil {
+				mockTaskRepo.EXPECT().Create(context.TODO(), &domain.Task{
+					Title:        "test title",
+					Description:  "test description",
+					CreatedBy:    1,
+					DueDate:      AnyDate,
+					Priority:     domain.TaskPriorityNone,
+					CustomFields: tt.wantFields,
+				}).Return(1, nil)
+				mockTaskPermissionRepo.EXPECT().GrantPermission(context.TODO(), gomock.Any()).Return(nil)
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventCreated, gomock.Any()).Return(nil)
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, mockCustomFieldRepo, getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			_, err := uc.Create(context.TODO(), "test title", "test description", 1, AnyDate, "", nil, tt.customFields)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskCustomFields(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskRepo := getMockTaskRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+	mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+	mockCustomFieldRepo := getMockCustomFieldRepository(ctrl)
+	mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+	dueDate := domain.MustDateOnly("2024-12-31")
+	before := &domain.Task{ID: 1, Title: "title", DueDate: dueDate, Priority: domain.TaskPriorityNone,
+		CustomFields: domain.CustomFieldValues{"customer": "acme", "points": float64(3)}}
+	after := &domain.Task{ID: 1, Title: "title", DueDate: dueDate, Priority: domain.TaskPriorityNone,
+		CustomFields: domain.CustomFieldValues{"customer": "acme", "env": "dev"}}
+
+	mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+		Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+	mockCustomFieldRepo.EXPECT().FetchFields(context.TODO()).Return(testCustomFields(), nil)
+	mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).Return(before, nil)
+	// the values of the other fields are kept and a null value is removed
+	mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{
+		"title":            "title",
+		"description":      "",
+		"due_date":         dueDate,
+		"priority":         domain.TaskPriorityNone,
+		"estimate_minutes": (*int)(nil),
+		"custom_fields":    domain.CustomFieldValues{"customer": "acme", "env": "dev"},
+	}).Return(nil)
+	mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).Return(after, nil)
+	mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventUpdated, *after).Return(nil)
+	mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+		TaskID:  1,
+		ActorID: 1,
+		Action:  domain.TaskActivityUpdated,
+		Changes: map[string]domain.FieldChange{
+			"customFields": {
+				Before: map[string]any{"customer": "acme", "points": float64(3)},
+				After:  map[string]any{"customer": "acme", "env": "dev"},
+			},
+		},
+	}).Return(nil)
+
+	uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, mockCustomFieldRepo, getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+
+	assert.NoError(t, uc.Update(context.TODO(), 1, 1, "title", "", dueDate, "", nil,
+		domain.CustomFieldValues{"points": nil, "env": "dev"}))
+}
+
+func TestFetchAllTaskByCustomField(t *testing.T) {
+	tests := []struct {
+		title       string
+		filter      domain.TaskFilter
+		wantMatches []domain.CustomFieldValues
+		wantError   error
+	}{
+		{
+			"typed by the fields",
+			domain.TaskFilter{Field: []string{"points:3", "platforms:ios", "reviewer:me", "release:2024-12-31"}, SortField: "points"},
+			[]domain.CustomFieldValues{
+				{"points": float64(3)},
+				{"platforms": []string{"ios"}},
+				{"reviewer": 1},
+				{"release": "2024-12-31"},
+			},
+			nil,
+		},
+		{
+			"unknown field",
+			domain.TaskFilter{Field: []string{"severity:high"}},
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"unknown sort field",
+			domain.TaskFilter{SortField: "severity"},
+			nil,
+			myerror.ErrValidation,
+		},
+		{
+			"not a number",
+			domain.TaskFilter{Field: []string{"points:many"}},
+			nil,
+			myerror.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockCustomFieldRepo := getMockCustomFieldRepository(ctrl)
+
+			mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, true, true).Return([]int{1, 2}, nil)
+			mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, false, true).Return(nil, nil)
+			mockCustomFieldRepo.EXPECT().FetchFields(context.TODO()).Return(testCustomFields(), nil)
+			if tt.wantError == nil {
+				filter := tt.filter
+				filter.FieldMatches = tt.wantMatches
+				mockTaskRepo.EXPECT().FetchAllTaskByTaskID(context.TODO(), 1, filter, 1, 2).Return(nil, nil)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, getMockTaskActivityRepository(ctrl),
+				mockCustomFieldRepo, getMockUserSettingRepository(ctrl), getMockTaskEventUsecase(ctrl), &transaction.Noop{})
+			_, err := uc.FetchAllTaskByUserID(context.TODO(), 1, tt.filter)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFetchAllOverdueTaskInUserTimezone(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskRepo := getMockTaskRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+	mockUserSettingRepo := getMockUserSettingRepository(ctrl)
+
+	setting := domain.NewUserSetting(1)
+	setting.Timezone = "Pacific/Kiritimati"
+	loc, _ := time.LoadLocation(setting.Timezone)
+	filter := domain.TaskFilter{Overdue: true, Today: domain.Today(loc)}
+
+	mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, true, true).Return([]int{1, 2}, nil)
+	mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, false, true).Return(nil, nil)
+	mockUserSettingRepo.EXPECT().FetchSettingByUserID(context.TODO(), 1).Return(setting, nil)
+	mockTaskRepo.EXPECT().FetchAllTaskByTaskID(context.TODO(), 1, filter, 1, 2).Return(nil, nil)
+
+	// run
+	uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, getMockTaskActivityRepository(ctrl),
+		getMockCustomFieldRepository(ctrl), mockUserSettingRepo, getMockTaskEventUsecase(ctrl), &transaction.Noop{})
+	_, err := uc.FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{Overdue: true})
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestRestoreTask(t *testing.T) {
+	tests := []struct {
+		title                       string
+		setupMockTaskRepo           func(*mock.MockTaskRepository)
+		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		setupMockTaskEventUsecase   func(*mock.MockTaskEventUsecase)
+		setupMockTaskActivityRepo   func(*mock.MockTaskActivityRepository)
+		wantError                   error
+	}{
+		{
+			"success",
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().Restore(context.TODO(), 1).Return(nil)
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, Title: "test title"}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventRestored, domain.Task{ID: 1, Title: "test title"}).
+					Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityRestored,
+					Changes: map[string]domain.FieldChange{},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"permission denied",
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: false}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+		{
+			"task is not in the trash",
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().Restore(context.TODO(), 1).Return(myerror.ErrTaskNotFound)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			if tt.setupMockTaskRepo != nil {
+				tt.setupMockTaskRepo(mockTaskRepo)
+			}
+			if tt.setupMockTaskPermissionRepo != nil {
+				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
+			}
+			if tt.setupMockTaskEventUsecase != nil {
+				tt.setupMockTaskEventUsecase(mockTaskEventUsecase)
+			}
+			if tt.setupMockTaskActivityRepo != nil {
+				tt.setupMockTaskActivityRepo(mockTaskActivityRepo)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			err := uc.Restore(context.TODO(), 1, 1)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeDeletedTask(t *testing.T) {
+	tests := []struct {
+		title      string
+		taskIDs    []int
+		wantPurged int
+	}{
+		{"purge expired tasks", []int{1, 2}, 2},
+		{"nothing to purge", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			mockTaskRepo.EXPECT().FetchTaskIDsDeletedBefore(context.TODO(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, before time.Time, _ int) ([]int, error) {
+					// the tasks deleted within the retention stay in the trash
+					assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+					return tt.taskIDs, nil
+				})
+			if len(tt.taskIDs) > 0 {
+				gomock.InOrder(
+					mockTaskPermissionRepo.EXPECT().DeleteByTaskID(context.TODO(), 1, 2).Return(nil),
+					mockTaskActivityRepo.EXPECT().DeleteByTaskID(context.TODO(), 1, 2).Return(nil),
+					mockTaskRepo.EXPECT().Purge(context.TODO(), 1, 2).Return(nil),
+				)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			n, err := uc.PurgeDeleted(context.TODO(), 24*time.Hour)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPurged, n)
+		})
+	}
+}
+
+func TestArchiveTask(t *testing.T) {
+	archivedAt := time.Now()
+
+	tests := []struct {
+		title                       string
+		archived                    bool
+		setupMockTaskRepo           func(*mock.MockTaskRepository)
+		setupMockTaskPermissionRepo func(*mock.MockTaskPermissionRepository)
+		setupMockTaskEventUsecase   func(*mock.MockTaskEventUsecase)
+		setupMockTaskActivityRepo   func(*mock.MockTaskActivityRepository)
+		wantError                   error
+	}{
+		{
+			"archive task",
+			true,
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				gomock.InOrder(
+					mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+						Return(&domain.Task{ID: 1, Completed: true}, nil),
+					mockTaskRepo.EXPECT().Update(context.TODO(), 1, gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ int, updateFields map[string]any) error {
+							assert.NotNil(t, updateFields["archived_at"])
+							return nil
+						}),
+					mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+						Return(&domain.Task{ID: 1, Completed: true, ArchivedAt: &archivedAt}, nil),
+				)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventArchived,
+					domain.Task{ID: 1, Completed: true, ArchivedAt: &archivedAt}).Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityArchived,
+					Changes: map[string]domain.FieldChange{"archived": {Before: false, After: true}},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"unarchive task",
+			false,
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				gomock.InOrder(
+					mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+						Return(&domain.Task{ID: 1, ArchivedAt: &archivedAt}, nil),
+					mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{"archived_at": (*time.Time)(nil)}).
+						Return(nil),
+					mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+						Return(&domain.Task{ID: 1}, nil),
+				)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			func(mockTaskEventUsecase *mock.MockTaskEventUsecase) {
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventUnarchived, domain.Task{ID: 1}).
+					Return(nil)
+			},
+			func(mockTaskActivityRepo *mock.MockTaskActivityRepository) {
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), &domain.TaskActivity{
+					TaskID:  1,
+					ActorID: 1,
+					Action:  domain.TaskActivityUnarchived,
+					Changes: map[string]domain.FieldChange{"archived": {Before: true, After: false}},
+				}).Return(nil)
+			},
+			nil,
+		},
+		{
+			"task is already archived",
+			true,
+			func(mockTaskRepo *mock.MockTaskRepository) {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+					Return(&domain.Task{ID: 1, ArchivedAt: &archivedAt}, nil)
+			},
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"permission denied",
+			true,
+			nil,
+			func(mockTaskPermissionRepo *mock.MockTaskPermissionRepository) {
+				mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+					Return(&domain.TaskPermission{CanRead: true, CanEdit: false}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			if tt.setupMockTaskRepo != nil {
+				tt.setupMockTaskRepo(mockTaskRepo)
+			}
+			if tt.setupMockTaskPermissionRepo != nil {
+				tt.setupMockTaskPermissionRepo(mockTaskPermissionRepo)
+			}
+			if tt.setupMockTaskEventUsecase != nil {
+				tt.setupMockTaskEventUsecase(mockTaskEventUsecase)
+			}
+			if tt.setupMockTaskActivityRepo != nil {
+				tt.setupMockTaskActivityRepo(mockTaskActivityRepo)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			err := uc.Archive(context.TODO(), 1, 1, tt.archived)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCompleteTask(t *testing.T) {
+	tests := []struct {
+		title         string
+		completed     bool
+		permission    *domain.TaskPermission
+		wantEventType domain.TaskEventType
+		wantAction    domain.TaskActivityAction
+		wantError     error
+	}{
+		{"complete", true, &domain.TaskPermission{CanRead: true, CanEdit: true}, domain.TaskEventCompleted, domain.TaskActivityCompleted, nil},
+		{"reopen", false, &domain.TaskPermission{CanRead: true, CanEdit: true}, domain.TaskEventUpdated, domain.TaskActivityReopened, nil},
+		{"read only", true, &domain.TaskPermission{CanRead: true, CanEdit: false}, "", "", myerror.ErrPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).Return(tt.permission, nil)
+			if tt.wantError == nil {
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).Return(&domain.Task{ID: 1, Completed: !tt.completed}, nil)
+				mockTaskRepo.EXPECT().Update(context.TODO(), 1, gomock.Any()).Return(nil)
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).Return(&domain.Task{ID: 1, Completed: tt.completed}, nil)
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), tt.wantEventType, domain.Task{ID: 1, Completed: tt.completed}).Return(nil)
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, activity *domain.TaskActivity) error {
+						assert.Equal(t, tt.wantAction, activity.Action)
+						assert.Equal(t, map[string]domain.FieldChange{
+							"completed": {Before: !tt.completed, After: tt.completed},
+						}, activity.Changes)
+						return nil
+					})
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			err := uc.Complete(context.TODO(), 1, 1, tt.completed)
+
+			// assert
+			assert.Equal(t, tt.wantError, err)
+		})
+	}
+}
+
+func TestUpdateArchivedTask(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskRepo := getMockTaskRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+	mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+	mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+	archivedAt := time.Now()
+	mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+		Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+	mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+		Return(&domain.Task{ID: 1, ArchivedAt: &archivedAt}, nil)
+
+	// run
+	uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+	err := uc.Update(context.TODO(), 1, 1, "title", "description", domain.MustDateOnly("2024-12-31"), "", nil, nil)
+
+	// assert
+	assert.Equal(t, myerror.ErrTaskArchived, err)
+}
+
+func TestAutoArchiveTask(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskRepo := getMockTaskRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+	mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+	mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+	mockTaskRepo.EXPECT().FetchTaskIDsToAutoArchive(context.TODO(), 1, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, completedBefore time.Time) ([]int, error) {
+			// only the tasks completed more than 7 days ago are archived
+			assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), completedBefore, time.Minute)
+			return []int{2, 3}, nil
+		})
+	for _, taskID := range []int{2, 3} {
+		mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), taskID).
+			Return(&domain.Task{ID: taskID, Completed: true}, nil).Times(2)
+		mockTaskRepo.EXPECT().Update(context.TODO(), taskID, gomock.Any()).Return(nil)
+	}
+	mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventArchived, gomock.Any()).
+		Return(nil).Times(2)
+	mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil).Times(2)
+
+	// run
+	uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+	n, err := uc.AutoArchive(context.TODO(), 1, 7*24*time.Hour)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestSearchTask(t *testing.T) {
+	tests := []struct {
+		title       string
+		editableIDs []int
+		readOnlyIDs []int
+		limit       int
+		wantLimit   int
+		wantResults []domain.TaskSearchResult
+	}{
+		{"default limit", []int{1, 2}, nil, 0, 20, []domain.TaskSearchResult{{Task: domain.Task{ID: 1}, Rank: 0.5}}},
+		{"limit given", []int{1, 2}, nil, 5, 5, []domain.TaskSearchResult{{Task: domain.Task{ID: 1}, Rank: 0.5}}},
+		{"shared read-only", []int{1}, []int{2}, 0, 20, []domain.TaskSearchResult{{Task: domain.Task{ID: 2}, Rank: 0.5}}},
+		{"no tasks to search", nil, nil, 0, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, true, true).
+				Return(tt.editableIDs, nil)
+			mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, false, true).
+				Return(tt.readOnlyIDs, nil)
+			if len(tt.editableIDs)+len(tt.readOnlyIDs) > 0 {
+				mockTaskRepo.EXPECT().Search(context.TODO(), "milk", tt.wantLimit, 1, 2).
+					Return(tt.wantResults, nil)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			results, err := uc.Search(context.TODO(), 1, "milk", tt.limit)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResults, results)
+		})
+	}
+}
+
+func TestBulkTask(t *testing.T) {
+	tests := []struct {
+		title        string
+		allOrNothing bool
+		wantResults  []domain.TaskBulkResult
+	}{
+		{
+			"report the failure of each task",
+			false,
+			[]domain.TaskBulkResult{
+				{TaskID: 1, Status: domain.TaskBulkSucceeded},
+				{TaskID: 2, Status: domain.TaskBulkFailed, Error: &domain.ErrorItem{
+					Code:    int(myerror.CodePermissionDenied),
+					Message: myerror.ErrMessages[myerror.CodePermissionDenied],
+				}},
+			},
+		},
+		{
+			"all or nothing",
+			true,
+			[]domain.TaskBulkResult{
+				{TaskID: 1, Status: domain.TaskBulkRolledBack},
+				{TaskID: 2, Status: domain.TaskBulkFailed, Error: &domain.ErrorItem{
+					Code:    int(myerror.CodePermissionDenied),
+					Message: myerror.ErrMessages[myerror.CodePermissionDenied],
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			// the user can edit task 1 but only read task 2
+			mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+				Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil)
+			mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 2, 1).
+				Return(&domain.TaskPermission{CanRead: true, CanEdit: false}, nil)
+			mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).
+				Return(&domain.Task{ID: 1}, nil)
+			mockTaskRepo.EXPECT().Delete(context.TODO(), 1).Return(nil)
+			mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventDeleted, domain.Task{ID: 1}).Return(nil)
+			mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			results, err := uc.Bulk(context.TODO(), 1, []int{1, 2}, nil,
+				domain.TaskBulkOperation{Action: domain.TaskBulkDelete}, tt.allOrNothing)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResults, results)
+		})
+	}
+}
+
+func TestBulkTaskLabels(t *testing.T) {
+	archivedAt := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		title       string
+		action      domain.TaskBulkAction
+		task        domain.Task
+		wantLabels  domain.StringList
+		wantResults []domain.TaskBulkResult
+	}{
+		{
+			"add a label",
+			domain.TaskBulkAddLabel,
+			domain.Task{ID: 1, Labels: domain.StringList{"backend"}},
+			domain.StringList{"backend", "urgent"},
+			[]domain.TaskBulkResult{{TaskID: 1, Status: domain.TaskBulkSucceeded}},
+		},
+		{
+			"remove a label",
+			domain.TaskBulkRemoveLabel,
+			domain.Task{ID: 1, Labels: domain.StringList{"urgent", "backend"}},
+			domain.StringList{"backend"},
+			[]domain.TaskBulkResult{{TaskID: 1, Status: domain.TaskBulkSucceeded}},
+		},
+		{
+			"archived task",
+			domain.TaskBulkAddLabel,
+			domain.Task{ID: 1, Labels: domain.StringList{"backend"}, ArchivedAt: &archivedAt},
+			nil,
+			[]domain.TaskBulkResult{{TaskID: 1, Status: domain.TaskBulkFailed, Error: &domain.ErrorItem{
+				Code:    int(myerror.CodeTaskArchived),
+				Message: myerror.ErrMessages[myerror.CodeTaskArchived],
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), 1, 1).
+				Return(&domain.TaskPermission{CanRead: true, CanEdit: true}, nil).Times(2)
+			mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).Return(&tt.task, nil).Times(2)
+			if tt.wantLabels != nil {
+				updated := tt.task
+				updated.Labels = tt.wantLabels
+				mockTaskRepo.EXPECT().Update(context.TODO(), 1, map[string]any{"labels": tt.wantLabels}).Return(nil)
+				mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), 1).Return(&updated, nil)
+				mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventUpdated, updated).Return(nil)
+				mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+			}
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			results, err := uc.Bulk(context.TODO(), 1, []int{1}, nil,
+				domain.TaskBulkOperation{Action: tt.action, Label: " urgent "}, false)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResults, results)
+		})
+	}
+}
+
+func TestBulkTaskValidation(t *testing.T) {
+	tests := []struct {
+		title string
+		op    domain.TaskBulkOperation
+	}{
+		{"move due date without date", domain.TaskBulkOperation{Action: domain.TaskBulkMoveDueDate}},
+		{"share without user", domain.TaskBulkOperation{Action: domain.TaskBulkShare}},
+		{"share with yourself", domain.TaskBulkOperation{Action: domain.TaskBulkShare, UserID: 1}},
+		{"add label without label", domain.TaskBulkOperation{Action: domain.TaskBulkAddLabel, Label: " "}},
+		{"remove label without label", domain.TaskBulkOperation{Action: domain.TaskBulkRemoveLabel}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// run
+			uc := usecase.NewTaskUsecase(getMockTaskRepository(ctrl), getMockTaskPermissionRepository(ctrl),
+				getMockTaskActivityRepository(ctrl), getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), getMockTaskEventUsecase(ctrl), &transaction.Noop{})
+			results, err := uc.Bulk(context.TODO(), 1, []int{1}, nil, tt.op, false)
+
+			// assert
+			assert.Nil(t, results)
+			assert.ErrorIs(t, err, myerror.ErrValidation)
+		})
+	}
+}
+
+func TestImportTask(t *testing.T) {
+	records := []domain.TaskImportRecord{
+		{Row: 1, Title: "buy milk", Description: "low fat", DueDate: "2024-12-31"},
+		{Row: 2, Title: "write report", Description: "weekly", DueDate: "2025-01-10"},
+	}
+
+	tests := []struct {
+		title       string
+		records     []domain.TaskImportRecord
+		dryRun      bool
+		wantCreated int
+		wantResults []domain.TaskImportResult
+	}{
+		{
+			"import all rows",
+			records,
+			false,
+			2,
+			[]domain.TaskImportResult{
+				{Row: 1, Status: domain.TaskImportCreated},
+				{Row: 2, Status: domain.TaskImportCreated},
+			},
+		},
+		{
+			"dry run",
+			records,
+			true,
+			0,
+			[]domain.TaskImportResult{
+				{Row: 1, Status: domain.TaskImportValid},
+				{Row: 2, Status: domain.TaskImportValid},
+			},
+		},
+		{
+			"nothing is imported when a row is invalid",
+			append(records[:1:1], domain.TaskImportRecord{Row: 2, Title: "", Description: "weekly", DueDate: "2025/01/10"}),
+			false,
+			0,
+			[]domain.TaskImportResult{
+				{Row: 1, Status: domain.TaskImportValid},
+				{Row: 2, Status: domain.TaskImportInvalid, Errors: []string{"dueDate: expect format yyyy-mm-dd", "title: required"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			// every imported task gets the creator permission
+			mockTaskRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(1, nil).Times(tt.wantCreated)
+			mockTaskPermissionRepo.EXPECT().GrantPermission(context.TODO(), gomock.Any()).Return(nil).Times(tt.wantCreated)
+			mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventCreated, gomock.Any()).Return(nil).Times(tt.wantCreated)
+			mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil).Times(tt.wantCreated)
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			results, err := uc.Import(context.TODO(), 1, tt.records, tt.dryRun)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResults, results)
+		})
+	}
+}
+
+func TestImportMatchedTask(t *testing.T) {
+	completed, notCompleted := true, false
+	tasks := map[int]*domain.Task{
+		1: {ID: 1, Title: "buy milk"},
+		2: {ID: 2, Title: "write report", Completed: true},
+	}
+	permissions := map[int]*domain.TaskPermission{
+		1: {TaskID: 1, UserID: 1, CanEdit: true, CanRead: true},
+		2: {TaskID: 2, UserID: 1, CanEdit: false, CanRead: true},
+	}
+
+	tests := []struct {
+		title       string
+		records     []domain.TaskImportRecord
+		wantUpdated int
+		wantResults []domain.TaskImportResult
+	}{
+		{
+			"completion is updated",
+			[]domain.TaskImportRecord{
+				{Row: 1, TaskID: 1, Title: "buy milk", Completed: &completed},
+				{Row: 2, TaskID: 2, Title: "write report", Completed: &completed},
+			},
+			1,
+			[]domain.TaskImportResult{
+				{Row: 1, Status: domain.TaskImportUpdated},
+				{Row: 2, Status: domain.TaskImportUnchanged},
+			},
+		},
+		{
+			"read-only and unknown tasks are invalid",
+			[]domain.TaskImportRecord{
+				{Row: 1, TaskID: 2, Title: "write report", Completed: &notCompleted},
+				{Row: 2, TaskID: 3, Title: "unknown", Completed: &completed},
+			},
+			0,
+			[]domain.TaskImportResult{
+				{Row: 1, Status: domain.TaskImportInvalid, Errors: []string{"completed: permission denied"}},
+				{Row: 2, Status: domain.TaskImportInvalid, Errors: []string{"id: task not found"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskRepo := getMockTaskRepository(ctrl)
+			mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+			mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+			mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+			mockTaskPermissionRepo.EXPECT().FetchPermissionByTaskID(context.TODO(), gomock.Any(), 1).
+				DoAndReturn(func(_ context.Context, taskID, _ int) (*domain.TaskPermission, error) {
+					if permission, ok := permissions[taskID]; ok {
+						return permission, nil
+					}
+					return nil, myerror.ErrPermissionNotFound
+				}).AnyTimes()
+			mockTaskRepo.EXPECT().FetchTaskByTaskID(context.TODO(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, taskID int) (*domain.Task, error) {
+					task := *tasks[taskID]
+					return &task, nil
+				}).AnyTimes()
+			mockTaskRepo.EXPECT().Update(context.TODO(), 1, gomock.Any()).Return(nil).Times(tt.wantUpdated)
+			mockTaskEventUsecase.EXPECT().Publish(context.TODO(), domain.TaskEventCompleted, gomock.Any()).Return(nil).Times(tt.wantUpdated)
+			mockTaskActivityRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil).Times(tt.wantUpdated)
+
+			// run
+			uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+			results, err := uc.Import(context.TODO(), 1, tt.records, false)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResults, results)
+		})
+	}
+}
+
+func TestExportTask(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskRepo := getMockTaskRepository(ctrl)
+	mockTaskPermissionRepo := getMockTaskPermissionRepository(ctrl)
+	mockTaskActivityRepo := getMockTaskActivityRepository(ctrl)
+	mockTaskEventUsecase := getMockTaskEventUsecase(ctrl)
+
+	// the tasks shared read-only are exported too
+	mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, true, true).Return([]int{1}, nil)
+	mockTaskPermissionRepo.EXPECT().FetchTaskIDByUserID(context.TODO(), 1, false, true).Return([]int{2}, nil)
+	mockTaskRepo.EXPECT().FetchAllTaskInBatches(context.TODO(), gomock.Any(), gomock.Any(), 1, 2).
+		DoAndReturn(func(_ context.Context, _ int, fn func([]domain.Task) error, _ ...int) error {
+			return fn([]domain.Task{{ID: 1}, {ID: 2}})
+		})
+
+	// run
+	var exported []domain.Task
+	uc := usecase.NewTaskUsecase(mockTaskRepo, mockTaskPermissionRepo, mockTaskActivityRepo, getMockCustomFieldRepository(ctrl), getMockUserSettingRepository(ctrl), mockTaskEventUsecase, &transaction.Noop{})
+	err := uc.Export(context.TODO(), 1, func(tasks []domain.Task) error {
+		exported = append(exported, tasks...)
+		return nil
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Task{{ID: 1}, {ID: 2}}, exported)
+}
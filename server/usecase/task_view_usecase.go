@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+)
+
+type taskViewUsecase struct {
+	taskViewRepository domain.TaskViewRepository
+	taskUsecase        domain.TaskUsecase
+}
+
+func NewTaskViewUsecase(taskViewRepo domain.TaskViewRepository, taskUsecase domain.TaskUsecase) domain.TaskViewUsecase {
+	return &taskViewUsecase{
+		taskViewRepository: taskViewRepo,
+		taskUsecase:        taskUsecase,
+	}
+}
+
+func (u *taskViewUsecase) Create(ctx context.Context, userID int, name string, filter domain.TaskFilter, shared bool) (*domain.TaskView, error) {
+	view := &domain.TaskView{
+		UserID: userID,
+		Name:   name,
+		Filter: filter,
+		Shared: shared,
+	}
+	if err := u.taskViewRepository.Create(ctx, view); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// FetchViewsByUserID returns the built-in views followed by the views saved by the user
+// and the views shared by the others.
+func (u *taskViewUsecase) FetchViewsByUserID(ctx context.Context, userID int) ([]domain.TaskView, error) {
+	views, err := u.taskViewRepository.FetchViewsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(domain.BuiltInTaskViews(), views...), nil
+}
+
+func (u *taskViewUsecase) Share(ctx context.Context, viewID, userID int, shared bool) (*domain.TaskView, error) {
+	v, err := u.fetchOwnedView(ctx, viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.taskViewRepository.SetShared(ctx, viewID, shared); err != nil {
+		return nil, err
+	}
+	v.Shared = shared
+	return v, nil
+}
+
+func (u *taskViewUsecase) Delete(ctx context.Context, viewID, userID int) error {
+	if _, err := u.fetchOwnedView(ctx, viewID, userID); err != nil {
+		return err
+	}
+	return u.taskViewRepository.Delete(ctx, viewID)
+}
+
+// FetchTasksByView lists the tasks matching the view, which is either the key of
+// a built-in view or the ID of a view saved by the user or shared by another user.
+// The view runs on the tasks the user can read, so a shared view never shows the
+// tasks of its owner to the others.
+func (u *taskViewUsecase) FetchTasksByView(ctx context.Context, view string, userID int) ([]domain.Task, error) {
+	for _, v := range domain.BuiltInTaskViews() {
+		if v.Key == view {
+			return u.taskUsecase.FetchAllTaskByUserID(ctx, userID, v.Filter)
+		}
+	}
+
+	viewID, err := strconv.Atoi(view)
+	if err != nil {
+		return nil, myerror.ErrTaskViewNotFound
+	}
+	v, err := u.taskViewRepository.FetchViewByID(ctx, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if v.UserID != userID && !v.Shared {
+		return nil, myerror.ErrPermissionDenied
+	}
+	return u.taskUsecase.FetchAllTaskByUserID(ctx, userID, v.Filter)
+}
+
+// fetchOwnedView returns the view saved by the user, the others cannot change a view
+// even when it is shared.
+func (u *taskViewUsecase) fetchOwnedView(ctx context.Context, viewID, userID int) (*domain.TaskView, error) {
+	v, err := u.taskViewRepository.FetchViewByID(ctx, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if v.UserID != userID {
+		return nil, myerror.ErrPermissionDenied
+	}
+	return v, nil
+}
@@ -0,0 +1,200 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFetchTasksByView(t *testing.T) {
+	tests := []struct {
+		title                 string
+		view                  string
+		setupMockTaskViewRepo func(*mock.MockTaskViewRepository)
+		setupMockTaskUsecase  func(*mock.MockTaskUsecase)
+		wantTasks             []domain.Task
+		wantError             error
+	}{
+		{
+			"built-in view",
+			"overdue",
+			nil,
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{Overdue: true}).
+					Return([]domain.Task{{ID: 1}}, nil)
+			},
+			[]domain.Task{{ID: 1}},
+			nil,
+		},
+		{
+			"saved view",
+			"3",
+			func(mockTaskViewRepo *mock.MockTaskViewRepository) {
+				mockTaskViewRepo.EXPECT().FetchViewByID(context.TODO(), 3).
+					Return(&domain.TaskView{ID: 3, UserID: 1, Filter: domain.TaskFilter{Owner: domain.TaskOwnerOthers}}, nil)
+			},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{Owner: domain.TaskOwnerOthers}).
+					Return([]domain.Task{{ID: 2}}, nil)
+			},
+			[]domain.Task{{ID: 2}},
+			nil,
+		},
+		{
+			"view saved by another user",
+			"3",
+			func(mockTaskViewRepo *mock.MockTaskViewRepository) {
+				mockTaskViewRepo.EXPECT().FetchViewByID(context.TODO(), 3).
+					Return(&domain.TaskView{ID: 3, UserID: 2}, nil)
+			},
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+		{
+			"view shared by another user",
+			"3",
+			func(mockTaskViewRepo *mock.MockTaskViewRepository) {
+				mockTaskViewRepo.EXPECT().FetchViewByID(context.TODO(), 3).
+					Return(&domain.TaskView{ID: 3, UserID: 2, Shared: true, Filter: domain.TaskFilter{Overdue: true}}, nil)
+			},
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				// the view runs on the tasks of the user running it
+				mockTaskUsecase.EXPECT().FetchAllTaskByUserID(context.TODO(), 1, domain.TaskFilter{Overdue: true}).
+					Return([]domain.Task{{ID: 4}}, nil)
+			},
+			[]domain.Task{{ID: 4}},
+			nil,
+		},
+		{
+			"unknown view",
+			"someday",
+			nil,
+			nil,
+			nil,
+			myerror.ErrTaskViewNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskViewRepo := mock.NewMockTaskViewRepository(ctrl)
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+			if tt.setupMockTaskViewRepo != nil {
+				tt.setupMockTaskViewRepo(mockTaskViewRepo)
+			}
+			if tt.setupMockTaskUsecase != nil {
+				tt.setupMockTaskUsecase(mockTaskUsecase)
+			}
+
+			// run
+			uc := usecase.NewTaskViewUsecase(mockTaskViewRepo, mockTaskUsecase)
+			tasks, err := uc.FetchTasksByView(context.TODO(), tt.view, 1)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTasks, tasks)
+			}
+		})
+	}
+}
+
+func TestFetchViewsByUserID(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskViewRepo := mock.NewMockTaskViewRepository(ctrl)
+	mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+	saved := domain.TaskView{ID: 3, UserID: 1, Name: "backend"}
+	mockTaskViewRepo.EXPECT().FetchViewsByUserID(context.TODO(), 1).Return([]domain.TaskView{saved}, nil)
+
+	// run
+	uc := usecase.NewTaskViewUsecase(mockTaskViewRepo, mockTaskUsecase)
+	views, err := uc.FetchViewsByUserID(context.TODO(), 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, append(domain.BuiltInTaskViews(), saved), views)
+}
+
+func TestShareView(t *testing.T) {
+	tests := []struct {
+		title                 string
+		view                  *domain.TaskView
+		shared                bool
+		setupMockTaskViewRepo func(*mock.MockTaskViewRepository)
+		wantView              *domain.TaskView
+		wantError             error
+	}{
+		{
+			"share",
+			&domain.TaskView{ID: 3, UserID: 1, Name: "backend"},
+			true,
+			func(mockTaskViewRepo *mock.MockTaskViewRepository) {
+				mockTaskViewRepo.EXPECT().SetShared(context.TODO(), 3, true).Return(nil)
+			},
+			&domain.TaskView{ID: 3, UserID: 1, Name: "backend", Shared: true},
+			nil,
+		},
+		{
+			"stop sharing",
+			&domain.TaskView{ID: 3, UserID: 1, Name: "backend", Shared: true},
+			false,
+			func(mockTaskViewRepo *mock.MockTaskViewRepository) {
+				mockTaskViewRepo.EXPECT().SetShared(context.TODO(), 3, false).Return(nil)
+			},
+			&domain.TaskView{ID: 3, UserID: 1, Name: "backend"},
+			nil,
+		},
+		{
+			"view shared by another user",
+			&domain.TaskView{ID: 3, UserID: 2, Name: "backend", Shared: true},
+			false,
+			nil,
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskViewRepo := mock.NewMockTaskViewRepository(ctrl)
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+
+			mockTaskViewRepo.EXPECT().FetchViewByID(context.TODO(), 3).Return(tt.view, nil)
+			if tt.setupMockTaskViewRepo != nil {
+				tt.setupMockTaskViewRepo(mockTaskViewRepo)
+			}
+
+			// run
+			uc := usecase.NewTaskViewUsecase(mockTaskViewRepo, mockTaskUsecase)
+			view, err := uc.Share(context.TODO(), 3, 1, tt.shared)
+
+			// assert
+			if tt.wantError != nil {
+				assert.Equal(t, tt.wantError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantView, view)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/transaction"
+)
+
+// timesheetMaxDays limits the period of a timesheet.
+const timesheetMaxDays = 366
+
+type timeEntryUsecase struct {
+	timeEntryRepository domain.TimeEntryRepository
+	taskUsecase         domain.TaskUsecase
+	transaction         transaction.Transaction
+}
+
+func NewTimeEntryUsecase(timeEntryRepo domain.TimeEntryRepository, taskUsecase domain.TaskUsecase,
+	transaction transaction.Transaction) domain.TimeEntryUsecase {
+	return &timeEntryUsecase{
+		timeEntryRepository: timeEntryRepo,
+		taskUsecase:         taskUsecase,
+		transaction:         transaction,
+	}
+}
+
+// Start starts the user's timer on the task. The timer running on another task is
+// stopped, a user tracks one task at a time. Starting the running timer again does nothing.
+func (u *timeEntryUsecase) Start(ctx context.Context, taskID, userID int) (*domain.TimeEntry, error) {
+	task, err := u.taskUsecase.FetchTaskByTaskID(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt != nil {
+		return nil, myerror.ErrTaskArchived
+	}
+
+	started, err := u.transaction.DoInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		now := time.Now()
+		running, err := u.timeEntryRepository.FetchRunningEntryByUserID(ctx, userID)
+		switch {
+		case err == nil && running.TaskID == taskID:
+			return running, nil
+		case err == nil:
+			if err := u.timeEntryRepository.Stop(ctx, running.ID, now); err != nil {
+				return nil, err
+			}
+		case !errors.Is(err, myerror.ErrTimeEntryNotFound):
+			return nil, err
+		}
+
+		entry := &domain.TimeEntry{TaskID: taskID, UserID: userID, StartedAt: now}
+		if err := u.timeEntryRepository.Create(ctx, entry); err != nil {
+			return nil, err
+		}
+		return entry, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return started.(*domain.TimeEntry), nil
+}
+
+// Stop stops the user's timer running on the task. The user can stop the timer
+// even after losing the access to the task.
+func (u *timeEntryUsecase) Stop(ctx context.Context, taskID, userID int) (*domain.TimeEntry, error) {
+	running, err := u.timeEntryRepository.FetchRunningEntryByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if running.TaskID != taskID {
+		return nil, myerror.ErrTimeEntryNotFound
+	}
+
+	now := time.Now()
+	if err := u.timeEntryRepository.Stop(ctx, running.ID, now); err != nil {
+		return nil, err
+	}
+	running.StoppedAt = &now
+	return running, nil
+}
+
+// FetchRunningEntry returns nil when the user has no running timer.
+func (u *timeEntryUsecase) FetchRunningEntry(ctx context.Context, userID int) (*domain.TimeEntry, error) {
+	running, err := u.timeEntryRepository.FetchRunningEntryByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrTimeEntryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return running, nil
+}
+
+// FetchEntriesByTaskID returns the entries of all the users who worked on the task, the latest first.
+func (u *timeEntryUsecase) FetchEntriesByTaskID(ctx context.Context, taskID, userID int) ([]domain.TimeEntry, error) {
+	if _, err := u.taskUsecase.FetchTaskByTaskID(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+	return u.timeEntryRepository.FetchEntriesByTaskID(ctx, taskID)
+}
+
+// FetchTimesheet sums the time tracked by the user per day and per task, the tasks
+// are listed from the one the user worked on the longest. An entry spanning midnight
+// is divided between the days, and a running timer counts up to now.
+func (u *timeEntryUsecase) FetchTimesheet(ctx context.Context, userID int, from, to domain.DateOnly) (*domain.Timesheet, error) {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	days := int(end.Sub(start) / (24 * time.Hour))
+	if days <= 0 {
+		return nil, myerror.ErrValidation.WithDescription("to must not be before from")
+	}
+	if days > timesheetMaxDays {
+		return nil, myerror.ErrValidation.WithDescription("the period of a timesheet must be within a year")
+	}
+
+	entries, err := u.timeEntryRepository.FetchEntriesByUserID(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	timesheet := &domain.Timesheet{
+		From:  domain.DateOnly{Time: start},
+		To:    domain.DateOnly{Time: end.AddDate(0, 0, -1)},
+		Days:  make([]domain.TimesheetDay, days),
+		Tasks: []domain.TimesheetTask{},
+	}
+	for i := range timesheet.Days {
+		timesheet.Days[i].Date = domain.DateOnly{Time: start.AddDate(0, 0, i)}
+	}
+
+	now := time.Now()
+	tasks := map[int]*domain.TimesheetTask{}
+	for _, entry := range entries {
+		entryStart, entryEnd := entry.StartedAt, now
+		if entry.StoppedAt != nil {
+			entryEnd = *entry.StoppedAt
+		}
+		if entryStart.Before(start) {
+			entryStart = start
+		}
+		if entryEnd.After(end) {
+			entryEnd = end
+		}
+
+		task, ok := tasks[entry.TaskID]
+		if !ok {
+			task = &domain.TimesheetTask{TaskID: entry.TaskID, Title: entry.TaskTitle}
+			tasks[entry.TaskID] = task
+		}
+		for cur := entryStart; cur.Before(entryEnd); {
+			day := int(cur.Sub(start) / (24 * time.Hour))
+			next := start.AddDate(0, 0, day+1)
+			if next.After(entryEnd) {
+				next = entryEnd
+			}
+			seconds := int64(next.Sub(cur) / time.Second)
+			timesheet.Days[day].Seconds += seconds
+			task.Seconds += seconds
+			timesheet.TotalSeconds += seconds
+			cur = next
+		}
+	}
+
+	for _, task := range tasks {
+		if task.Seconds > 0 {
+			timesheet.Tasks = append(timesheet.Tasks, *task)
+		}
+	}
+	sort.Slice(timesheet.Tasks, func(i, j int) bool {
+		if timesheet.Tasks[i].Seconds != timesheet.Tasks[j].Seconds {
+			return timesheet.Tasks[i].Seconds > timesheet.Tasks[j].Seconds
+		}
+		return timesheet.Tasks[i].TaskID < timesheet.Tasks[j].TaskID
+	})
+	return timesheet, nil
+}
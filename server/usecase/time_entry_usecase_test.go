@@ -0,0 +1,210 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/transaction"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStartTimer(t *testing.T) {
+	archivedAt := time.Now()
+
+	tests := []struct {
+		title                  string
+		setupMockTaskUsecase   func(*mock.MockTaskUsecase)
+		setupMockTimeEntryRepo func(*mock.MockTimeEntryRepository)
+		wantTaskID             int
+		wantError              error
+	}{
+		{
+			"start a timer",
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 1, 1).Return(&domain.Task{ID: 1}, nil)
+			},
+			func(mockTimeEntryRepo *mock.MockTimeEntryRepository) {
+				mockTimeEntryRepo.EXPECT().FetchRunningEntryByUserID(context.TODO(), 1).Return(nil, myerror.ErrTimeEntryNotFound)
+				mockTimeEntryRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+			},
+			1,
+			nil,
+		},
+		{
+			"the timer running on another task is stopped",
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 1, 1).Return(&domain.Task{ID: 1}, nil)
+			},
+			func(mockTimeEntryRepo *mock.MockTimeEntryRepository) {
+				mockTimeEntryRepo.EXPECT().FetchRunningEntryByUserID(context.TODO(), 1).
+					Return(&domain.TimeEntry{ID: 5, TaskID: 2, UserID: 1}, nil)
+				mockTimeEntryRepo.EXPECT().Stop(context.TODO(), 5, gomock.Any()).Return(nil)
+				mockTimeEntryRepo.EXPECT().Create(context.TODO(), gomock.Any()).Return(nil)
+			},
+			1,
+			nil,
+		},
+		{
+			"the timer running on the task keeps running",
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 1, 1).Return(&domain.Task{ID: 1}, nil)
+			},
+			func(mockTimeEntryRepo *mock.MockTimeEntryRepository) {
+				mockTimeEntryRepo.EXPECT().FetchRunningEntryByUserID(context.TODO(), 1).
+					Return(&domain.TimeEntry{ID: 5, TaskID: 1, UserID: 1}, nil)
+			},
+			1,
+			nil,
+		},
+		{
+			"archived task",
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 1, 1).
+					Return(&domain.Task{ID: 1, ArchivedAt: &archivedAt}, nil)
+			},
+			nil,
+			0,
+			myerror.ErrTaskArchived,
+		},
+		{
+			"permission not found",
+			func(mockTaskUsecase *mock.MockTaskUsecase) {
+				mockTaskUsecase.EXPECT().FetchTaskByTaskID(context.TODO(), 1, 1).Return(nil, myerror.ErrPermissionNotFound)
+			},
+			nil,
+			0,
+			myerror.ErrPermissionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+			mockTimeEntryRepo := mock.NewMockTimeEntryRepository(ctrl)
+
+			tt.setupMockTaskUsecase(mockTaskUsecase)
+			if tt.setupMockTimeEntryRepo != nil {
+				tt.setupMockTimeEntryRepo(mockTimeEntryRepo)
+			}
+
+			// run
+			uc := usecase.NewTimeEntryUsecase(mockTimeEntryRepo, mockTaskUsecase, &transaction.Noop{})
+			entry, err := uc.Start(context.TODO(), 1, 1)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTaskID, entry.TaskID)
+				assert.Nil(t, entry.StoppedAt)
+			}
+		})
+	}
+}
+
+func TestStopTimer(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+	mockTimeEntryRepo := mock.NewMockTimeEntryRepository(ctrl)
+
+	mockTimeEntryRepo.EXPECT().FetchRunningEntryByUserID(context.TODO(), 1).
+		Return(&domain.TimeEntry{ID: 5, TaskID: 2, UserID: 1}, nil).Times(2)
+	mockTimeEntryRepo.EXPECT().Stop(context.TODO(), 5, gomock.Any()).Return(nil)
+
+	uc := usecase.NewTimeEntryUsecase(mockTimeEntryRepo, mockTaskUsecase, &transaction.Noop{})
+
+	// the timer runs on another task
+	_, err := uc.Stop(context.TODO(), 1, 1)
+	assert.ErrorIs(t, err, myerror.ErrTimeEntryNotFound)
+
+	entry, err := uc.Stop(context.TODO(), 2, 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, entry.StoppedAt)
+}
+
+func TestFetchTimesheet(t *testing.T) {
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		title         string
+		from, to      domain.DateOnly
+		entries       []domain.TimeEntry
+		wantTimesheet *domain.Timesheet
+		wantError     error
+	}{
+		{
+			"entries are divided at midnight and cut to the period",
+			domain.MustDateOnly("2025-01-06"),
+			domain.MustDateOnly("2025-01-07"),
+			[]domain.TimeEntry{
+				{TaskID: 1, TaskTitle: "write report", StartedAt: *at(5, 23), StoppedAt: at(6, 1)},
+				{TaskID: 2, TaskTitle: "review", StartedAt: *at(6, 9), StoppedAt: at(6, 12)},
+				{TaskID: 1, TaskTitle: "write report", StartedAt: *at(7, 22), StoppedAt: at(8, 2)},
+			},
+			&domain.Timesheet{
+				From:         domain.MustDateOnly("2025-01-06"),
+				To:           domain.MustDateOnly("2025-01-07"),
+				TotalSeconds: 6 * 3600,
+				Days: []domain.TimesheetDay{
+					{Date: domain.MustDateOnly("2025-01-06"), Seconds: 4 * 3600},
+					{Date: domain.MustDateOnly("2025-01-07"), Seconds: 2 * 3600},
+				},
+				Tasks: []domain.TimesheetTask{
+					{TaskID: 1, Title: "write report", Seconds: 3 * 3600},
+					{TaskID: 2, Title: "review", Seconds: 3 * 3600},
+				},
+			},
+			nil,
+		},
+		{
+			"to before from",
+			domain.MustDateOnly("2025-01-07"),
+			domain.MustDateOnly("2025-01-06"),
+			nil,
+			nil,
+			myerror.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockTaskUsecase := mock.NewMockTaskUsecase(ctrl)
+			mockTimeEntryRepo := mock.NewMockTimeEntryRepository(ctrl)
+
+			if tt.wantError == nil {
+				mockTimeEntryRepo.EXPECT().FetchEntriesByUserID(context.TODO(), 1, tt.from.Time, tt.to.AddDate(0, 0, 1)).
+					Return(tt.entries, nil)
+			}
+
+			// run
+			uc := usecase.NewTimeEntryUsecase(mockTimeEntryRepo, mockTaskUsecase, &transaction.Noop{})
+			timesheet, err := uc.FetchTimesheet(context.TODO(), 1, tt.from, tt.to)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTimesheet, timesheet)
+			}
+		})
+	}
+}
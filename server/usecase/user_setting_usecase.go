@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/keitatwr/task-management-app/domain"
+)
+
+type userSettingUsecase struct {
+	userSettingRepository domain.UserSettingRepository
+}
+
+func NewUserSettingUsecase(userSettingRepo domain.UserSettingRepository) domain.UserSettingUsecase {
+	return &userSettingUsecase{
+		userSettingRepository: userSettingRepo,
+	}
+}
+
+func (u *userSettingUsecase) FetchSettingByUserID(ctx context.Context, userID int) (*domain.UserSetting, error) {
+	return u.userSettingRepository.FetchSettingByUserID(ctx, userID)
+}
+
+func (u *userSettingUsecase) Update(ctx context.Context, userID int, request domain.UserSettingUpdateRequest) (*domain.UserSetting, error) {
+	setting, err := u.userSettingRepository.FetchSettingByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if request.AutoArchiveDays != nil {
+		setting.AutoArchiveDays = *request.AutoArchiveDays
+	}
+	if request.DigestEnabled != nil {
+		setting.DigestEnabled = *request.DigestEnabled
+	}
+	if request.DigestHour != nil {
+		setting.DigestHour = *request.DigestHour
+	}
+	if request.Timezone != nil {
+		setting.Timezone = *request.Timezone
+	}
+	if request.Locale != nil {
+		setting.Locale = *request.Locale
+	}
+	if err := u.userSettingRepository.Save(ctx, setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+func (u *userSettingUsecase) FetchAutoArchiveSettings(ctx context.Context) ([]domain.UserSetting, error) {
+	return u.userSettingRepository.FetchAutoArchiveSettings(ctx)
+}
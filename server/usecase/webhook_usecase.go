@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/webhook"
+)
+
+const (
+	deliveryBatchSize = 20
+	// deliveryLease keeps the claimed deliveries from the other workers while the batch
+	// is sent one after another, it outlasts a batch whose every request times out so
+	// that a delivery in flight is never claimed and sent twice.
+	deliveryLease = deliveryBatchSize*webhook.DefaultTimeout + time.Minute
+)
+
+type webhookUsecase struct {
+	webhookRepository         domain.WebhookRepository
+	webhookDeliveryRepository domain.WebhookDeliveryRepository
+	sender                    webhook.Sender
+}
+
+func NewWebhookUsecase(webhookRepo domain.WebhookRepository,
+	webhookDeliveryRepo domain.WebhookDeliveryRepository,
+	sender webhook.Sender) domain.WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepository:         webhookRepo,
+		webhookDeliveryRepository: webhookDeliveryRepo,
+		sender:                    sender,
+	}
+}
+
+func (u *webhookUsecase) Register(ctx context.Context, userID int, url string, eventTypes []string) (*domain.Webhook, error) {
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, myerror.ErrUnExpected.WrapWithDescription(err, "failed to generate webhook secret")
+	}
+
+	w := &domain.Webhook{
+		UserID:     userID,
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+	if err := u.webhookRepository.Create(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (u *webhookUsecase) FetchWebhooksByUserID(ctx context.Context, userID int) ([]domain.Webhook, error) {
+	webhooks, err := u.webhookRepository.FetchWebhooksByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// the secret is only shown once when the webhook is registered
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (u *webhookUsecase) Delete(ctx context.Context, webhookID, userID int) error {
+	if _, err := u.fetchOwnedWebhook(ctx, webhookID, userID); err != nil {
+		return err
+	}
+	return u.webhookRepository.Delete(ctx, webhookID)
+}
+
+func (u *webhookUsecase) FetchDeliveries(ctx context.Context, webhookID, userID int) ([]domain.WebhookDelivery, error) {
+	if _, err := u.fetchOwnedWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+	return u.webhookDeliveryRepository.FetchDeliveriesByWebhookID(ctx, webhookID)
+}
+
+func (u *webhookUsecase) Redeliver(ctx context.Context, webhookID int, deliveryID int64, userID int) error {
+	if _, err := u.fetchOwnedWebhook(ctx, webhookID, userID); err != nil {
+		return err
+	}
+
+	delivery, err := u.webhookDeliveryRepository.FetchDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery.WebhookID != webhookID {
+		return myerror.ErrWebhookDeliveryNotFound
+	}
+
+	// keep the log of the original delivery and send the payload again as a new one
+	return u.webhookDeliveryRepository.Create(ctx, &domain.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventType:     delivery.EventType,
+		Payload:       delivery.Payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: time.Now(),
+	})
+}
+
+func (u *webhookUsecase) Enqueue(ctx context.Context, event domain.TaskEvent) error {
+	if len(event.Recipients) == 0 {
+		return nil
+	}
+
+	webhooks, err := u.webhookRepository.FetchSubscribedWebhooks(ctx, event.Type, event.Recipients...)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return myerror.ErrUnExpected.WrapWithDescription(err, "failed to marshal webhook payload")
+	}
+
+	for _, w := range webhooks {
+		delivery := &domain.WebhookDelivery{
+			WebhookID:     w.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: time.Now(),
+		}
+		if err := u.webhookDeliveryRepository.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeliverDue sends the deliveries whose next attempt is due and returns how many were processed.
+// A delivery failing to be recorded is logged and the others are still sent, it is claimed
+// again once its lease expires.
+func (u *webhookUsecase) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := u.webhookDeliveryRepository.ClaimDueDeliveries(ctx, deliveryBatchSize, deliveryLease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if err := u.deliver(ctx, delivery); err != nil {
+			logger.E(ctx, "failed to record the webhook delivery", err, "deliveryID", delivery.ID)
+		}
+	}
+	return len(deliveries), nil
+}
+
+func (u *webhookUsecase) deliver(ctx context.Context, delivery domain.WebhookDelivery) error {
+	w, err := u.webhookRepository.FetchWebhookByID(ctx, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrWebhookNotFound) {
+			return u.webhookDeliveryRepository.Update(ctx, delivery.ID, map[string]any{
+				"status":     domain.WebhookDeliveryFailed,
+				"last_error": "webhook not found",
+			})
+		}
+		return err
+	}
+
+	statusCode, sendErr := u.sender.Send(ctx, webhook.Request{
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventType:  string(delivery.EventType),
+		DeliveryID: delivery.ID,
+		Body:       delivery.Payload,
+	})
+
+	attempts := delivery.Attempts + 1
+	updateFields := map[string]any{
+		"attempts":         attempts,
+		"last_status_code": statusCode,
+	}
+
+	switch {
+	case sendErr == nil:
+		updateFields["status"] = domain.WebhookDeliverySucceeded
+		updateFields["last_error"] = ""
+		updateFields["delivered_at"] = time.Now()
+
+	case attempts >= webhook.MaxAttempts:
+		logger.W(ctx, "webhook delivery failed, giving up", sendErr, "deliveryID", delivery.ID)
+		updateFields["status"] = domain.WebhookDeliveryFailed
+		updateFields["last_error"] = sendErr.Error()
+
+	default:
+		logger.I(ctx, "webhook delivery failed, will retry", "deliveryID", delivery.ID, "attempts", attempts)
+		updateFields["last_error"] = sendErr.Error()
+		updateFields["next_attempt_at"] = time.Now().Add(webhook.Backoff(attempts))
+	}
+
+	return u.webhookDeliveryRepository.Update(ctx, delivery.ID, updateFields)
+}
+
+func (u *webhookUsecase) fetchOwnedWebhook(ctx context.Context, webhookID, userID int) (*domain.Webhook, error) {
+	w, err := u.webhookRepository.FetchWebhookByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if w.UserID != userID {
+		return nil, myerror.ErrPermissionDenied
+	}
+	return w, nil
+}
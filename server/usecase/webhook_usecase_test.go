@@ -0,0 +1,217 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/myerror"
+	"github.com/keitatwr/task-management-app/internal/webhook"
+	"github.com/keitatwr/task-management-app/tests/mock"
+	"github.com/keitatwr/task-management-app/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type stubSender struct {
+	statusCode int
+	err        error
+	requests   []webhook.Request
+}
+
+func (s *stubSender) Send(ctx context.Context, req webhook.Request) (int, error) {
+	s.requests = append(s.requests, req)
+	return s.statusCode, s.err
+}
+
+func TestDeliverDueWebhook(t *testing.T) {
+	tests := []struct {
+		title              string
+		attempts           int
+		sender             *stubSender
+		wantStatus         domain.WebhookDeliveryStatus
+		wantNextAttemptSet bool
+	}{
+		{
+			"success",
+			0,
+			&stubSender{statusCode: http.StatusOK},
+			domain.WebhookDeliverySucceeded,
+			false,
+		},
+		{
+			"failed and retry later",
+			0,
+			&stubSender{statusCode: http.StatusInternalServerError, err: errors.New("unexpected status code")},
+			"",
+			true,
+		},
+		{
+			"failed too many times",
+			webhook.MaxAttempts - 1,
+			&stubSender{statusCode: http.StatusInternalServerError, err: errors.New("unexpected status code")},
+			domain.WebhookDeliveryFailed,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWebhookRepo := mock.NewMockWebhookRepository(ctrl)
+			mockWebhookDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+
+			mockWebhookDeliveryRepo.EXPECT().ClaimDueDeliveries(context.TODO(), gomock.Any(), gomock.Any()).
+				Return([]domain.WebhookDelivery{
+					{ID: 10, WebhookID: 1, EventType: domain.TaskEventCreated, Payload: []byte(`{}`), Attempts: tt.attempts},
+				}, nil)
+			mockWebhookRepo.EXPECT().FetchWebhookByID(context.TODO(), 1).
+				Return(&domain.Webhook{ID: 1, URL: "http://example.com/hook", Secret: "secret"}, nil)
+
+			var updateFields map[string]any
+			mockWebhookDeliveryRepo.EXPECT().Update(context.TODO(), int64(10), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int64, fields map[string]any) error {
+					updateFields = fields
+					return nil
+				})
+
+			// run
+			uc := usecase.NewWebhookUsecase(mockWebhookRepo, mockWebhookDeliveryRepo, tt.sender)
+			n, err := uc.DeliverDue(context.TODO())
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, 1, n)
+			assert.Equal(t, []webhook.Request{{
+				URL:        "http://example.com/hook",
+				Secret:     "secret",
+				EventType:  string(domain.TaskEventCreated),
+				DeliveryID: 10,
+				Body:       []byte(`{}`),
+			}}, tt.sender.requests)
+			assert.Equal(t, tt.attempts+1, updateFields["attempts"])
+			assert.Equal(t, tt.sender.statusCode, updateFields["last_status_code"])
+			if tt.wantStatus != "" {
+				assert.Equal(t, tt.wantStatus, updateFields["status"])
+			} else {
+				assert.NotContains(t, updateFields, "status")
+			}
+			_, ok := updateFields["next_attempt_at"]
+			assert.Equal(t, tt.wantNextAttemptSet, ok)
+		})
+	}
+}
+
+func TestDeliverDueWebhookUpdateFailed(t *testing.T) {
+	// mock
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhookRepo := mock.NewMockWebhookRepository(ctrl)
+	mockWebhookDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+	sender := &stubSender{statusCode: http.StatusOK}
+
+	mockWebhookDeliveryRepo.EXPECT().ClaimDueDeliveries(context.TODO(), gomock.Any(), gomock.Any()).
+		Return([]domain.WebhookDelivery{
+			{ID: 10, WebhookID: 1, EventType: domain.TaskEventCreated, Payload: []byte(`{}`)},
+			{ID: 11, WebhookID: 1, EventType: domain.TaskEventUpdated, Payload: []byte(`{}`)},
+		}, nil)
+	mockWebhookRepo.EXPECT().FetchWebhookByID(context.TODO(), 1).
+		Return(&domain.Webhook{ID: 1, URL: "http://example.com/hook", Secret: "secret"}, nil).Times(2)
+	mockWebhookDeliveryRepo.EXPECT().Update(context.TODO(), int64(10), gomock.Any()).Return(myerror.ErrQueryFailed)
+	// the rest of the batch is still delivered
+	mockWebhookDeliveryRepo.EXPECT().Update(context.TODO(), int64(11), gomock.Any()).Return(nil)
+
+	// run
+	uc := usecase.NewWebhookUsecase(mockWebhookRepo, mockWebhookDeliveryRepo, sender)
+	n, err := uc.DeliverDue(context.TODO())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Len(t, sender.requests, 2)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	tests := []struct {
+		title                        string
+		userID                       int
+		setupMockWebhookRepo         func(*mock.MockWebhookRepository)
+		setupMockWebhookDeliveryRepo func(*mock.MockWebhookDeliveryRepository)
+		wantError                    error
+	}{
+		{
+			"success",
+			1,
+			func(mockWebhookRepo *mock.MockWebhookRepository) {
+				mockWebhookRepo.EXPECT().FetchWebhookByID(context.TODO(), 1).
+					Return(&domain.Webhook{ID: 1, UserID: 1}, nil)
+			},
+			func(mockWebhookDeliveryRepo *mock.MockWebhookDeliveryRepository) {
+				mockWebhookDeliveryRepo.EXPECT().FetchDeliveryByID(context.TODO(), int64(10)).
+					Return(&domain.WebhookDelivery{ID: 10, WebhookID: 1, EventType: domain.TaskEventCreated}, nil)
+				mockWebhookDeliveryRepo.EXPECT().Create(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, delivery *domain.WebhookDelivery) error {
+						assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+						assert.Equal(t, domain.TaskEventCreated, delivery.EventType)
+						return nil
+					})
+			},
+			nil,
+		},
+		{
+			"not owner",
+			2,
+			func(mockWebhookRepo *mock.MockWebhookRepository) {
+				mockWebhookRepo.EXPECT().FetchWebhookByID(context.TODO(), 1).
+					Return(&domain.Webhook{ID: 1, UserID: 1}, nil)
+			},
+			nil,
+			myerror.ErrPermissionDenied,
+		},
+		{
+			"delivery of another webhook",
+			1,
+			func(mockWebhookRepo *mock.MockWebhookRepository) {
+				mockWebhookRepo.EXPECT().FetchWebhookByID(context.TODO(), 1).
+					Return(&domain.Webhook{ID: 1, UserID: 1}, nil)
+			},
+			func(mockWebhookDeliveryRepo *mock.MockWebhookDeliveryRepository) {
+				mockWebhookDeliveryRepo.EXPECT().FetchDeliveryByID(context.TODO(), int64(10)).
+					Return(&domain.WebhookDelivery{ID: 10, WebhookID: 2}, nil)
+			},
+			myerror.ErrWebhookDeliveryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// mock
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockWebhookRepo := mock.NewMockWebhookRepository(ctrl)
+			mockWebhookDeliveryRepo := mock.NewMockWebhookDeliveryRepository(ctrl)
+
+			if tt.setupMockWebhookRepo != nil {
+				tt.setupMockWebhookRepo(mockWebhookRepo)
+			}
+			if tt.setupMockWebhookDeliveryRepo != nil {
+				tt.setupMockWebhookDeliveryRepo(mockWebhookDeliveryRepo)
+			}
+
+			// run
+			uc := usecase.NewWebhookUsecase(mockWebhookRepo, mockWebhookDeliveryRepo, &stubSender{})
+			err := uc.Redeliver(context.TODO(), 1, 10, tt.userID)
+
+			// assert
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
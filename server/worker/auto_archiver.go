@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+)
+
+// AutoArchiver archives the completed tasks of the users who enabled auto-archive in their settings.
+type AutoArchiver struct {
+	taskUsecase        domain.TaskUsecase
+	userSettingUsecase domain.UserSettingUsecase
+	interval           time.Duration
+}
+
+func NewAutoArchiver(taskUsecase domain.TaskUsecase, userSettingUsecase domain.UserSettingUsecase, interval time.Duration) *AutoArchiver {
+	return &AutoArchiver{
+		taskUsecase:        taskUsecase,
+		userSettingUsecase: userSettingUsecase,
+		interval:           interval,
+	}
+}
+
+func (a *AutoArchiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		a.archive(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *AutoArchiver) archive(ctx context.Context) {
+	settings, err := a.userSettingUsecase.FetchAutoArchiveSettings(ctx)
+	if err != nil {
+		logger.W(ctx, "failed to fetch auto-archive settings", err)
+		return
+	}
+	for _, s := range settings {
+		n, err := a.taskUsecase.AutoArchive(ctx, s.UserID, time.Duration(s.AutoArchiveDays)*24*time.Hour)
+		if err != nil {
+			logger.W(ctx, "failed to auto-archive tasks", err)
+		}
+		if n > 0 {
+			logger.I(ctx, "auto-archived tasks", "userID", s.UserID, "count", n)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+)
+
+// DigestSender sends the daily digest of the overdue and the due today tasks.
+type DigestSender struct {
+	digestUsecase domain.DigestUsecase
+	interval      time.Duration
+}
+
+func NewDigestSender(digestUsecase domain.DigestUsecase, interval time.Duration) *DigestSender {
+	return &DigestSender{
+		digestUsecase: digestUsecase,
+		interval:      interval,
+	}
+}
+
+func (s *DigestSender) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := s.digestUsecase.SendDueDigests(ctx, time.Now())
+		if err != nil {
+			logger.W(ctx, "failed to send digests", err)
+		}
+		if n > 0 {
+			logger.I(ctx, "sent digests", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+)
+
+// OutboxRelay hands the task events stored in the outbox to the event handlers.
+type OutboxRelay struct {
+	taskEventUsecase domain.TaskEventUsecase
+	interval         time.Duration
+}
+
+func NewOutboxRelay(taskEventUsecase domain.TaskEventUsecase, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		taskEventUsecase: taskEventUsecase,
+		interval:         interval,
+	}
+}
+
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while full batches are relayed
+		for {
+			n, err := r.taskEventUsecase.Relay(ctx)
+			if err != nil {
+				logger.W(ctx, "failed to relay task events", err)
+				break
+			}
+			if n == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+	"github.com/keitatwr/task-management-app/repository"
+)
+
+const reconnectInterval = 5 * time.Second
+
+// TaskEventListener receives the task event IDs notified by every server replica
+// and hands them to the local subscribers.
+type TaskEventListener struct {
+	connect          func(ctx context.Context) (*pgx.Conn, error)
+	taskEventUsecase domain.TaskEventUsecase
+}
+
+func NewTaskEventListener(connect func(ctx context.Context) (*pgx.Conn, error),
+	taskEventUsecase domain.TaskEventUsecase) *TaskEventListener {
+	return &TaskEventListener{
+		connect:          connect,
+		taskEventUsecase: taskEventUsecase,
+	}
+}
+
+func (l *TaskEventListener) Run(ctx context.Context) {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.W(ctx, "task event listener stopped, reconnecting", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+func (l *TaskEventListener) listen(ctx context.Context) error {
+	conn, err := l.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	channel := pgx.Identifier{repository.TaskEventChannel}.Sanitize()
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+	logger.I(ctx, "listening task events")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		eventID, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			logger.W(ctx, "received invalid task event id", err, "payload", notification.Payload)
+			continue
+		}
+		if err := l.taskEventUsecase.Dispatch(ctx, eventID); err != nil {
+			logger.W(ctx, "failed to dispatch task event", err, "eventID", eventID)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+)
+
+// TrashPurger permanently removes the tasks which stayed in the trash longer than the retention.
+type TrashPurger struct {
+	taskUsecase domain.TaskUsecase
+	retention   time.Duration
+	interval    time.Duration
+}
+
+func NewTrashPurger(taskUsecase domain.TaskUsecase, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		taskUsecase: taskUsecase,
+		retention:   retention,
+		interval:    interval,
+	}
+}
+
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while full batches are purged
+		for {
+			n, err := p.taskUsecase.PurgeDeleted(ctx, p.retention)
+			if err != nil {
+				logger.W(ctx, "failed to purge deleted tasks", err)
+				break
+			}
+			if n == 0 {
+				break
+			}
+			logger.I(ctx, "purged deleted tasks", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/keitatwr/task-management-app/domain"
+	"github.com/keitatwr/task-management-app/internal/logger"
+)
+
+// WebhookDispatcher sends the queued webhook deliveries whose next attempt is due.
+type WebhookDispatcher struct {
+	webhookUsecase domain.WebhookUsecase
+	interval       time.Duration
+}
+
+func NewWebhookDispatcher(webhookUsecase domain.WebhookUsecase, interval time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookUsecase: webhookUsecase,
+		interval:       interval,
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while full batches are claimed
+		for {
+			n, err := d.webhookUsecase.DeliverDue(ctx)
+			if err != nil {
+				logger.W(ctx, "failed to deliver webhooks", err)
+				break
+			}
+			if n == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/keitatwr/task-management-app/bootstrap"
+	"github.com/keitatwr/task-management-app/internal/mailer"
+	"github.com/keitatwr/task-management-app/internal/webhook"
+	"github.com/keitatwr/task-management-app/repository"
+	"github.com/keitatwr/task-management-app/usecase"
+)
+
+const (
+	outboxRelayInterval     = time.Second
+	webhookDispatchInterval = 5 * time.Second
+	trashPurgeInterval      = time.Hour
+	autoArchiveInterval     = time.Hour
+	digestInterval          = 5 * time.Minute
+)
+
+// Start runs the background workers until ctx is cancelled.
+func Start(ctx context.Context, app *bootstrap.Application) {
+	db := app.Postgres
+
+	tRepo := repository.NewTaskRepository(db)
+	taRepo := repository.NewTaskActivityRepository(db)
+	fRepo := repository.NewCustomFieldRepository(db)
+	teRepo := repository.NewTaskEventRepository(db)
+	tpRepo := repository.NewTaskPermissionRepository(db)
+	wRepo := repository.NewWebhookRepository(db)
+	wdRepo := repository.NewWebhookDeliveryRepository(db)
+	usRepo := repository.NewUserSettingRepository(db)
+	dRepo := repository.NewDigestRepository(db)
+	uRepo := repository.NewUserReposiotry(db)
+	alRepo := repository.NewTaskAuditLogRepository(db)
+	tnRepo := repository.NewTaskNotificationRepository(db)
+	transaction := repository.NewTransaction(db)
+
+	var m mailer.Mailer = &mailer.LogMailer{}
+	if app.Env.SMTPHost != "" {
+		m = &mailer.SMTPMailer{
+			Host:     app.Env.SMTPHost,
+			Port:     app.Env.SMTPPort,
+			Username: app.Env.SMTPUsername,
+			Password: app.Env.SMTPPassword,
+			From:     app.Env.MailFrom,
+		}
+	}
+
+	wu := usecase.NewWebhookUsecase(wRepo, wdRepo, webhook.NewHTTPSender(webhook.DefaultTimeout))
+	teu := usecase.NewTaskEventUsecase(teRepo, tpRepo, transaction, app.EventHub,
+		usecase.NewTaskEventHandler("webhook", wu.Enqueue),
+		usecase.NewTaskSearchIndexHandler(tRepo),
+		usecase.NewTaskAuditHandler(alRepo),
+		usecase.NewTaskAssignmentNotifier(uRepo, usRepo, tnRepo, m),
+	)
+
+	relay := NewOutboxRelay(teu, outboxRelayInterval)
+	go relay.Run(ctx)
+
+	listener := NewTaskEventListener(
+		func(ctx context.Context) (*pgx.Conn, error) {
+			return bootstrap.NewPostgresListenerConn(ctx, app.Env)
+		},
+		teu,
+	)
+	go listener.Run(ctx)
+
+	dispatcher := NewWebhookDispatcher(wu, webhookDispatchInterval)
+	go dispatcher.Run(ctx)
+
+	tu := usecase.NewTaskUsecase(tRepo, tpRepo, taRepo, fRepo, usRepo, teu, transaction)
+
+	retention := time.Duration(app.Env.TrashRetentionDays) * 24 * time.Hour
+	purger := NewTrashPurger(tu, retention, trashPurgeInterval)
+	go purger.Run(ctx)
+
+	archiver := NewAutoArchiver(tu, usecase.NewUserSettingUsecase(usRepo), autoArchiveInterval)
+	go archiver.Run(ctx)
+
+	digestSender := NewDigestSender(usecase.NewDigestUsecase(dRepo, m), digestInterval)
+	go digestSender.Run(ctx)
+}